@@ -0,0 +1,108 @@
+package blocks
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+func (r *Renderer) RenderAbbreviation(b Block) string {
+	return fmt.Sprintf("<abbr%s%s>%s</abbr>", r.attrs(ElementAbbreviation), titleAttr(deref(b.Title)), r.internalRender(b.Children))
+}
+
+// titleAttr returns the title attribute including the leading space, or
+// nothing for empty titles.
+func titleAttr(title string) string {
+	if title == "" {
+		return ""
+	}
+	return fmt.Sprintf(` title="%s"`, html.EscapeString(title))
+}
+
+// Abbreviations returns a transformer marking the abbreviations of the
+// glossary in all texts, the glossary maps abbreviations to their
+// expansion. Only whole words are matched, case sensitive. Code, links and
+// existing abbreviations are left as they are.
+func Abbreviations(glossary map[string]string) Transformer {
+	words := make([]string, 0, len(glossary))
+	for w := range glossary {
+		if strings.TrimSpace(w) != "" {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 {
+		return TransformerFunc(func(blocks []Block) []Block { return blocks })
+	}
+	// longer abbreviations first, so they win over their prefixes
+	slices.SortFunc(words, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		quoted = append(quoted, regexp.QuoteMeta(w))
+	}
+	pattern := regexp.MustCompile(`(^|[^\pL\pN])(` + strings.Join(quoted, "|") + `)($|[^\pL\pN])`)
+
+	var abbreviate func(blocks []Block) []Block
+	abbreviate = func(blocks []Block) []Block {
+		if blocks == nil {
+			return nil
+		}
+		out := make([]Block, 0, len(blocks))
+		for _, b := range blocks {
+			switch b.Type {
+			case BlockTypeCode, BlockTypeLink, BlockTypeAbbreviation:
+				out = append(out, b)
+			case BlockTypeText:
+				out = append(out, abbreviateText(b, pattern, glossary)...)
+			default:
+				b.Children = abbreviate(b.Children)
+				out = append(out, b)
+			}
+		}
+		return out
+	}
+	return TransformerFunc(abbreviate)
+}
+
+// abbreviateText splits the text block at the abbreviations, the parts keep
+// the modifiers of the block.
+func abbreviateText(b Block, pattern *regexp.Regexp, glossary map[string]string) []Block {
+	if b.Text == nil || b.HasModifier(ModifierCode) {
+		return []Block{b}
+	}
+	text := *b.Text
+	part := func(s string) Block {
+		p := b
+		p.Text = &s
+		return p
+	}
+
+	out := []Block{}
+	start, last := 0, 0
+	// the boundaries are part of the match, so adjacent abbreviations are
+	// found one by one
+	for {
+		loc := pattern.FindStringSubmatchIndex(text[last:])
+		if loc == nil {
+			break
+		}
+		from, to := last+loc[4], last+loc[5]
+		if from > start {
+			out = append(out, part(text[start:from]))
+		}
+		title := glossary[text[from:to]]
+		out = append(out, Block{Type: BlockTypeAbbreviation, Title: &title, Children: []Block{part(text[from:to])}})
+		start, last = to, to
+	}
+	if start == 0 {
+		return []Block{b}
+	}
+	if start < len(text) {
+		out = append(out, part(text[start:]))
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderAbbreviation(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("Rendered with ")},
+		{Type: BlockTypeAbbreviation, Title: ptr(`Hypertext "Markup" Language`), Children: []Block{
+			{Type: BlockTypeText, Text: ptr("HTML")},
+		}},
+	}}}
+
+	assert.Equal(t, `<p>
+  Rendered with
+  <abbr title="Hypertext &#34;Markup&#34; Language">
+    HTML
+  </abbr>
+</p>`, Render(doc))
+	assert.Equal(t, "Rendered with HTML", PlainText(doc))
+}
+
+func TestAbbreviations(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("CMS and CMSs, a headless CMS")},
+			link("/cms", "CMS"),
+			{Type: BlockTypeText, Text: ptr("CMS"), Code: ptr(true)},
+		}},
+		codeBlock("", "CMS"),
+	}
+
+	got := Abbreviations(map[string]string{"CMS": "Content Management System", "": "empty"}).Transform(doc)
+	abbr := func(text string) Block {
+		return Block{Type: BlockTypeAbbreviation, Title: ptr("Content Management System"), Children: []Block{
+			{Type: BlockTypeText, Text: ptr(text)},
+		}}
+	}
+	assert.Equal(t, []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			abbr("CMS"),
+			{Type: BlockTypeText, Text: ptr(" and CMSs, a headless ")},
+			abbr("CMS"),
+			link("/cms", "CMS"),
+			{Type: BlockTypeText, Text: ptr("CMS"), Code: ptr(true)},
+		}},
+		codeBlock("", "CMS"),
+	}, got)
+
+	assert.Equal(t, doc, Abbreviations(nil).Transform(doc))
+}
@@ -0,0 +1,64 @@
+package blocks
+
+import (
+	"net/url"
+	"strings"
+)
+
+// LinkAnalytics decorates outbound links for analytics. Links are outbound
+// when they are absolute http(s) urls to a host not in InternalHosts.
+type LinkAnalytics struct {
+	InternalHosts []string
+	// Attributes returns the attributes of an outbound link, defaults to
+	// OutboundAttributes.
+	Attributes func(u *url.URL) AttributeList
+	// Redirect returns the href of an outbound link, for tracking redirects
+	// like "/out?url=...". Without it the link is kept.
+	Redirect func(link string) string
+}
+
+// WithLinkAnalytics applies the analytics to every outbound link.
+func WithLinkAnalytics(a LinkAnalytics) Option {
+	if a.Attributes == nil {
+		a.Attributes = OutboundAttributes
+	}
+	return func(r *Renderer) {
+		r.linkAnalytics = &a
+	}
+}
+
+// OutboundAttributes marks the link with data-outbound and its host without
+// www. as data-domain.
+func OutboundAttributes(u *url.URL) AttributeList {
+	return AttributeList{
+		{Name: "data-outbound", Value: "true"},
+		{Name: "data-domain", Value: strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")},
+	}
+}
+
+// RedirectURL returns a Redirect passing the link as query parameter to the
+// tracking endpoint, like RedirectURL("/out", "url").
+func RedirectURL(endpoint, param string) func(link string) string {
+	return func(link string) string {
+		sep := "?"
+		if strings.Contains(endpoint, "?") {
+			sep = "&"
+		}
+		return endpoint + sep + url.QueryEscape(param) + "=" + url.QueryEscape(link)
+	}
+}
+
+// outbound returns the href and the analytics attributes of the link
+func (r *Renderer) outbound(link, href string) (string, AttributeList) {
+	if r.linkAnalytics == nil || !isExternal(link, r.linkAnalytics.InternalHosts) {
+		return href, nil
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return href, nil
+	}
+	if r.linkAnalytics.Redirect != nil {
+		href = r.linkAnalytics.Redirect(link)
+	}
+	return href, r.linkAnalytics.Attributes(u)
+}
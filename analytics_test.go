@@ -0,0 +1,29 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkAnalytics(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		link("https://www.Example.com/a?b=1", "external"),
+		link("https://blog.cdreier.de/post", "internal"),
+		link("/about", "relative"),
+	}}}
+
+	r := New(WithLinkAnalytics(LinkAnalytics{InternalHosts: []string{"blog.cdreier.de"}, Redirect: RedirectURL("/out", "url")}))
+	assert.Equal(t, `<p>
+  <a href="/out?url=https%3A%2F%2Fwww.Example.com%2Fa%3Fb%3D1" data-outbound="true" data-domain="example.com">
+    external
+  </a>
+  <a href="https://blog.cdreier.de/post">
+    internal
+  </a>
+  <a href="/about">
+    relative
+  </a>
+</p>`, r.Render(doc))
+}
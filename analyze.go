@@ -0,0 +1,44 @@
+package blocks
+
+import (
+	"unicode/utf8"
+)
+
+// Report holds statistics about a document, for example to enforce editorial
+// rules in CI.
+type Report struct {
+	// Types counts the blocks per type
+	Types map[BlockType]int
+	// MaxDepth is the deepest nesting, top level blocks have depth 1
+	MaxDepth int
+	// TextLength is the number of characters of all texts
+	TextLength    int
+	Links         int
+	ExternalLinks int
+	Images        int
+}
+
+// Analyze collects the statistics of the blocks. Links are external when they
+// are absolute http(s) urls to a host not in the list of internal hosts.
+func Analyze(blocks []Block, internalHosts ...string) Report {
+	report := Report{Types: map[BlockType]int{}}
+	Walk(blocks, func(path []int, b Block) bool {
+		report.Types[b.Type]++
+		report.MaxDepth = max(report.MaxDepth, len(path))
+		switch b.Type {
+		case BlockTypeText:
+			report.TextLength += utf8.RuneCountInString(deref(b.Text))
+		case BlockTypeLink:
+			report.Links++
+			if isExternal(deref(b.URL), internalHosts) {
+				report.ExternalLinks++
+			}
+		case BlockTypeImage:
+			report.Images++
+		case BlockTypeGallery:
+			report.Images += len(b.Images)
+		}
+		return true
+	})
+	return report
+}
@@ -0,0 +1,35 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+
+	doc := []Block{
+		heading(1, "Touché"),
+		{Type: BlockTypeParagraph, Children: []Block{
+			link("https://example.com/a", "a"),
+			link("https://cms.example.org/b", "b"),
+			link("/c", "c"),
+		}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg"}, Children: []Block{{Type: BlockTypeText, Text: ptr("")}}},
+	}
+
+	assert.Equal(t, Report{
+		Types: map[BlockType]int{
+			BlockTypeHeading:   1,
+			BlockTypeParagraph: 1,
+			BlockTypeLink:      3,
+			BlockTypeImage:     1,
+			BlockTypeText:      5,
+		},
+		MaxDepth:      3,
+		TextLength:    9,
+		Links:         3,
+		ExternalLinks: 1,
+		Images:        1,
+	}, Analyze(doc, "cms.example.org"))
+}
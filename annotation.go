@@ -0,0 +1,45 @@
+package blocks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnnotationMode decides how editorial annotations are rendered.
+type AnnotationMode int
+
+const (
+	// AnnotationsStrip leaves annotations out, for production
+	AnnotationsStrip AnnotationMode = iota
+	// AnnotationsComment renders annotations as html comments
+	AnnotationsComment
+	// AnnotationsVisible renders annotations as <aside>, or <span> in running
+	// text, for draft previews. The class is set with ElementAnnotation.
+	AnnotationsVisible
+)
+
+// WithAnnotations sets how annotation blocks are rendered, without the option
+// they are stripped.
+func WithAnnotations(mode AnnotationMode) Option {
+	return func(r *Renderer) {
+		r.annotations = mode
+	}
+}
+
+// RenderAnnotation renders the editorial comments of annotation blocks, they
+// are not part of the plain text of their parents.
+func (r *Renderer) RenderAnnotation(b Block) string {
+	switch r.annotations {
+	case AnnotationsComment:
+		// hyphens are replaced by U+2010, so the text cannot form -->, --!>
+		// or <!--, the spaces keep a leading > or trailing <! apart
+		text := strings.ReplaceAll(b.PlainText(), "-", "\u2010")
+		return fmt.Sprintf("<!-- %s -->", text)
+	case AnnotationsVisible:
+		if r.inlineImage() {
+			return fmt.Sprintf(`<span%s role="note">%s</span>`, r.attrs(ElementAnnotation), r.internalRender(b.Children))
+		}
+		return fmt.Sprintf(`<aside%s role="note">%s</aside>`, r.attrs(ElementAnnotation), r.internalRender(b.Children))
+	}
+	return ""
+}
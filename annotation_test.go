@@ -0,0 +1,45 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderAnnotation(t *testing.T) {
+
+	raw := []byte(`[
+		{"type": "paragraph", "children": [
+			{"type": "text", "text": "Revenue grew"},
+			{"type": "annotation", "children": [{"type": "text", "text": "check -- source?"}]},
+			{"type": "text", "text": " by 10%."}
+		]},
+		{"type": "annotation", "children": [{"type": "text", "text": "Add a chart"}]},
+		{"type": "annotation", "children": [{"type": "text", "text": "q3 numbers ---> leaked"}]},
+		{"type": "annotation", "children": [{"type": "text", "text": "->--!><!-"}]}
+	]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "<p>\n  Revenue grew by 10%.\n</p>", New().Render(doc))
+	assert.Equal(t, `<p>
+  Revenue grew
+  <!-- check ‐‐ source? -->
+  by 10%.
+</p>
+<!-- Add a chart -->
+<!-- q3 numbers ‐‐‐> leaked -->
+<!-- ‐>‐‐!><!‐ -->`, New(WithAnnotations(AnnotationsComment)).Render(doc))
+	assert.Equal(t, `<p>
+  Revenue grew
+  <span class="annotation" role="note">
+    check -- source?
+  </span>
+  by 10%.
+</p>
+<aside class="annotation" role="note">
+  Add a chart
+</aside>`, New(WithAnnotations(AnnotationsVisible)).Render(doc[:2]))
+	assert.Equal(t, "Revenue grew by 10%.", PlainText(doc))
+}
@@ -0,0 +1,62 @@
+package blocks
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AccessibilityOptions configures WithAccessibility.
+type AccessibilityOptions struct {
+	// LinkLabel labels links without text, like icon links. It defaults to
+	// the host of the url.
+	LinkLabel func(url string) string
+	// IDPrefix prefixes the generated ids, to keep them unique if several
+	// documents are rendered into one page
+	IDPrefix string
+}
+
+// WithAccessibility labels links without text, adds roles to math and
+// diagram fallbacks and wires figures to their captions with
+// aria-describedby.
+func WithAccessibility(opts AccessibilityOptions) Option {
+	if opts.LinkLabel == nil {
+		opts.LinkLabel = defaultLinkLabel
+	}
+	return func(r *Renderer) {
+		r.a11y = &opts
+	}
+}
+
+func defaultLinkLabel(link string) string {
+	if u, err := url.Parse(link); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return link
+}
+
+// ariaLink labels links without text
+func (r *Renderer) ariaLink(url, text string) AttributeList {
+	if r.a11y == nil || strings.TrimSpace(text) != "" {
+		return nil
+	}
+	return AttributeList{{Name: "aria-label", Value: r.a11y.LinkLabel(url)}}
+}
+
+// ariaRole returns the role and label of elements without accessible text
+func (r *Renderer) ariaRole(role, label string) string {
+	if r.a11y == nil {
+		return ""
+	}
+	return AttributeList{{Name: "role", Value: role}, {Name: "aria-label", Value: label}}.String()
+}
+
+// ariaCaption returns the attributes of a figure and its caption
+func (r *Renderer) ariaCaption() (figure, caption string) {
+	if r.a11y == nil {
+		return "", ""
+	}
+	r.ids++
+	id := fmt.Sprintf("%scaption-%d", r.a11y.IDPrefix, r.ids)
+	return AttributeList{{Name: "aria-describedby", Value: id}}.String(), AttributeList{{Name: "id", Value: id}}.String()
+}
@@ -0,0 +1,38 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAccessibility(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			link("https://github.com/cdreier", ""),
+			link("/about", "about"),
+		}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", Caption: "first"}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/b.png", Caption: "second"}},
+		{Type: BlockTypeMath, Children: []Block{{Type: BlockTypeText, Text: ptr("a^2")}}},
+	}
+
+	r := New(WithAccessibility(AccessibilityOptions{IDPrefix: "post-"}))
+	out := r.Render(doc)
+	assert.Contains(t, out, `<a href="https://github.com/cdreier" aria-label="github.com">`)
+	assert.Contains(t, out, `<a href="/about">`)
+	assert.Contains(t, out, `<figure aria-describedby="post-caption-1">`)
+	assert.Contains(t, out, `<figcaption id="post-caption-1">`)
+	assert.Contains(t, out, `<figcaption id="post-caption-2">`)
+	assert.Contains(t, out, `<div class="math display" role="math" aria-label="a^2">`)
+
+	assert.Equal(t, out, r.Render(doc), "ids restart with every render")
+
+	r = New(WithMermaid(nil), WithAccessibility(AccessibilityOptions{LinkLabel: func(url string) string { return "icon" }}))
+	out = r.Render([]Block{codeBlock("mermaid", "graph TD"), {Type: BlockTypeParagraph, Children: []Block{link("/x", " ")}}})
+	assert.Contains(t, out, `<pre class="mermaid" role="img" aria-label="diagram">`)
+	assert.Contains(t, out, `aria-label="icon"`)
+
+	assert.NotContains(t, Render(doc), "aria")
+}
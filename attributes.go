@@ -0,0 +1,131 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+)
+
+// Attribute is an extra html attribute. Attributes without value are
+// rendered as boolean attributes, like itemscope.
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+type AttributeList []Attribute
+
+func (l AttributeList) String() string {
+	out := strings.Builder{}
+	for _, a := range l {
+		if a.Value == "" {
+			fmt.Fprintf(&out, " %s", a.Name)
+			continue
+		}
+		fmt.Fprintf(&out, ` %s="%s"`, a.Name, html.EscapeString(a.Value))
+	}
+	return out.String()
+}
+
+// Attributes maps elements to extra attributes they are rendered with.
+type Attributes map[Element]AttributeList
+
+// WithAttributes adds attributes to the given elements, after the attributes
+// added before.
+func WithAttributes(a Attributes) Option {
+	return func(r *Renderer) {
+		if r.attributes == nil {
+			r.attributes = Attributes{}
+		}
+		for el, attrs := range a {
+			// clipped, so renderers cloned with With do not share the list
+			r.attributes[el] = append(slices.Clip(r.attributes[el]), attrs...)
+		}
+	}
+}
+
+// WithMicrodata annotates the output with schema.org microdata for articles,
+// the document is the articleBody and images are images of the article.
+// Without a theme wrapper the document is wrapped in a div, so WithMicrodata
+// has to come after WithTheme.
+func WithMicrodata() Option {
+	microdata := WithAttributes(Attributes{
+		ElementDocument: {{Name: "itemprop", Value: "articleBody"}},
+		ElementImage:    {{Name: "itemprop", Value: "image"}},
+	})
+	return func(r *Renderer) {
+		if r.wrapper == "" {
+			r.wrapper = "div"
+		}
+		microdata(r)
+	}
+}
+
+// BlockAttributes maps block types to extra attributes of their main element,
+// like the <img> of images and the <pre> of code blocks.
+type BlockAttributes map[BlockType]AttributeList
+
+// blockTypeElements are the main elements of the block types, html blocks
+// only have the wrapper of embedded iframes
+var blockTypeElements = map[BlockType]Element{
+	BlockTypeParagraph:             ElementParagraph,
+	BlockTypeHeading:               ElementHeading,
+	BlockTypeList:                  ElementList,
+	BlockTypeListItem:              ElementListItem,
+	BlockTypeLink:                  ElementLink,
+	BlockTypeImage:                 ElementImage,
+	BlockTypeQuote:                 ElementQuote,
+	BlockTypeCode:                  ElementPre,
+	BlockTypeMention:               ElementMention,
+	BlockTypeAbbreviation:          ElementAbbreviation,
+	BlockTypeDefinitionList:        ElementDefinitionList,
+	BlockTypeDefinitionTerm:        ElementDefinitionTerm,
+	BlockTypeDefinitionDescription: ElementDefinitionDescription,
+	BlockTypeDetails:               ElementDetails,
+	BlockTypeColumns:               ElementColumns,
+	BlockTypeColumn:                ElementColumn,
+	BlockTypeGallery:               ElementGallery,
+	BlockTypeHTML:                  ElementEmbed,
+}
+
+// WithBlockAttributes adds attributes to the blocks of the given types, for
+// behaviour attached declaratively like htmx or alpine.js. See HTMX and
+// Alpine. Types without a fixed element, like text and custom types, are
+// ignored.
+func WithBlockAttributes(a BlockAttributes) Option {
+	attrs := Attributes{}
+	for t, list := range a {
+		if el, ok := blockTypeElements[t]; ok {
+			attrs[el] = list
+		}
+	}
+	return WithAttributes(attrs)
+}
+
+// HTMX returns the htmx attributes, the names get the hx- prefix. For example
+// HTMX("get", "/embeds/consent", "trigger", "revealed") lazy loads content
+// when the element is scrolled into view.
+func HTMX(pairs ...string) AttributeList {
+	return prefixed("hx-", pairs)
+}
+
+// Alpine returns the alpine.js attributes, the names get the x- prefix, like
+// Alpine("data", "{ open: false }").
+func Alpine(pairs ...string) AttributeList {
+	return prefixed("x-", pairs)
+}
+
+// prefixed turns name value pairs into attributes, a missing last value is
+// rendered as boolean attribute
+func prefixed(prefix string, pairs []string) AttributeList {
+	list := AttributeList{}
+	for i := 0; i < len(pairs); i += 2 {
+		a := Attribute{Name: prefix + pairs[i]}
+		if i+1 < len(pairs) {
+			a.Value = pairs[i+1]
+		}
+		list = append(list, a)
+	}
+	return list
+}
@@ -0,0 +1,73 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAttributes(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quote")}}},
+		{Type: BlockTypeParagraph, Children: []Block{link("https://example.com", "link")}},
+	}
+
+	r := New(
+		WithClasses(Classes{ElementQuote: "pull"}),
+		WithAttributes(Attributes{ElementQuote: {{Name: "data-kind", Value: `"x"`}}}),
+		WithAttributes(Attributes{ElementQuote: {{Name: "hidden"}}, ElementLink: {{Name: "rel", Value: "nofollow"}}}),
+	)
+	assert.Equal(t, `<blockquote class="pull" data-kind="&#34;x&#34;" hidden>
+  quote
+</blockquote>
+<p>
+  <a href="https://example.com" rel="nofollow">
+    link
+  </a>
+</p>`, r.Render(doc))
+
+	derived := r.With(WithAttributes(Attributes{ElementLink: {{Name: "target", Value: "_blank"}}}))
+	assert.Contains(t, derived.Render(doc), `rel="nofollow" target="_blank"`)
+	assert.NotContains(t, r.Render(doc), `target`)
+}
+
+func TestWithMicrodata(t *testing.T) {
+
+	doc := []Block{
+		paragraph("text"),
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png"}},
+	}
+
+	assert.Equal(t, `<div itemprop="articleBody">
+  <p>
+    text
+  </p>
+  <img src="/a.png" alt="" itemprop="image" />
+</div>`, New(WithMicrodata()).Render(doc))
+
+	out := New(WithTheme(ThemeTailwindProse), WithMicrodata()).Render(doc)
+	assert.Contains(t, out, `<article class="prose" itemprop="articleBody">`)
+}
+
+func TestWithBlockAttributes(t *testing.T) {
+
+	embed := `<iframe src="https://www.youtube-nocookie.com/embed/abc"></iframe>`
+	doc := []Block{
+		{Type: BlockTypeDetails, Summary: ptr("More"), Children: []Block{}},
+		{Type: BlockTypeHTML, HTML: &embed},
+	}
+	r := New(WithRawHTML(nil), WithEmbeds(EmbedOptions{Hosts: []string{"www.youtube-nocookie.com"}}), WithBlockAttributes(BlockAttributes{
+		BlockTypeDetails: Alpine("data", "{ open: false }", "cloak"),
+		BlockTypeHTML:    HTMX("get", "/embeds/consent", "trigger", "revealed"),
+		BlockTypeText:    HTMX("boost", "true"),
+	}))
+	assert.Equal(t, `<details x-data="{ open: false }" x-cloak>
+  <summary>
+    More
+  </summary>
+</details>
+<div class="embed" style="aspect-ratio: 16 / 9;" hx-get="/embeds/consent" hx-trigger="revealed">
+  <iframe src="https://www.youtube-nocookie.com/embed/abc" sandbox="allow-scripts allow-same-origin allow-presentation allow-popups" referrerpolicy="strict-origin-when-cross-origin" class="embed-frame"></iframe>
+</div>`, r.Render(doc))
+}
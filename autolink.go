@@ -0,0 +1,95 @@
+package blocks
+
+import (
+	"regexp"
+	"strings"
+)
+
+var autoLinkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+|\b[\w.+-]+@[\w-]+(?:\.[\w-]+)*\.[a-z]{2,}\b`)
+
+// AutoLink returns a transformer turning bare urls and email addresses in
+// texts into links. Urls starting with www. link to https, trailing
+// punctuation is not part of the link. Code and existing links are left as
+// they are.
+func AutoLink() Transformer {
+	var autoLink func(blocks []Block) []Block
+	autoLink = func(blocks []Block) []Block {
+		if blocks == nil {
+			return nil
+		}
+		out := make([]Block, 0, len(blocks))
+		for _, b := range blocks {
+			switch b.Type {
+			case BlockTypeCode, BlockTypeLink:
+				out = append(out, b)
+			case BlockTypeText:
+				out = append(out, autoLinkText(b)...)
+			default:
+				b.Children = autoLink(b.Children)
+				out = append(out, b)
+			}
+		}
+		return out
+	}
+	return TransformerFunc(autoLink)
+}
+
+// autoLinkText splits the text block at the urls, the parts keep the
+// modifiers of the block.
+func autoLinkText(b Block) []Block {
+	if b.Text == nil || b.HasModifier(ModifierCode) {
+		return []Block{b}
+	}
+	text := *b.Text
+	part := func(s string) Block {
+		p := b
+		p.Text = &s
+		return p
+	}
+
+	out := []Block{}
+	start := 0
+	for _, loc := range autoLinkPattern.FindAllStringIndex(text, -1) {
+		from, to := loc[0], loc[0]+len(trimURL(text[loc[0]:loc[1]]))
+		if from < start || from == to {
+			continue
+		}
+		if from > start {
+			out = append(out, part(text[start:from]))
+		}
+		match := text[from:to]
+		url := match
+		switch {
+		case strings.Contains(match, "@") && !strings.Contains(match, "/"):
+			url = "mailto:" + match
+		case strings.HasPrefix(strings.ToLower(match), "www."):
+			url = "https://" + match
+		}
+		out = append(out, Block{Type: BlockTypeLink, URL: &url, Children: []Block{part(match)}})
+		start = to
+	}
+	if start == 0 {
+		return []Block{b}
+	}
+	if start < len(text) {
+		out = append(out, part(text[start:]))
+	}
+	return out
+}
+
+// trimURL removes trailing punctuation, closing parentheses are kept when
+// the url has the opening one, like wikipedia links do.
+func trimURL(url string) string {
+	for url != "" {
+		last := url[len(url)-1]
+		switch {
+		case strings.IndexByte(".,;:!?'*", last) >= 0:
+			url = url[:len(url)-1]
+		case last == ')' && strings.Count(url, "(") < strings.Count(url, ")"):
+			url = url[:len(url)-1]
+		default:
+			return url
+		}
+	}
+	return url
+}
@@ -0,0 +1,44 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoLink(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("See https://example.com/a?b=1. Or (www.example.org), mail info@example.com!"), Bold: ptr(true)},
+			{Type: BlockTypeText, Text: ptr("https://example.com/code"), Code: ptr(true)},
+			link("https://example.com", "https://example.com"),
+			{Type: BlockTypeText, Text: ptr(" https://en.wikipedia.org/wiki/Go_(language)")},
+		}},
+		codeBlock("", "https://example.com"),
+	}
+
+	text := func(s string) Block {
+		return Block{Type: BlockTypeText, Text: ptr(s), Bold: ptr(true)}
+	}
+	autoLink := func(url, s string) Block {
+		return Block{Type: BlockTypeLink, URL: ptr(url), Children: []Block{text(s)}}
+	}
+	got := AutoLink().Transform(doc)
+	assert.Equal(t, []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			text("See "),
+			autoLink("https://example.com/a?b=1", "https://example.com/a?b=1"),
+			text(". Or ("),
+			autoLink("https://www.example.org", "www.example.org"),
+			text("), mail "),
+			autoLink("mailto:info@example.com", "info@example.com"),
+			text("!"),
+			{Type: BlockTypeText, Text: ptr("https://example.com/code"), Code: ptr(true)},
+			link("https://example.com", "https://example.com"),
+			{Type: BlockTypeText, Text: ptr(" ")},
+			link("https://en.wikipedia.org/wiki/Go_(language)", "https://en.wikipedia.org/wiki/Go_(language)"),
+		}},
+		codeBlock("", "https://example.com"),
+	}, got)
+}
@@ -0,0 +1,235 @@
+package blocks
+
+import (
+	"context"
+	"strings"
+)
+
+// Backend produces values of type T for blocks, for example gomponents nodes
+// or pdf elements. Build does the traversal, so a backend only creates the
+// value for a single block out of its already built children.
+type Backend[T any] interface {
+	Paragraph(b Block, children []T) T
+	// LineBreak is built for paragraphs with a single empty text
+	LineBreak(b Block) T
+	Text(b Block, text string) T
+	Modifier(m Modifier, b Block, content T) T
+	List(b Block, format ListFormat, children []T) T
+	ListItem(b Block, children []T) T
+	Heading(b Block, level int, children []T) T
+	// Link gets the link as RenderLink emits it, with the attributes of the
+	// link policy, analytics and accessibility options
+	Link(b Block, link ResolvedLink, children []T) T
+	Image(b Block, img Image) T
+	Gallery(b Block, images []Image) T
+	Quote(b Block, children []T) T
+	Code(b Block, children []T) T
+	// Mention gets the profile url of the user, empty without
+	// WithMentionResolver or when the user cannot be resolved
+	Mention(b Block, m Mention, url string) T
+	// Math is built for math blocks and for texts marked as math, which are
+	// not displayed as a block
+	Math(b Block, tex string, display bool) T
+	// HTML is built for html blocks with WithRawHTML, with the html as the
+	// renderer emits it
+	HTML(b Block, html string) T
+	// Annotation is built unless annotations are stripped, see
+	// WithAnnotations
+	Annotation(b Block, children []T) T
+	Abbreviation(b Block, title string, children []T) T
+	DefinitionList(b Block, children []T) T
+	DefinitionTerm(b Block, children []T) T
+	DefinitionDescription(b Block, children []T) T
+	Details(b Block, summary string, children []T) T
+	Columns(b Block, children []T) T
+	Column(b Block, children []T) T
+	// CrossReference and Citation are built for references NumberFigures
+	// and Citations did not resolve, with the key they reference
+	CrossReference(b Block, target string) T
+	Citation(b Block, key string) T
+	// Figure wraps the images numbered by NumberFigures, id is the anchor
+	// the cross-references link to
+	Figure(b Block, id string, children []T) T
+	// References is the section Citations appends, Reference its entries
+	// with the anchor the citations link to
+	References(b Block, title string, children []T) T
+	Reference(b Block, id string, children []T) T
+	// Mark wraps the matches of Highlight
+	Mark(b Block, children []T) T
+	// Fallback is built for invalid blocks, unsupported block types and
+	// blocks failing with WithErrorBoundaries
+	Fallback(b Block, message string) T
+}
+
+// Build returns one value per block, built with the defaults of New. See
+// BuildWith for the options of a renderer.
+func Build[T any](be Backend[T], blocks []Block) []T {
+	return builder[T]{be: be, r: New()}.build(blocks)
+}
+
+// BuildBlock builds a single block and its children.
+func BuildBlock[T any](be Backend[T], b Block) T {
+	return builder[T]{be: be, r: New()}.block(b)
+}
+
+// BuildWith is Build with the renderer, it makes the decisions of rendering:
+// the limits, the transformers, the modifier order, variables, links as
+// RenderLink resolves them, mentions, raw html, annotations, empty
+// paragraphs and the warnings, see Renderer.Warnings. Panics are recovered
+// with WithRecover and WithErrorBoundaries, a failed block is built as
+// Fallback. Custom block renderers, themes, classes and other options of the
+// markup only apply to the string output.
+func BuildWith[T any](r *Renderer, be Backend[T], blocks []Block) (out []T, err error) {
+	r.begin()
+	if err := r.checkLimits(blocks); err != nil {
+		return nil, err
+	}
+	blocks = r.transform(blocks)
+	defer r.recoverRender(blocks, &err)
+	return builder[T]{be: be, r: r}.build(blocks), nil
+}
+
+// builder walks the blocks like internalRender, keeping the path of the
+// renderer for its warnings
+type builder[T any] struct {
+	be Backend[T]
+	r  *Renderer
+}
+
+func (bd builder[T]) build(blocks []Block) []T {
+	r := bd.r
+	out := make([]T, 0, len(blocks))
+	for i, b := range blocks {
+		if r.collapsed(blocks, i) || b.emptyParagraph() && r.emptyParagraphs == EmptyParagraphSkip {
+			continue
+		}
+		r.path = append(r.path, i)
+		if r.isReadMore(b) || b.Type == BlockTypeAnnotation && r.annotations == AnnotationsStrip {
+			r.path = r.path[:len(r.path)-1]
+			continue
+		}
+		out = append(out, bd.block(b))
+		r.path = r.path[:len(r.path)-1]
+	}
+	return out
+}
+
+func (bd builder[T]) block(b Block) (out T) {
+	if bd.r.errorBoundaries {
+		defer bd.errorBoundary(bd.r.ctx, b, len(bd.r.path), &out)
+	}
+	return bd.dispatch(b)
+}
+
+// errorBoundary is Renderer.errorBoundary building the fallback
+func (bd builder[T]) errorBoundary(ctx context.Context, b Block, depth int, out *T) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	*out = bd.be.Fallback(b, bd.r.blockFailed(ctx, b, depth, p).Error())
+}
+
+func (bd builder[T]) dispatch(b Block) T {
+	be, r := bd.be, bd.r
+	switch b.Type {
+	case BlockTypeParagraph:
+		if b.emptyParagraph() {
+			if r.emptyParagraphs == EmptyParagraphNbsp {
+				return be.Paragraph(b, []T{be.Text(b.Children[0], "\u00a0")})
+			}
+			return be.LineBreak(b)
+		}
+		return be.Paragraph(b, bd.build(b.Children))
+	case BlockTypeText:
+		if b.Text == nil {
+			return be.Text(b, r.missingText(b))
+		}
+		text := r.substitute(*b.Text, func(s string) string { return s })
+		var out T
+		if b.Math != nil && *b.Math {
+			out = be.Math(b, text, false)
+		} else {
+			out = be.Text(b, text)
+		}
+		for _, m := range r.modifiers {
+			if b.HasModifier(m) {
+				out = be.Modifier(m, b, out)
+			}
+		}
+		return out
+	case BlockTypeList:
+		format, ok := r.listFormat(b)
+		if !ok {
+			return be.Fallback(b, "unsupported list")
+		}
+		return be.List(b, format, bd.build(b.Children))
+	case BlockTypeListItem:
+		return be.ListItem(b, bd.build(b.Children))
+	case BlockTypeHeading:
+		level, ok := headingLevel(b)
+		if !ok {
+			return be.Text(b, strings.TrimSpace(b.PlainText()))
+		}
+		return be.Heading(b, level, bd.build(b.Children))
+	case BlockTypeLink:
+		return be.Link(b, r.resolveLinkBlock(b), bd.build(b.Children))
+	case BlockTypeImage:
+		if !r.hasMedia(b) {
+			return be.Fallback(b, "missing image")
+		}
+		return be.Image(b, *b.Image)
+	case BlockTypeQuote:
+		return be.Quote(b, bd.build(b.Children))
+	case BlockTypeCode:
+		return be.Code(b, bd.build(b.Children))
+	case BlockTypeMention:
+		url, ok := r.mentionURL(b)
+		if !ok {
+			return be.Fallback(b, "missing mention")
+		}
+		return be.Mention(b, *b.Mention, url)
+	case BlockTypeMath:
+		return be.Math(b, b.PlainText(), true)
+	case BlockTypeHTML:
+		if !r.rawHTMLEnabled() {
+			return be.Fallback(b, "html blocks are not enabled")
+		}
+		return be.HTML(b, r.RenderHTML(b))
+	case BlockTypeAnnotation:
+		return be.Annotation(b, bd.build(b.Children))
+	case BlockTypeAbbreviation:
+		return be.Abbreviation(b, deref(b.Title), bd.build(b.Children))
+	case BlockTypeDefinitionList:
+		return be.DefinitionList(b, bd.build(b.Children))
+	case BlockTypeDefinitionTerm:
+		return be.DefinitionTerm(b, bd.build(b.Children))
+	case BlockTypeDefinitionDescription:
+		return be.DefinitionDescription(b, bd.build(b.Children))
+	case BlockTypeGallery:
+		if !r.hasMedia(b) {
+			return be.Fallback(b, "missing image")
+		}
+		return be.Gallery(b, b.Images)
+	case BlockTypeColumns:
+		return be.Columns(b, bd.build(b.Children))
+	case BlockTypeColumn:
+		return be.Column(b, bd.build(b.Children))
+	case BlockTypeDetails:
+		return be.Details(b, deref(b.Summary), bd.build(b.Children))
+	case BlockTypeCrossReference:
+		return be.CrossReference(b, r.unresolvedReference(b))
+	case BlockTypeCitation:
+		return be.Citation(b, r.unresolvedReference(b))
+	case blockTypeAnchor:
+		return be.Figure(b, deref(b.URL), bd.build(b.Children))
+	case blockTypeReferences:
+		return be.References(b, deref(b.Title), bd.build(b.Children))
+	case blockTypeReference:
+		return be.Reference(b, deref(b.URL), bd.build(b.Children))
+	case blockTypeMark:
+		return be.Mark(b, bd.build(b.Children))
+	}
+	r.unsupportedBlock(b)
+	return be.Fallback(b, "unsupported block type")
+}
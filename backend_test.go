@@ -0,0 +1,283 @@
+package blocks
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// outline builds a compact string outline of the block tree
+type outline struct{}
+
+func (outline) children(name string, children []string) string {
+	return name + "(" + strings.Join(children, ",") + ")"
+}
+
+func (o outline) Paragraph(b Block, children []string) string       { return o.children("p", children) }
+func (outline) LineBreak(b Block) string                            { return "br" }
+func (outline) Text(b Block, text string) string                    { return fmt.Sprintf("%q", text) }
+func (outline) Modifier(m Modifier, b Block, content string) string { return string(m) + ":" + content }
+func (o outline) List(b Block, format ListFormat, children []string) string {
+	return o.children(string(format), children)
+}
+func (o outline) ListItem(b Block, children []string) string { return o.children("li", children) }
+func (o outline) Heading(b Block, level int, children []string) string {
+	return o.children(fmt.Sprintf("h%d", level), children)
+}
+func (o outline) Link(b Block, link ResolvedLink, children []string) string {
+	out := o.children("a "+link.Href+link.Attributes.String(), children)
+	if link.Printed != "" {
+		out += " (" + link.Printed + ")"
+	}
+	return out
+}
+func (outline) Image(b Block, img Image) string { return "img " + img.URL }
+func (outline) Gallery(b Block, images []Image) string {
+	urls := []string{}
+	for _, img := range images {
+		urls = append(urls, img.URL)
+	}
+	return "gallery " + strings.Join(urls, " ")
+}
+func (o outline) Quote(b Block, children []string) string { return o.children("quote", children) }
+func (o outline) Code(b Block, children []string) string  { return o.children("code", children) }
+func (outline) Mention(b Block, m Mention, url string) string {
+	return strings.TrimSpace("@" + m.Label() + " " + url)
+}
+func (outline) Math(b Block, tex string, display bool) string {
+	if display {
+		return "math " + tex
+	}
+	return "inline math " + tex
+}
+func (outline) HTML(b Block, html string) string { return "html " + html }
+func (o outline) Annotation(b Block, children []string) string {
+	return o.children("note", children)
+}
+func (o outline) Abbreviation(b Block, title string, children []string) string {
+	return o.children("abbr "+title, children)
+}
+func (o outline) DefinitionList(b Block, children []string) string { return o.children("dl", children) }
+func (o outline) DefinitionTerm(b Block, children []string) string { return o.children("dt", children) }
+func (o outline) DefinitionDescription(b Block, children []string) string {
+	return o.children("dd", children)
+}
+func (o outline) Details(b Block, summary string, children []string) string {
+	return o.children("details "+summary, children)
+}
+func (o outline) Columns(b Block, children []string) string  { return o.children("columns", children) }
+func (o outline) Column(b Block, children []string) string   { return o.children("column", children) }
+func (outline) CrossReference(b Block, target string) string { return "xref " + target }
+func (outline) Citation(b Block, key string) string          { return "cite " + key }
+func (o outline) Figure(b Block, id string, children []string) string {
+	return o.children("figure "+id, children)
+}
+func (o outline) References(b Block, title string, children []string) string {
+	return o.children("refs "+title, children)
+}
+func (o outline) Reference(b Block, id string, children []string) string {
+	return o.children("ref "+id, children)
+}
+func (o outline) Mark(b Block, children []string) string { return o.children("mark", children) }
+func (outline) Fallback(b Block, message string) string  { return "!" + message }
+
+func TestBuild(t *testing.T) {
+
+	doc := []Block{
+		heading(2, "Title"),
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("a"), Bold: ptr(true), Code: ptr(true)},
+			link("/b", "b"),
+		}},
+		paragraph(""),
+		{Type: BlockTypeList, Format: ptr("ordered"), Children: []Block{
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeText, Text: ptr("c")}}},
+		}},
+		{Type: BlockTypeList, Format: ptr("bullets")},
+		{Type: BlockTypeHeading, Level: ptr(9), Children: []Block{{Type: BlockTypeText, Text: ptr(" d ")}}},
+		{Type: BlockTypeImage},
+		{Type: "video"},
+	}
+
+	assert.Equal(t, []string{
+		`h2("Title")`,
+		`p(code:bold:"a",a /b("b"))`,
+		`br`,
+		`ordered(li("c"))`,
+		`!unsupported list`,
+		`"d"`,
+		`!missing image`,
+		`!unsupported block type`,
+	}, Build(outline{}, doc))
+}
+
+func TestBuild_BlockTypes(t *testing.T) {
+
+	// every block type, the bibliography is only rendered by Citations
+	text := func(s string) []Block { return []Block{{Type: BlockTypeText, Text: ptr(s)}} }
+	built := map[BlockType]string{
+		BlockTypeParagraph:             `p("a")`,
+		BlockTypeText:                  `"a"`,
+		BlockTypeList:                  `unordered(li("a"))`,
+		BlockTypeLink:                  `a /a("a")`,
+		BlockTypeListItem:              `li("a")`,
+		BlockTypeHeading:               `h3("a")`,
+		BlockTypeImage:                 `img /a.jpg`,
+		BlockTypeQuote:                 `quote("a")`,
+		BlockTypeCode:                  `code("a")`,
+		BlockTypeMention:               `@rob`,
+		BlockTypeMath:                  `math a`,
+		BlockTypeAbbreviation:          `abbr b("a")`,
+		BlockTypeDefinitionList:        `dl("a")`,
+		BlockTypeDefinitionTerm:        `dt("a")`,
+		BlockTypeDefinitionDescription: `dd("a")`,
+		BlockTypeDetails:               `details b("a")`,
+		BlockTypeColumns:               `columns("a")`,
+		BlockTypeColumn:                `column("a")`,
+		BlockTypeHTML:                  `!html blocks are not enabled`,
+		BlockTypeGallery:               `gallery /a.jpg`,
+		BlockTypeCrossReference:        `xref fig`,
+		BlockTypeCitation:              `cite fig`,
+		BlockTypeBibliography:          `!unsupported block type`,
+		BlockTypeBibliographyEntry:     `!unsupported block type`,
+		BlockTypeAnnotation:            ``,
+	}
+	for bt, expected := range built {
+		b := Block{Type: bt, Children: text("a")}
+		switch bt {
+		case BlockTypeText:
+			b = text("a")[0]
+		case BlockTypeList:
+			b.Format = ptr("unordered")
+			b.Children = []Block{{Type: BlockTypeListItem, Children: text("a")}}
+		case BlockTypeLink:
+			b.URL = ptr("/a")
+		case BlockTypeHeading:
+			b.Level = ptr(3)
+		case BlockTypeImage:
+			b.Image = &Image{URL: "/a.jpg"}
+		case BlockTypeGallery:
+			b.Images = []Image{{URL: "/a.jpg"}}
+		case BlockTypeMention:
+			b.Mention = &Mention{Username: "rob"}
+		case BlockTypeAbbreviation:
+			b.Title = ptr("b")
+		case BlockTypeDetails:
+			b.Summary = ptr("b")
+		case BlockTypeCrossReference, BlockTypeCitation:
+			b.Target = ptr("fig")
+		case BlockTypeAnnotation:
+			assert.Empty(t, Build(outline{}, []Block{b}), "annotations are stripped")
+			continue
+		}
+		assert.Equal(t, expected, BuildBlock(outline{}, b), bt)
+	}
+}
+
+func TestBuildWith(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("Hi {{name}}, "), Bold: ptr(true), Italic: ptr(true)},
+			{Type: BlockTypeText, Text: ptr("x^2"), Math: ptr(true)},
+			{Type: BlockTypeMention, Mention: &Mention{Username: "rob"}},
+			{Type: BlockTypeCitation, Target: ptr("knuth74")},
+		}},
+		{Type: BlockTypeImage, Image: &Image{Name: "arch.png", URL: "/arch.png"}},
+		{Type: BlockTypeHTML, HTML: ptr("<b>html</b>")},
+		{Type: BlockTypeAnnotation, Children: []Block{{Type: BlockTypeText, Text: ptr("todo")}}},
+	}
+	r := New(
+		WithModifierOrder(ModifierItalic, ModifierBold),
+		WithVariables(map[string]string{"name": "<Ann>"}, MissingVariableKeep),
+		WithMentionResolver(MentionResolverFunc(func(m Mention) (string, bool) { return "/u/" + m.Username, true })),
+		WithRawHTML(nil),
+		WithAnnotations(AnnotationsVisible),
+		WithTransformers(
+			Highlight("hi"),
+			NumberFigures(FigureOptions{}),
+			Citations(CitationOptions{References: []Reference{{Key: "knuth74", Text: "Knuth"}}}),
+		),
+	)
+	out, err := BuildWith(r, outline{}, doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`p(mark(bold:italic:"Hi"),bold:italic:" <Ann>, ",inline math x^2,@rob /u/rob,a #ref-knuth74("[1]"))`,
+		`figure fig-arch(img /arch.png)`,
+		`html <b>html</b>`,
+		`note("todo")`,
+		`refs References(ref ref-knuth74("Knuth"))`,
+	}, out)
+	assert.Empty(t, r.Warnings())
+}
+
+func TestBuildWithLinks(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		link("https://example.com/a", "a"),
+		{Type: BlockTypeLink, URL: ptr("https://example.com/b"), Children: []Block{{Type: BlockTypeText, Text: ptr("")}}},
+		{Type: BlockTypeLink, Children: []Block{{Type: BlockTypeText, Text: ptr("c")}}},
+	}}}
+	r := New(
+		WithLinkPolicy(LinkPolicy{ExternalTarget: "_blank"}),
+		WithLinkAnalytics(LinkAnalytics{Redirect: RedirectURL("/out", "url")}),
+		WithAccessibility(AccessibilityOptions{}),
+		WithPrint(),
+	)
+	out, err := BuildWith(r, outline{}, doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`p(` +
+			`a /out?url=https%3A%2F%2Fexample.com%2Fa target="_blank" rel="noopener noreferrer" data-outbound="true" data-domain="example.com"("a") (https://example.com/a),` +
+			`a /out?url=https%3A%2F%2Fexample.com%2Fb target="_blank" rel="noopener noreferrer" data-outbound="true" data-domain="example.com" aria-label="example.com"("") (https://example.com/b),` +
+			`a #("c"))`,
+	}, out)
+	assert.Equal(t, []Warning{{Code: WarningBrokenLink, Message: "link without url", Path: []int{0, 2}}}, r.Warnings())
+}
+
+func TestBuildWithLimits(t *testing.T) {
+
+	r := New(WithLimits(Limits{MaxBlocks: 1}))
+	out, err := BuildWith(r, outline{}, []Block{paragraph("a")})
+	assert.Nil(t, out)
+	var limit *LimitError
+	assert.ErrorAs(t, err, &limit)
+	assert.Equal(t, WarningLimitExceeded, r.Warnings()[0].Code)
+}
+
+func TestBuildWithRecover(t *testing.T) {
+
+	doc := []Block{paragraph("a"), {Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeText, Text: ptr("b")}, {Type: BlockTypeText}}}}
+
+	r := New(WithNilSafe(false), WithRecover(true))
+	out, err := BuildWith(r, outline{}, doc)
+	assert.Nil(t, out)
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	assert.Equal(t, []int{1, 1}, renderErr.Path)
+
+	r = New(WithNilSafe(false), WithErrorBoundaries(nil))
+	out, err = BuildWith(r, outline{}, doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`p("a")`, `p("b",!blocks: rendering text block [1 1] panicked: blocks: text block without text)`}, out)
+	assert.Len(t, r.Errors(), 1)
+}
+
+func TestBuildWithEmptyParagraphs(t *testing.T) {
+
+	empty := Block{Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeText, Text: ptr("")}}}
+	doc := []Block{paragraph("a"), empty, empty, paragraph("b")}
+
+	for mode, expected := range map[EmptyParagraph][]string{
+		EmptyParagraphBreak:    {`p("a")`, `br`, `br`, `p("b")`},
+		EmptyParagraphNbsp:     {`p("a")`, `p("\u00a0")`, `p("\u00a0")`, `p("b")`},
+		EmptyParagraphSkip:     {`p("a")`, `p("b")`},
+		EmptyParagraphCollapse: {`p("a")`, `br`, `p("b")`},
+	} {
+		out, err := BuildWith(New(WithEmptyParagraphs(mode)), outline{}, doc)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, out, mode)
+	}
+}
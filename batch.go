@@ -0,0 +1,105 @@
+package blocks
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Document is an entry rendered by RenderBatch.
+type Document struct {
+	// ID identifies the document in the results, like the strapi documentId
+	ID     string
+	Blocks []Block
+}
+
+// Result is the rendered document of RenderBatch.
+type Result struct {
+	ID       string
+	HTML     string
+	Warnings []Warning
+	// Err is set when the document could not be rendered, for example when
+	// it exceeds the limits, a panic was recovered or the context was
+	// canceled before it was rendered
+	Err error
+}
+
+// WithBatchConcurrency sets the number of documents RenderBatch renders in
+// parallel, defaults to GOMAXPROCS.
+func WithBatchConcurrency(n int) Option {
+	return func(r *Renderer) {
+		r.batchConcurrency = n
+	}
+}
+
+// RenderBatch renders many documents with the configuration of the
+// renderer, for static exports of whole collections. Every worker renders
+// with its own copy of the renderer, see With, so block renderers must be
+// safe for concurrent use. The results are in the order of the documents, a
+// failed document does not stop the batch.
+func (r *Renderer) RenderBatch(ctx context.Context, docs []Document) []Result {
+	workers := r.batchConcurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(docs))
+
+	results := make([]Result, len(docs))
+	next := make(chan int)
+	wg := sync.WaitGroup{}
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker := r.With()
+			for i := range next {
+				results[i] = worker.renderDocument(ctx, docs[i])
+			}
+		}()
+	}
+	for i, doc := range docs {
+		if ctx.Err() != nil {
+			results[i] = Result{ID: doc.ID, Err: ctx.Err()}
+			continue
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+func RenderBatch(ctx context.Context, docs []Document) []Result {
+	return New().RenderBatch(ctx, docs)
+}
+
+func (r *Renderer) renderDocument(ctx context.Context, doc Document) Result {
+	if err := ctx.Err(); err != nil {
+		return Result{ID: doc.ID, Err: err}
+	}
+	out, err := r.render(ctx, doc.Blocks)
+	return Result{ID: doc.ID, HTML: out, Warnings: r.warnings, Err: err}
+}
+
+// CountWarnings aggregates the warnings of the results by code, for the
+// summary of an export.
+func CountWarnings(results []Result) map[string]int {
+	counts := map[string]int{}
+	for _, res := range results {
+		for _, w := range res.Warnings {
+			counts[w.Code]++
+		}
+	}
+	return counts
+}
+
+// Failed returns the results of the documents which could not be rendered.
+func Failed(results []Result) []Result {
+	failed := []Result{}
+	for _, res := range results {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
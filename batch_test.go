@@ -0,0 +1,45 @@
+package blocks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderBatch(t *testing.T) {
+
+	docs := []Document{}
+	for i := range 50 {
+		docs = append(docs, Document{ID: fmt.Sprint(i), Blocks: []Block{paragraph(fmt.Sprintf("doc %d", i))}})
+	}
+	docs = append(docs,
+		Document{ID: "image", Blocks: []Block{{Type: BlockTypeImage}}},
+		Document{ID: "large", Blocks: []Block{paragraph("a"), paragraph("b")}},
+	)
+
+	r := New(WithLimits(Limits{MaxBlocks: 3}), WithBatchConcurrency(4))
+	results := r.RenderBatch(context.Background(), docs)
+	assert.Len(t, results, len(docs))
+	for i := range 50 {
+		assert.Equal(t, fmt.Sprint(i), results[i].ID)
+		assert.Equal(t, fmt.Sprintf("<p>\n  doc %d\n</p>", i), results[i].HTML)
+		assert.NoError(t, results[i].Err)
+	}
+	assert.Equal(t, map[string]int{WarningMissingImage: 1, WarningLimitExceeded: 1}, CountWarnings(results))
+	failed := Failed(results)
+	assert.Len(t, failed, 1)
+	assert.Equal(t, "large", failed[0].ID)
+	assert.ErrorIs(t, failed[0].Err, ErrLimitExceeded)
+}
+
+func TestRenderBatchCanceled(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := RenderBatch(ctx, []Document{{ID: "a", Blocks: []Block{paragraph("a")}}, {ID: "b"}})
+	assert.Len(t, Failed(results), 2)
+	assert.ErrorIs(t, results[1].Err, context.Canceled)
+	assert.Equal(t, "b", results[1].ID)
+}
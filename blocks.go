@@ -1,10 +1,14 @@
 package blocks
 
 import (
+	"context"
 	"fmt"
+	"html"
+	"io"
+	"log/slog"
 	"strings"
 
-	"github.com/yosssi/gohtml"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type BlockType string
@@ -18,6 +22,22 @@ const BlockTypeHeading BlockType = "heading"
 const BlockTypeImage BlockType = "image"
 const BlockTypeQuote BlockType = "quote"
 const BlockTypeCode BlockType = "code"
+const BlockTypeMention BlockType = "mention"
+const BlockTypeMath BlockType = "math"
+const BlockTypeAbbreviation BlockType = "abbreviation"
+const BlockTypeDefinitionList BlockType = "definition-list"
+const BlockTypeDefinitionTerm BlockType = "definition-term"
+const BlockTypeDefinitionDescription BlockType = "definition-description"
+const BlockTypeDetails BlockType = "details"
+const BlockTypeColumns BlockType = "columns"
+const BlockTypeColumn BlockType = "column"
+const BlockTypeHTML BlockType = "html"
+const BlockTypeGallery BlockType = "gallery"
+const BlockTypeCrossReference BlockType = "cross-reference"
+const BlockTypeCitation BlockType = "citation"
+const BlockTypeBibliography BlockType = "bibliography"
+const BlockTypeBibliographyEntry BlockType = "bibliography-entry"
+const BlockTypeAnnotation BlockType = "annotation"
 
 type ListFormat string
 
@@ -25,24 +45,71 @@ const ListFormatUnordered ListFormat = "unordered"
 const ListFormatOrdered ListFormat = "ordered"
 
 type Block struct {
-	Type          BlockType `json:"type"`
-	Children      []Block   `json:"children"`
-	Text          *string   `json:"text"`
-	Italic        *bool     `json:"italic"`
-	Underline     *bool     `json:"underline"`
-	Bold          *bool     `json:"bold"`
-	StrikeThrough *bool     `json:"strikethrough"`
-	Code          *bool     `json:"code"`
-	Format        *string   `json:"format"`
-	URL           *string   `json:"url"`
-	Level         *int      `json:"level"`
-	Image         *Image    `json:"image"`
+	Type           BlockType `json:"type"`
+	Children       []Block   `json:"children"`
+	Text           *string   `json:"text"`
+	Italic         *bool     `json:"italic"`
+	Underline      *bool     `json:"underline"`
+	Bold           *bool     `json:"bold"`
+	StrikeThrough  *bool     `json:"strikethrough"`
+	Code           *bool     `json:"code"`
+	Superscript    *bool     `json:"superscript"`
+	Subscript      *bool     `json:"subscript"`
+	Keyboard       *bool     `json:"kbd"`
+	Highlight      *Mark     `json:"highlight"`
+	Color          *string   `json:"color"`
+	Background     *string   `json:"backgroundColor"`
+	Math           *bool     `json:"math"`
+	Format         *string   `json:"format"`
+	URL            *string   `json:"url"`
+	Level          *int      `json:"level"`
+	Image          *Image    `json:"image"`
+	Language       *string   `json:"language"`
+	HighlightLines *string   `json:"highlightLines"`
+	Mention        *Mention  `json:"mention"`
+	Title          *string   `json:"title"`
+	Summary        *string   `json:"summary"`
+	Open           *bool     `json:"open"`
+	HTML           *string   `json:"html"`
+	Images         []Image   `json:"images"`
+	Target         *string   `json:"target"`
 }
 
 type Image struct {
-	Name            string `json:"name"`
-	AlternativeText string `json:"alternativeText"`
-	URL             string `json:"url"`
+	Name            string                 `json:"name"`
+	AlternativeText string                 `json:"alternativeText"`
+	URL             string                 `json:"url"`
+	Caption         string                 `json:"caption"`
+	Width           int                    `json:"width"`
+	Height          int                    `json:"height"`
+	PreviewURL      string                 `json:"previewUrl"`
+	Blurhash        string                 `json:"blurhash"`
+	Mime            string                 `json:"mime"`
+	Formats         map[string]ImageFormat `json:"formats"`
+	// Dark is the variant of the image for dark mode, see DarkImageField
+	Dark *Image `json:"dark"`
+}
+
+// ImageFormat is a derived version of an uploaded image, like the
+// thumbnail or small formats strapi generates.
+type ImageFormat struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Mime   string `json:"mime"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// BlockRenderer renders any block type. It returns false for blocks it
+// leaves to the next renderer, see WithBlockRenderers.
+type BlockRenderer interface {
+	RenderBlock(Block) (string, bool)
+}
+
+type BlockRendererFunc func(Block) (string, bool)
+
+func (f BlockRendererFunc) RenderBlock(b Block) (string, bool) {
+	return f(b)
 }
 
 type ParagraphRenderer interface {
@@ -72,21 +139,140 @@ type QuoteRenderer interface {
 type CodeRenderer interface {
 	RenderCode(Block) string
 }
+type MentionRenderer interface {
+	RenderMention(Block) string
+}
+type MathRenderer interface {
+	RenderMath(Block) string
+}
+type HTMLRenderer interface {
+	RenderHTML(Block) string
+}
+type GalleryRenderer interface {
+	RenderGallery(Block) string
+}
+type AnnotationRenderer interface {
+	RenderAnnotation(Block) string
+}
+type AbbreviationRenderer interface {
+	RenderAbbreviation(Block) string
+}
+type DefinitionListRenderer interface {
+	RenderDefinitionList(Block) string
+}
+type DefinitionTermRenderer interface {
+	RenderDefinitionTerm(Block) string
+}
+type DefinitionDescriptionRenderer interface {
+	RenderDefinitionDescription(Block) string
+}
+type DetailsRenderer interface {
+	RenderDetails(Block) string
+}
+type ColumnsRenderer interface {
+	RenderColumns(Block) string
+}
+type ColumnRenderer interface {
+	RenderColumn(Block) string
+}
 
+// Renderer renders blocks to html. It keeps the warnings of the last render,
+// so a single Renderer must not be used concurrently.
 type Renderer struct {
-	ParagraphRenderer ParagraphRenderer
-	TextRenderer      TextRenderer
-	ListRenderer      ListRenderer
-	ListItemRenderer  ListItemRenderer
-	HeadingRenderer   HeadingRenderer
-	LinkRenderer      LinkRenderer
-	ImageRenderer     ImageRenderer
-	QuoteRenderer     QuoteRenderer
-	CodeRenderer      CodeRenderer
-}
-
-func New() *Renderer {
-	r := &Renderer{}
+	ParagraphRenderer             ParagraphRenderer
+	TextRenderer                  TextRenderer
+	ListRenderer                  ListRenderer
+	ListItemRenderer              ListItemRenderer
+	HeadingRenderer               HeadingRenderer
+	LinkRenderer                  LinkRenderer
+	ImageRenderer                 ImageRenderer
+	QuoteRenderer                 QuoteRenderer
+	CodeRenderer                  CodeRenderer
+	MentionRenderer               MentionRenderer
+	MathRenderer                  MathRenderer
+	AbbreviationRenderer          AbbreviationRenderer
+	DefinitionListRenderer        DefinitionListRenderer
+	DefinitionTermRenderer        DefinitionTermRenderer
+	DefinitionDescriptionRenderer DefinitionDescriptionRenderer
+	DetailsRenderer               DetailsRenderer
+	ColumnsRenderer               ColumnsRenderer
+	ColumnRenderer                ColumnRenderer
+	HTMLRenderer                  HTMLRenderer
+	GalleryRenderer               GalleryRenderer
+	AnnotationRenderer            AnnotationRenderer
+	ModifierRenderer              ModifierRenderer
+
+	nilSafe      bool
+	placeholders Placeholders
+	lqip         bool
+	aspectRatio  bool
+	picture      *PictureOptions
+	classes      Classes
+	styles       Styles
+	attributes   Attributes
+	wrapper      string
+	quoteFigure  bool
+	nonce        string
+
+	imageURLBuilder  ImageURLBuilder
+	linkRewriters    []func(url, text string) string
+	linkResolver     LinkResolver
+	linkFallback     string
+	mentionResolver  MentionResolver
+	mathRenderer     func(tex string, display bool) (string, error)
+	mermaid          bool
+	diagramRenderer  func(src string) (string, error)
+	lineNumbers      bool
+	highlightLines   func(b Block) []int
+	codeCopy         *CodeCopyOptions
+	tracer           trace.Tracer
+	spanTypes        map[BlockType]bool
+	logger           *slog.Logger
+	transformers     []Transformer
+	variables        map[string]string
+	missingVariable  MissingVariable
+	readMore         ReadMoreMarker
+	limits           Limits
+	recoverPanics    bool
+	errorBoundaries  bool
+	failedBlock      func(err *RenderError) string
+	components       map[string]ComponentRenderer
+	a11y             *AccessibilityOptions
+	blockRenderers   []BlockRenderer
+	modifiers        []Modifier
+	reactCompat      bool
+	emptyParagraphs  EmptyParagraph
+	textColors       *TextColorOptions
+	linkPolicy       *LinkPolicy
+	rawHTML          bool
+	sanitizer        Sanitizer
+	embeds           *EmbedOptions
+	gallery          GalleryOptions
+	print            bool
+	darkImage        func(img Image) string
+	validateHTML     bool
+	serialization    *Serialization
+	componentPrefix  string
+	linkAnalytics    *LinkAnalytics
+	annotations      AnnotationMode
+	batchConcurrency int
+
+	ctx      context.Context
+	path     []int
+	types    []BlockType
+	field    string
+	ids      int
+	warnings []Warning
+	errors   []*RenderError
+}
+
+func New(opts ...Option) *Renderer {
+	r := &Renderer{
+		nilSafe:      true,
+		placeholders: DefaultPlaceholders,
+		classes:      defaultClasses(),
+		modifiers:    DefaultModifierOrder,
+	}
 	r.ParagraphRenderer = r
 	r.TextRenderer = r
 	r.ListRenderer = r
@@ -96,13 +282,62 @@ func New() *Renderer {
 	r.ImageRenderer = r
 	r.QuoteRenderer = r
 	r.CodeRenderer = r
+	r.MentionRenderer = r
+	r.MathRenderer = r
+	r.AbbreviationRenderer = r
+	r.DefinitionListRenderer = r
+	r.DefinitionTermRenderer = r
+	r.DefinitionDescriptionRenderer = r
+	r.DetailsRenderer = r
+	r.ColumnsRenderer = r
+	r.ColumnRenderer = r
+	r.HTMLRenderer = r
+	r.GalleryRenderer = r
+	r.AnnotationRenderer = r
+	r.ModifierRenderer = r
+
+	for _, opt := range opts {
+		opt(r)
+	}
 
 	return r
 }
 
 func (r *Renderer) Render(blocks []Block) string {
-	out := r.internalRender(blocks)
-	return gohtml.Format(out)
+	return r.RenderContext(context.Background(), blocks)
+}
+
+// RenderContext is Render with a context, which is the parent of the tracing
+// spans.
+func (r *Renderer) RenderContext(ctx context.Context, blocks []Block) string {
+	out, _ := r.render(ctx, blocks)
+	return out
+}
+
+func (r *Renderer) render(ctx context.Context, blocks []Block) (string, error) {
+	return r.renderMarkup(ctx, blocks, r.format)
+}
+
+// renderMarkup is the render path shared by the render methods producing a
+// whole document, format is applied to the html of the renderer but not to
+// the output of WithReactCompat.
+func (r *Renderer) renderMarkup(ctx context.Context, blocks []Block, format func(string) string) (out string, err error) {
+	r.begin()
+	if err := r.checkLimits(blocks); err != nil {
+		return "", err
+	}
+	blocks = r.transform(blocks)
+	ctx, span := r.startRenderSpan(ctx, blocks)
+	// the span ends after a recovered panic as well
+	defer func() { r.endRenderSpan(span, out, err) }()
+	defer r.recoverRender(blocks, &err)
+	r.ctx = ctx
+	if r.reactCompat {
+		return reactRender(blocks), nil
+	} else if r.componentPrefix != "" {
+		return format(r.document(r.componentRender(blocks))), nil
+	}
+	return format(r.document(r.internalRender(blocks))), nil
 }
 
 func Render(blocks []Block) string {
@@ -110,15 +345,56 @@ func Render(blocks []Block) string {
 	return r.Render(blocks)
 }
 
+// RenderChildren renders the children of the block, for custom renderers
+// recursing into the tree. It must only be called while the renderer is
+// rendering, paths and warnings are kept.
+func (r *Renderer) RenderChildren(b Block) string {
+	return r.internalRender(b.Children)
+}
+
+// WriteChildren is RenderChildren writing to w.
+func (r *Renderer) WriteChildren(w io.Writer, b Block) error {
+	_, err := io.WriteString(w, r.internalRender(b.Children))
+	return err
+}
+
 func (r *Renderer) internalRender(blocks []Block) string {
 	out := strings.Builder{}
-	for _, block := range blocks {
+	for i, block := range blocks {
+		if r.collapsed(blocks, i) {
+			continue
+		}
+		r.path = append(r.path, i)
 		out.WriteString(r.renderBlock(block))
+		r.path = r.path[:len(r.path)-1]
 	}
 	return out.String()
 }
 
-func (r *Renderer) renderBlock(b Block) string {
+func (r *Renderer) renderBlock(b Block) (out string) {
+	if r.isReadMore(b) {
+		return ""
+	}
+	if r.errorBoundaries {
+		defer r.errorBoundary(r.ctx, b, len(r.path), &out)
+	}
+	r.types = append(r.types, b.Type)
+	defer func() { r.types = r.types[:len(r.types)-1] }()
+	if r.validateHTML && len(r.types) == 1 {
+		defer func() { r.checkHTML(b, out) }()
+	}
+	if r.tracer != nil && r.spanTypes[b.Type] {
+		return r.tracedBlock(b)
+	}
+	return r.dispatchBlock(b)
+}
+
+func (r *Renderer) dispatchBlock(b Block) string {
+	for _, br := range r.blockRenderers {
+		if out, ok := br.RenderBlock(b); ok {
+			return out
+		}
+	}
 	switch b.Type {
 	case BlockTypeParagraph:
 		return r.ParagraphRenderer.RenderParagraph(b)
@@ -138,8 +414,54 @@ func (r *Renderer) renderBlock(b Block) string {
 		return r.QuoteRenderer.RenderQuote(b)
 	case BlockTypeCode:
 		return r.CodeRenderer.RenderCode(b)
+	case BlockTypeMention:
+		return r.MentionRenderer.RenderMention(b)
+	case BlockTypeMath:
+		return r.MathRenderer.RenderMath(b)
+	case BlockTypeAbbreviation:
+		return r.AbbreviationRenderer.RenderAbbreviation(b)
+	case BlockTypeDefinitionList:
+		return r.DefinitionListRenderer.RenderDefinitionList(b)
+	case BlockTypeDefinitionTerm:
+		return r.DefinitionTermRenderer.RenderDefinitionTerm(b)
+	case BlockTypeDefinitionDescription:
+		return r.DefinitionDescriptionRenderer.RenderDefinitionDescription(b)
+	case BlockTypeDetails:
+		return r.DetailsRenderer.RenderDetails(b)
+	case BlockTypeColumns:
+		return r.ColumnsRenderer.RenderColumns(b)
+	case BlockTypeColumn:
+		return r.ColumnRenderer.RenderColumn(b)
+	case BlockTypeHTML:
+		return r.HTMLRenderer.RenderHTML(b)
+	case BlockTypeGallery:
+		return r.GalleryRenderer.RenderGallery(b)
+	case BlockTypeAnnotation:
+		return r.AnnotationRenderer.RenderAnnotation(b)
+	case BlockTypeCrossReference:
+		return r.renderCrossReference(b)
+	case BlockTypeCitation:
+		return r.renderCitation(b)
+	case blockTypeReferences:
+		return r.renderReferences(b)
+	case blockTypeReference:
+		return fmt.Sprintf(`<li id="%s">%s</li>`, html.EscapeString(deref(b.URL)), r.internalRender(b.Children))
+	case blockTypeAnchor:
+		return fmt.Sprintf(`<div id="%s">%s</div>`, html.EscapeString(deref(b.URL)), r.internalRender(b.Children))
+	case blockTypeInserted:
+		return fmt.Sprintf("<ins>%s</ins>", r.internalRender(b.Children))
+	case blockTypeDeleted:
+		return fmt.Sprintf("<del>%s</del>", r.internalRender(b.Children))
+	case blockTypeMark:
+		return fmt.Sprintf("<mark%s>%s</mark>", r.attrs(ElementMark), r.internalRender(b.Children))
 	}
-	return "unsupported block type"
+	r.unsupportedBlock(b)
+	return r.placeholders.UnsupportedBlock
+}
+
+// unsupportedBlock records the warning of blocks of unknown types
+func (r *Renderer) unsupportedBlock(b Block) {
+	r.warn(WarningUnsupportedBlock, "unsupported block type %q", b.Type)
 }
 
 func (b Block) EmptyText() bool {
@@ -147,87 +469,153 @@ func (b Block) EmptyText() bool {
 }
 
 func (r *Renderer) RenderParagraph(b Block) string {
-	if len(b.Children) == 1 && b.Children[0].EmptyText() {
-		return "<br />"
+	if b.emptyParagraph() {
+		return r.renderEmptyParagraph()
 	}
-	return fmt.Sprintf("<p>%s</p>", r.internalRender(b.Children))
+	return fmt.Sprintf("<p%s>%s</p>", r.attrs(ElementParagraph), r.internalRender(b.Children))
 }
 
 func (r *Renderer) RenderText(b Block) string {
-	out := *b.Text
-	if b.Bold != nil && *b.Bold {
-		out = fmt.Sprintf("<strong>%s</strong>", out)
+	if b.Text == nil {
+		return r.missingText(b)
 	}
-	if b.Italic != nil && *b.Italic {
-		out = fmt.Sprintf("<em>%s</em>", out)
+	out := r.interpolate(*b.Text)
+	if b.Math != nil && *b.Math {
+		out = r.math(out, false)
 	}
-	if b.Underline != nil && *b.Underline {
-		out = fmt.Sprintf("<u>%s</u>", out)
+	for _, m := range r.modifiers {
+		if b.HasModifier(m) {
+			out = r.ModifierRenderer.RenderModifier(m, b, out)
+		}
 	}
-	if b.StrikeThrough != nil && *b.StrikeThrough {
-		out = fmt.Sprintf("<del>%s</del>", out)
-	}
-	if b.Code != nil && *b.Code {
-		out = fmt.Sprintf("<code>%s</code>", out)
+	if r.textColors != nil {
+		out = r.textColor(b, out)
 	}
 	return out
 }
 
 func (r *Renderer) RenderList(b Block) string {
-	if b.Format != nil && *b.Format == string(ListFormatUnordered) {
-		return fmt.Sprintf("<ul>%s</ul>", r.internalRender(b.Children))
+	format, ok := r.listFormat(b)
+	if !ok {
+		return r.placeholders.UnsupportedList
 	}
-	if b.Format != nil && *b.Format == string(ListFormatOrdered) {
-		return fmt.Sprintf("<ol>%s</ol>", r.internalRender(b.Children))
+	if format == ListFormatUnordered {
+		return fmt.Sprintf("<ul%s>%s</ul>", r.attrs(ElementList), r.internalRender(b.Children))
 	}
-	return "unsupported list"
+	return fmt.Sprintf("<ol%s>%s</ol>", r.attrs(ElementList), r.internalRender(b.Children))
 }
+
+// listFormat returns the format of the list, recording a warning for
+// unsupported formats
+func (r *Renderer) listFormat(b Block) (ListFormat, bool) {
+	format := ListFormat(deref(b.Format))
+	if format != ListFormatOrdered && format != ListFormatUnordered {
+		r.warn(WarningUnsupportedList, "unsupported list format %q", deref(b.Format))
+		return "", false
+	}
+	return format, true
+}
+
 func (r *Renderer) RenderListItem(b Block) string {
-	return fmt.Sprintf("<li>%s</li>", r.internalRender(b.Children))
+	return fmt.Sprintf("<li%s>%s</li>", r.attrs(ElementListItem), r.internalRender(b.Children))
 }
 func (r *Renderer) RenderHeading(b Block) string {
-	if b.Level == nil {
-		return *b.Text
+	if _, ok := headingLevel(b); !ok {
+		return r.internalRender(b.Children)
+	}
+	attrs := r.attrs(ElementHeading)
+	if r.print {
+		attrs = r.attrsStyle(printHeadingStyle, ElementHeading)
 	}
 	switch *b.Level {
 	case 1:
-		return fmt.Sprintf("<h1>%s</h1>", r.internalRender(b.Children))
+		return fmt.Sprintf("<h1%s>%s</h1>", attrs, r.internalRender(b.Children))
 	case 2:
-		return fmt.Sprintf("<h2>%s</h2>", r.internalRender(b.Children))
+		return fmt.Sprintf("<h2%s>%s</h2>", attrs, r.internalRender(b.Children))
 	case 3:
-		return fmt.Sprintf("<h3>%s</h3>", r.internalRender(b.Children))
+		return fmt.Sprintf("<h3%s>%s</h3>", attrs, r.internalRender(b.Children))
 	case 4:
-		return fmt.Sprintf("<h4>%s</h4>", r.internalRender(b.Children))
+		return fmt.Sprintf("<h4%s>%s</h4>", attrs, r.internalRender(b.Children))
 	case 5:
-		return fmt.Sprintf("<h5>%s</h5>", r.internalRender(b.Children))
+		return fmt.Sprintf("<h5%s>%s</h5>", attrs, r.internalRender(b.Children))
 	case 6:
-		return fmt.Sprintf("<h6>%s</h6>", r.internalRender(b.Children))
+		return fmt.Sprintf("<h6%s>%s</h6>", attrs, r.internalRender(b.Children))
 	}
 
-	return *b.Text
+	return r.internalRender(b.Children)
+}
+
+// headingLevel returns the level of the heading, headings without a valid
+// level are rendered as their children
+func headingLevel(b Block) (int, bool) {
+	if b.Level == nil || *b.Level < 1 || *b.Level > 6 {
+		return 0, false
+	}
+	return *b.Level, true
 }
 
 func (r *Renderer) RenderImage(b Block) string {
-	if b.Image == nil {
-		return "missing image"
+	if !r.hasMedia(b) {
+		return r.placeholders.MissingImage
+	}
+	img := r.image(*b.Image, "")
+	if b.Image.Caption != "" && !r.inlineImage() {
+		return r.figure(*b.Image, img)
+	}
+	return img
+}
+
+// hasMedia reports whether the image or gallery block has media, recording a
+// warning otherwise
+func (r *Renderer) hasMedia(b Block) bool {
+	if b.Type == BlockTypeGallery && len(b.Images) > 0 || b.Type != BlockTypeGallery && b.Image != nil {
+		return true
+	}
+	r.warn(WarningMissingImage, "%s block without media", b.Type)
+	return false
+}
+
+// inlineImage reports whether the image being rendered is part of running
+// text, like an image inside a paragraph or link. Inline images are not
+// wrapped in a figure.
+func (r *Renderer) inlineImage() bool {
+	if len(r.types) < 2 {
+		return false
 	}
-	return fmt.Sprintf("<img src=%q alt=%q />", b.Image.URL, b.Image.AlternativeText)
+	parent := r.types[len(r.types)-2]
+	return parent == BlockTypeParagraph || parent == BlockTypeHeading || isInline(parent)
 }
 
 func (r *Renderer) RenderCode(b Block) string {
-	// TODO: there is a "language" attribute - react renderer also ignore it
-	return fmt.Sprintf("<pre><code>%s</code></pre>", r.internalRender(b.Children))
+	if r.mermaid && deref(b.Language) == "mermaid" {
+		return r.mermaidDiagram(b.PlainText())
+	}
+	var out string
+	if r.lineNumbers || r.highlightLines != nil || b.HighlightLines != nil {
+		out = r.codeLines(b)
+	} else {
+		out = fmt.Sprintf("<pre%s><code>%s</code></pre>", r.attrs(ElementPre), r.internalRender(b.Children))
+	}
+	if r.codeCopy != nil && !r.print {
+		return r.copyableCode(b, out)
+	}
+	return out
 }
 
 func (r *Renderer) RenderQuote(b Block) string {
-	return fmt.Sprintf("<blockquote>%s</blockquote>", r.internalRender(b.Children))
+	out := fmt.Sprintf("<blockquote%s>%s</blockquote>", r.attrs(ElementQuote), r.internalRender(b.Children))
+	if r.quoteFigure {
+		return fmt.Sprintf("<figure%s>%s</figure>", r.attrs(ElementQuoteFigure), out)
+	}
+	return out
 }
 
 func (r *Renderer) RenderLink(b Block) string {
-	url := "#"
-	if b.URL != nil {
-		url = *b.URL
+	link := r.resolveLinkBlock(b)
+	href, content := r.obfuscateEmail(link.Href, r.internalRender(b.Children))
+	out := fmt.Sprintf(`<a href=%q%s%s%s>%s</a>`, href, titleAttr(link.Title), r.attrs(ElementLink), link.Attributes, content)
+	if link.Printed != "" {
+		out += r.printURL(link.URL, link.Printed)
 	}
-
-	return fmt.Sprintf(`<a href=%q>%s</a>`, url, r.internalRender(b.Children))
+	return out
 }
@@ -3,6 +3,7 @@ package blocks
 import (
 	_ "embed"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -70,7 +71,7 @@ func TestBlock_Render(t *testing.T) {
 <h3>
   header 3
 </h3>
-<img src="http://localhost:1337/uploads/cdreier_gopher_small_a32e6e2b51.jpg" alt="cdreier_gopher_small.jpg" />
+<img src="http://localhost:1337/uploads/cdreier_gopher_small_a32e6e2b51.jpg" alt="cdreier_gopher_small.jpg" width="256" height="256" />
 <blockquote>
   this does support block quotes
 </blockquote><pre><code>func andCodeBlocks() string {
@@ -122,3 +123,39 @@ func TestBlock_Render(t *testing.T) {
 <br />`, out)
 
 }
+
+type sectionQuote struct {
+	r *Renderer
+}
+
+func (q sectionQuote) RenderQuote(b Block) string {
+	return "<aside>" + q.r.RenderChildren(b) + "</aside>"
+}
+
+type bufferedList struct {
+	r *Renderer
+}
+
+func (l bufferedList) RenderList(b Block) string {
+	out := strings.Builder{}
+	out.WriteString("<menu>")
+	l.r.WriteChildren(&out, b)
+	out.WriteString("</menu>")
+	return out.String()
+}
+
+func TestRenderer_RenderChildren(t *testing.T) {
+
+	r := New()
+	r.QuoteRenderer = sectionQuote{r}
+	r.ListRenderer = bufferedList{r}
+
+	doc := []Block{
+		{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeImage}}},
+		{Type: BlockTypeList, Children: []Block{
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeText, Text: ptr("item")}}},
+		}},
+	}
+	assert.Equal(t, "<aside>\n  missing image\n</aside>\n<menu>\n  <li>\n    item\n  </li>\n</menu>", r.Render(doc))
+	assert.Equal(t, []int{0, 0}, r.Warnings()[0].Path)
+}
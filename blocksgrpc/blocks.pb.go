@@ -0,0 +1,783 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: blocks.proto
+
+package blocksgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RenderOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// theme is one of the built-in themes, the server defaults are used when
+	// empty
+	Theme         string `protobuf:"bytes,1,opt,name=theme,proto3" json:"theme,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderOptions) Reset() {
+	*x = RenderOptions{}
+	mi := &file_blocks_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderOptions) ProtoMessage() {}
+
+func (x *RenderOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderOptions.ProtoReflect.Descriptor instead.
+func (*RenderOptions) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RenderOptions) GetTheme() string {
+	if x != nil {
+		return x.Theme
+	}
+	return ""
+}
+
+type RenderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []byte                 `protobuf:"bytes,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Options       *RenderOptions         `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderRequest) Reset() {
+	*x = RenderRequest{}
+	mi := &file_blocks_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderRequest) ProtoMessage() {}
+
+func (x *RenderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderRequest.ProtoReflect.Descriptor instead.
+func (*RenderRequest) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RenderRequest) GetBlocks() []byte {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *RenderRequest) GetOptions() *RenderOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type RenderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Html          string                 `protobuf:"bytes,1,opt,name=html,proto3" json:"html,omitempty"`
+	Warnings      []*Warning             `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderResponse) Reset() {
+	*x = RenderResponse{}
+	mi := &file_blocks_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderResponse) ProtoMessage() {}
+
+func (x *RenderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderResponse.ProtoReflect.Descriptor instead.
+func (*RenderResponse) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RenderResponse) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *RenderResponse) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type RenderStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Options       *RenderOptions         `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderStreamRequest) Reset() {
+	*x = RenderStreamRequest{}
+	mi := &file_blocks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderStreamRequest) ProtoMessage() {}
+
+func (x *RenderStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderStreamRequest.ProtoReflect.Descriptor instead.
+func (*RenderStreamRequest) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RenderStreamRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *RenderStreamRequest) GetOptions() *RenderOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type RenderStreamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Html          string                 `protobuf:"bytes,1,opt,name=html,proto3" json:"html,omitempty"`
+	Warnings      []*Warning             `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderStreamResponse) Reset() {
+	*x = RenderStreamResponse{}
+	mi := &file_blocks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderStreamResponse) ProtoMessage() {}
+
+func (x *RenderStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderStreamResponse.ProtoReflect.Descriptor instead.
+func (*RenderStreamResponse) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RenderStreamResponse) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *RenderStreamResponse) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// Warning is a non fatal problem found in the content while rendering.
+type Warning struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Code    string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// path points to the block, the indexes of the block and its parents
+	Path          []int32 `protobuf:"varint,3,rep,packed,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warning) Reset() {
+	*x = Warning{}
+	mi := &file_blocks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warning) ProtoMessage() {}
+
+func (x *Warning) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warning.ProtoReflect.Descriptor instead.
+func (*Warning) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Warning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Warning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Warning) GetPath() []int32 {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+type ParseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []byte                 `protobuf:"bytes,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseRequest) Reset() {
+	*x = ParseRequest{}
+	mi := &file_blocks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseRequest) ProtoMessage() {}
+
+func (x *ParseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseRequest.ProtoReflect.Descriptor instead.
+func (*ParseRequest) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ParseRequest) GetBlocks() []byte {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type ParseResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Blocks []byte                 `protobuf:"bytes,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	// version is the detected strapi version, v4, v5 or unknown
+	Version       string             `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Changes       []*MigrationChange `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	PlainText     string             `protobuf:"bytes,4,opt,name=plain_text,json=plainText,proto3" json:"plain_text,omitempty"`
+	Words         int32              `protobuf:"varint,5,opt,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseResponse) Reset() {
+	*x = ParseResponse{}
+	mi := &file_blocks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseResponse) ProtoMessage() {}
+
+func (x *ParseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseResponse.ProtoReflect.Descriptor instead.
+func (*ParseResponse) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ParseResponse) GetBlocks() []byte {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *ParseResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ParseResponse) GetChanges() []*MigrationChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ParseResponse) GetPlainText() string {
+	if x != nil {
+		return x.PlainText
+	}
+	return ""
+}
+
+func (x *ParseResponse) GetWords() int32 {
+	if x != nil {
+		return x.Words
+	}
+	return 0
+}
+
+type MigrationChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path is a json pointer into the original payload
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Description   string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrationChange) Reset() {
+	*x = MigrationChange{}
+	mi := &file_blocks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrationChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrationChange) ProtoMessage() {}
+
+func (x *MigrationChange) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrationChange.ProtoReflect.Descriptor instead.
+func (*MigrationChange) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MigrationChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MigrationChange) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ValidateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []byte                 `protobuf:"bytes,1,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateRequest) Reset() {
+	*x = ValidateRequest{}
+	mi := &file_blocks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateRequest) ProtoMessage() {}
+
+func (x *ValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateRequest) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateRequest) GetBlocks() []byte {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type ValidateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors        []*ValidationError     `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateResponse) Reset() {
+	*x = ValidateResponse{}
+	mi := &file_blocks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateResponse) ProtoMessage() {}
+
+func (x *ValidateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateResponse.ProtoReflect.Descriptor instead.
+func (*ValidateResponse) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValidateResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateResponse) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ValidationError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path is a json pointer to the invalid value
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	mi := &file_blocks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_blocks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_blocks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidationError) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_blocks_proto protoreflect.FileDescriptor
+
+const file_blocks_proto_rawDesc = "" +
+	"\n" +
+	"\fblocks.proto\x12\x0fstrapiblocks.v1\"%\n" +
+	"\rRenderOptions\x12\x14\n" +
+	"\x05theme\x18\x01 \x01(\tR\x05theme\"a\n" +
+	"\rRenderRequest\x12\x16\n" +
+	"\x06blocks\x18\x01 \x01(\fR\x06blocks\x128\n" +
+	"\aoptions\x18\x02 \x01(\v2\x1e.strapiblocks.v1.RenderOptionsR\aoptions\"Z\n" +
+	"\x0eRenderResponse\x12\x12\n" +
+	"\x04html\x18\x01 \x01(\tR\x04html\x124\n" +
+	"\bwarnings\x18\x02 \x03(\v2\x18.strapiblocks.v1.WarningR\bwarnings\"e\n" +
+	"\x13RenderStreamRequest\x12\x14\n" +
+	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x128\n" +
+	"\aoptions\x18\x02 \x01(\v2\x1e.strapiblocks.v1.RenderOptionsR\aoptions\"`\n" +
+	"\x14RenderStreamResponse\x12\x12\n" +
+	"\x04html\x18\x01 \x01(\tR\x04html\x124\n" +
+	"\bwarnings\x18\x02 \x03(\v2\x18.strapiblocks.v1.WarningR\bwarnings\"K\n" +
+	"\aWarning\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04path\x18\x03 \x03(\x05R\x04path\"&\n" +
+	"\fParseRequest\x12\x16\n" +
+	"\x06blocks\x18\x01 \x01(\fR\x06blocks\"\xb2\x01\n" +
+	"\rParseResponse\x12\x16\n" +
+	"\x06blocks\x18\x01 \x01(\fR\x06blocks\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12:\n" +
+	"\achanges\x18\x03 \x03(\v2 .strapiblocks.v1.MigrationChangeR\achanges\x12\x1d\n" +
+	"\n" +
+	"plain_text\x18\x04 \x01(\tR\tplainText\x12\x14\n" +
+	"\x05words\x18\x05 \x01(\x05R\x05words\"G\n" +
+	"\x0fMigrationChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\")\n" +
+	"\x0fValidateRequest\x12\x16\n" +
+	"\x06blocks\x18\x01 \x01(\fR\x06blocks\"b\n" +
+	"\x10ValidateResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x128\n" +
+	"\x06errors\x18\x02 \x03(\v2 .strapiblocks.v1.ValidationErrorR\x06errors\"?\n" +
+	"\x0fValidationError\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xd4\x02\n" +
+	"\rBlocksService\x12I\n" +
+	"\x06Render\x12\x1e.strapiblocks.v1.RenderRequest\x1a\x1f.strapiblocks.v1.RenderResponse\x12_\n" +
+	"\fRenderStream\x12$.strapiblocks.v1.RenderStreamRequest\x1a%.strapiblocks.v1.RenderStreamResponse(\x010\x01\x12F\n" +
+	"\x05Parse\x12\x1d.strapiblocks.v1.ParseRequest\x1a\x1e.strapiblocks.v1.ParseResponse\x12O\n" +
+	"\bValidate\x12 .strapiblocks.v1.ValidateRequest\x1a!.strapiblocks.v1.ValidateResponseB9Z7github.com/cdreier/strapi-blocks-go-renderer/blocksgrpcb\x06proto3"
+
+var (
+	file_blocks_proto_rawDescOnce sync.Once
+	file_blocks_proto_rawDescData []byte
+)
+
+func file_blocks_proto_rawDescGZIP() []byte {
+	file_blocks_proto_rawDescOnce.Do(func() {
+		file_blocks_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_blocks_proto_rawDesc), len(file_blocks_proto_rawDesc)))
+	})
+	return file_blocks_proto_rawDescData
+}
+
+var file_blocks_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_blocks_proto_goTypes = []any{
+	(*RenderOptions)(nil),        // 0: strapiblocks.v1.RenderOptions
+	(*RenderRequest)(nil),        // 1: strapiblocks.v1.RenderRequest
+	(*RenderResponse)(nil),       // 2: strapiblocks.v1.RenderResponse
+	(*RenderStreamRequest)(nil),  // 3: strapiblocks.v1.RenderStreamRequest
+	(*RenderStreamResponse)(nil), // 4: strapiblocks.v1.RenderStreamResponse
+	(*Warning)(nil),              // 5: strapiblocks.v1.Warning
+	(*ParseRequest)(nil),         // 6: strapiblocks.v1.ParseRequest
+	(*ParseResponse)(nil),        // 7: strapiblocks.v1.ParseResponse
+	(*MigrationChange)(nil),      // 8: strapiblocks.v1.MigrationChange
+	(*ValidateRequest)(nil),      // 9: strapiblocks.v1.ValidateRequest
+	(*ValidateResponse)(nil),     // 10: strapiblocks.v1.ValidateResponse
+	(*ValidationError)(nil),      // 11: strapiblocks.v1.ValidationError
+}
+var file_blocks_proto_depIdxs = []int32{
+	0,  // 0: strapiblocks.v1.RenderRequest.options:type_name -> strapiblocks.v1.RenderOptions
+	5,  // 1: strapiblocks.v1.RenderResponse.warnings:type_name -> strapiblocks.v1.Warning
+	0,  // 2: strapiblocks.v1.RenderStreamRequest.options:type_name -> strapiblocks.v1.RenderOptions
+	5,  // 3: strapiblocks.v1.RenderStreamResponse.warnings:type_name -> strapiblocks.v1.Warning
+	8,  // 4: strapiblocks.v1.ParseResponse.changes:type_name -> strapiblocks.v1.MigrationChange
+	11, // 5: strapiblocks.v1.ValidateResponse.errors:type_name -> strapiblocks.v1.ValidationError
+	1,  // 6: strapiblocks.v1.BlocksService.Render:input_type -> strapiblocks.v1.RenderRequest
+	3,  // 7: strapiblocks.v1.BlocksService.RenderStream:input_type -> strapiblocks.v1.RenderStreamRequest
+	6,  // 8: strapiblocks.v1.BlocksService.Parse:input_type -> strapiblocks.v1.ParseRequest
+	9,  // 9: strapiblocks.v1.BlocksService.Validate:input_type -> strapiblocks.v1.ValidateRequest
+	2,  // 10: strapiblocks.v1.BlocksService.Render:output_type -> strapiblocks.v1.RenderResponse
+	4,  // 11: strapiblocks.v1.BlocksService.RenderStream:output_type -> strapiblocks.v1.RenderStreamResponse
+	7,  // 12: strapiblocks.v1.BlocksService.Parse:output_type -> strapiblocks.v1.ParseResponse
+	10, // 13: strapiblocks.v1.BlocksService.Validate:output_type -> strapiblocks.v1.ValidateResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_blocks_proto_init() }
+func file_blocks_proto_init() {
+	if File_blocks_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_blocks_proto_rawDesc), len(file_blocks_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_blocks_proto_goTypes,
+		DependencyIndexes: file_blocks_proto_depIdxs,
+		MessageInfos:      file_blocks_proto_msgTypes,
+	}.Build()
+	File_blocks_proto = out.File
+	file_blocks_proto_goTypes = nil
+	file_blocks_proto_depIdxs = nil
+}
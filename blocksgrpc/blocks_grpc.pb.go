@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: blocks.proto
+
+package blocksgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BlocksService_Render_FullMethodName       = "/strapiblocks.v1.BlocksService/Render"
+	BlocksService_RenderStream_FullMethodName = "/strapiblocks.v1.BlocksService/RenderStream"
+	BlocksService_Parse_FullMethodName        = "/strapiblocks.v1.BlocksService/Parse"
+	BlocksService_Validate_FullMethodName     = "/strapiblocks.v1.BlocksService/Validate"
+)
+
+// BlocksServiceClient is the client API for BlocksService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BlocksService renders strapi blocks payloads for backends not written in
+// go. Blocks are passed as the json strapi returns for blocks fields.
+type BlocksServiceClient interface {
+	// Render renders a whole document.
+	Render(ctx context.Context, in *RenderRequest, opts ...grpc.CallOption) (*RenderResponse, error)
+	// RenderStream renders large documents without keeping them in memory. The
+	// client streams the json in chunks, the options are read from the first
+	// message. The html is streamed back one top level block at a time, the
+	// last message holds the warnings.
+	RenderStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RenderStreamRequest, RenderStreamResponse], error)
+	// Parse normalizes payloads of strapi v4 and v5 into the canonical json.
+	Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error)
+	// Validate checks the payload against the json schema of strapi blocks.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+}
+
+type blocksServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlocksServiceClient(cc grpc.ClientConnInterface) BlocksServiceClient {
+	return &blocksServiceClient{cc}
+}
+
+func (c *blocksServiceClient) Render(ctx context.Context, in *RenderRequest, opts ...grpc.CallOption) (*RenderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderResponse)
+	err := c.cc.Invoke(ctx, BlocksService_Render_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blocksServiceClient) RenderStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[RenderStreamRequest, RenderStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlocksService_ServiceDesc.Streams[0], BlocksService_RenderStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RenderStreamRequest, RenderStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlocksService_RenderStreamClient = grpc.BidiStreamingClient[RenderStreamRequest, RenderStreamResponse]
+
+func (c *blocksServiceClient) Parse(ctx context.Context, in *ParseRequest, opts ...grpc.CallOption) (*ParseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ParseResponse)
+	err := c.cc.Invoke(ctx, BlocksService_Parse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blocksServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, BlocksService_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlocksServiceServer is the server API for BlocksService service.
+// All implementations must embed UnimplementedBlocksServiceServer
+// for forward compatibility.
+//
+// BlocksService renders strapi blocks payloads for backends not written in
+// go. Blocks are passed as the json strapi returns for blocks fields.
+type BlocksServiceServer interface {
+	// Render renders a whole document.
+	Render(context.Context, *RenderRequest) (*RenderResponse, error)
+	// RenderStream renders large documents without keeping them in memory. The
+	// client streams the json in chunks, the options are read from the first
+	// message. The html is streamed back one top level block at a time, the
+	// last message holds the warnings.
+	RenderStream(grpc.BidiStreamingServer[RenderStreamRequest, RenderStreamResponse]) error
+	// Parse normalizes payloads of strapi v4 and v5 into the canonical json.
+	Parse(context.Context, *ParseRequest) (*ParseResponse, error)
+	// Validate checks the payload against the json schema of strapi blocks.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	mustEmbedUnimplementedBlocksServiceServer()
+}
+
+// UnimplementedBlocksServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBlocksServiceServer struct{}
+
+func (UnimplementedBlocksServiceServer) Render(context.Context, *RenderRequest) (*RenderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Render not implemented")
+}
+func (UnimplementedBlocksServiceServer) RenderStream(grpc.BidiStreamingServer[RenderStreamRequest, RenderStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method RenderStream not implemented")
+}
+func (UnimplementedBlocksServiceServer) Parse(context.Context, *ParseRequest) (*ParseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Parse not implemented")
+}
+func (UnimplementedBlocksServiceServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedBlocksServiceServer) mustEmbedUnimplementedBlocksServiceServer() {}
+func (UnimplementedBlocksServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBlocksServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlocksServiceServer will
+// result in compilation errors.
+type UnsafeBlocksServiceServer interface {
+	mustEmbedUnimplementedBlocksServiceServer()
+}
+
+func RegisterBlocksServiceServer(s grpc.ServiceRegistrar, srv BlocksServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBlocksServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BlocksService_ServiceDesc, srv)
+}
+
+func _BlocksService_Render_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlocksServiceServer).Render(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlocksService_Render_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlocksServiceServer).Render(ctx, req.(*RenderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlocksService_RenderStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlocksServiceServer).RenderStream(&grpc.GenericServerStream[RenderStreamRequest, RenderStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlocksService_RenderStreamServer = grpc.BidiStreamingServer[RenderStreamRequest, RenderStreamResponse]
+
+func _BlocksService_Parse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlocksServiceServer).Parse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlocksService_Parse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlocksServiceServer).Parse(ctx, req.(*ParseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlocksService_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlocksServiceServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlocksService_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlocksServiceServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BlocksService_ServiceDesc is the grpc.ServiceDesc for BlocksService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlocksService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "strapiblocks.v1.BlocksService",
+	HandlerType: (*BlocksServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Render",
+			Handler:    _BlocksService_Render_Handler,
+		},
+		{
+			MethodName: "Parse",
+			Handler:    _BlocksService_Parse_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _BlocksService_Validate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RenderStream",
+			Handler:       _BlocksService_RenderStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "blocks.proto",
+}
@@ -0,0 +1,173 @@
+// Package blocksgrpc exposes the renderer as a gRPC service, so backends
+// written in other languages can share one rendering service. The service
+// is defined in blocks.proto:
+//
+//	srv := grpc.NewServer()
+//	blocksgrpc.RegisterBlocksServiceServer(srv, blocksgrpc.NewServer(blocks.WithTheme(blocks.ThemeTailwindProse)))
+package blocksgrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative blocks.proto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements BlocksService. Every call renders with its own copy of
+// the renderer, see blocks.Renderer.With, so custom block renderers must be
+// safe for concurrent use.
+type Server struct {
+	UnimplementedBlocksServiceServer
+	renderer *blocks.Renderer
+}
+
+// NewServer returns a server rendering with the given options, the options
+// of the requests are applied on top.
+func NewServer(opts ...blocks.Option) *Server {
+	return &Server{renderer: blocks.New(opts...)}
+}
+
+func (s *Server) Render(ctx context.Context, req *RenderRequest) (*RenderResponse, error) {
+	r, err := s.with(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+	doc, err := blocks.Parse(req.GetBlocks())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parsing blocks: %s", err)
+	}
+	out := strings.Builder{}
+	if err := r.Component(doc).Render(ctx, &out); err != nil {
+		return nil, renderStatus(err, codes.Internal)
+	}
+	return &RenderResponse{Html: out.String(), Warnings: warnings(r.Warnings())}, nil
+}
+
+func (s *Server) RenderStream(stream BlocksService_RenderStreamServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	r, err := s.with(first.GetOptions())
+	if err != nil {
+		return err
+	}
+
+	rd, pw := io.Pipe()
+	go func() {
+		chunk := first.GetChunk()
+		for {
+			if _, err := pw.Write(chunk); err != nil {
+				return
+			}
+			req, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				pw.CloseWithError(err)
+				return
+			}
+			chunk = req.GetChunk()
+		}
+	}()
+	defer rd.Close()
+
+	if err := r.RenderStream(streamWriter{stream}, rd); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		// apart from the limits the stream fails on invalid json only
+		return renderStatus(err, codes.InvalidArgument)
+	}
+	return stream.Send(&RenderStreamResponse{Warnings: warnings(r.Warnings())})
+}
+
+// streamWriter sends every write as a message
+type streamWriter struct {
+	stream BlocksService_RenderStreamServer
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := w.stream.Send(&RenderStreamResponse{Html: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *Server) Parse(ctx context.Context, req *ParseRequest) (*ParseResponse, error) {
+	doc, report, err := blocks.Migrate(req.GetBlocks())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parsing blocks: %s", err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &ParseResponse{
+		Blocks:    raw,
+		Version:   string(report.Version),
+		PlainText: blocks.PlainText(doc),
+		Words:     int32(blocks.Words(doc)),
+	}
+	for _, c := range report.Changes {
+		res.Changes = append(res.Changes, &MigrationChange{Path: c.Path, Description: c.Description})
+	}
+	return res, nil
+}
+
+func (s *Server) Validate(ctx context.Context, req *ValidateRequest) (*ValidateResponse, error) {
+	res := &ValidateResponse{Valid: true}
+	for _, e := range blocks.Validate(req.GetBlocks()) {
+		res.Valid = false
+		res.Errors = append(res.Errors, &ValidationError{Path: e.Path, Message: e.Message})
+	}
+	return res, nil
+}
+
+// with returns a copy of the renderer with the options of the request
+func (s *Server) with(opts *RenderOptions) (*blocks.Renderer, error) {
+	if theme := opts.GetTheme(); theme != "" {
+		if _, ok := blocks.Themes[theme]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown theme %q", theme)
+		}
+		return s.renderer.With(blocks.WithTheme(theme)), nil
+	}
+	return s.renderer.With(), nil
+}
+
+// renderStatus maps render errors to status codes, other errors get the
+// given code
+func renderStatus(err error, other codes.Code) error {
+	switch {
+	case errors.Is(err, blocks.ErrLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(other, err.Error())
+}
+
+func warnings(ws []blocks.Warning) []*Warning {
+	out := make([]*Warning, 0, len(ws))
+	for _, w := range ws {
+		path := make([]int32, len(w.Path))
+		for i, p := range w.Path {
+			path[i] = int32(p)
+		}
+		out = append(out, &Warning{Code: w.Code, Message: w.Message, Path: path})
+	}
+	return out
+}
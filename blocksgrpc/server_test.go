@@ -0,0 +1,130 @@
+package blocksgrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func client(t *testing.T, opts ...blocks.Option) BlocksServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterBlocksServiceServer(srv, NewServer(opts...))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewBlocksServiceClient(conn)
+}
+
+const doc = `[
+	{"type": "heading", "level": 1, "children": [{"type": "text", "text": "Hello"}]},
+	{"type": "image", "children": []}
+]`
+
+func TestRender(t *testing.T) {
+	c := client(t)
+	ctx := context.Background()
+
+	res, err := c.Render(ctx, &RenderRequest{Blocks: []byte(doc)})
+	assert.NoError(t, err)
+	assert.Equal(t, "<h1>\n  Hello\n</h1>\nmissing image", res.Html)
+	assert.Len(t, res.Warnings, 1)
+	assert.Equal(t, blocks.WarningMissingImage, res.Warnings[0].Code)
+	assert.Equal(t, []int32{1}, res.Warnings[0].Path)
+
+	res, err = c.Render(ctx, &RenderRequest{Blocks: []byte(doc), Options: &RenderOptions{Theme: blocks.ThemeTailwindProse}})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Html, `<article class="prose">`)
+
+	_, err = c.Render(ctx, &RenderRequest{Blocks: []byte(doc), Options: &RenderOptions{Theme: "neon"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = c.Render(ctx, &RenderRequest{Blocks: []byte(`{`)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRender_limits(t *testing.T) {
+	c := client(t, blocks.WithLimits(blocks.Limits{MaxBlocks: 2}))
+	_, err := c.Render(context.Background(), &RenderRequest{Blocks: []byte(doc)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRenderStream(t *testing.T) {
+	c := client(t)
+	stream, err := c.RenderStream(context.Background())
+	assert.NoError(t, err)
+	for i, chunk := range []string{doc[:20], doc[20:50], doc[50:]} {
+		req := &RenderStreamRequest{Chunk: []byte(chunk)}
+		if i == 0 {
+			req.Options = &RenderOptions{Theme: blocks.ThemeTailwindProse}
+		}
+		assert.NoError(t, stream.Send(req))
+	}
+	assert.NoError(t, stream.CloseSend())
+
+	html := strings.Builder{}
+	var warnings []*Warning
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		html.WriteString(res.Html)
+		warnings = append(warnings, res.Warnings...)
+	}
+	assert.Equal(t, "<article class=\"prose\"><h1>\n  Hello\n</h1>\nmissing image</article>", html.String())
+	assert.Len(t, warnings, 1)
+}
+
+func TestRenderStream_invalid(t *testing.T) {
+	c := client(t)
+	stream, err := c.RenderStream(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&RenderStreamRequest{Chunk: []byte(`{"type": "paragraph"}`)}))
+	assert.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestParse(t *testing.T) {
+	c := client(t)
+	res, err := c.Parse(context.Background(), &ParseRequest{Blocks: []byte(`[
+		{"type": "image", "image": {"data": {"id": 3, "attributes": {"name": "a.jpg", "url": "/uploads/a.jpg"}}}, "children": []},
+		{"type": "paragraph", "children": [{"type": "text", "text": "two words"}]}
+	]`)})
+	assert.NoError(t, err)
+	assert.Equal(t, "v4", res.Version)
+	assert.NotEmpty(t, res.Changes)
+	assert.Equal(t, "two words", res.PlainText)
+	assert.Equal(t, int32(2), res.Words)
+	parsed, err := blocks.Parse(res.Blocks)
+	assert.NoError(t, err)
+	assert.Equal(t, "/uploads/a.jpg", parsed[0].Image.URL)
+}
+
+func TestValidate(t *testing.T) {
+	c := client(t)
+	res, err := c.Validate(context.Background(), &ValidateRequest{Blocks: []byte(`[{"type": "heading", "level": 2, "children": []}]`)})
+	assert.NoError(t, err)
+	assert.True(t, res.Valid)
+
+	res, err = c.Validate(context.Background(), &ValidateRequest{Blocks: []byte(`[{"type": "heading", "children": []}]`)})
+	assert.NoError(t, err)
+	assert.False(t, res.Valid)
+	assert.NotEmpty(t, res.Errors)
+}
@@ -0,0 +1,74 @@
+// Package blockstest tests renderers against json fixtures and golden html
+// files, the same way the renderer is tested itself. Run the tests with
+// -update to write the golden files from the current output. The -update
+// flag is registered by this package, so test packages using it must not
+// define their own. Generate produces random documents for benchmarks and
+// fuzz seeds.
+package blockstest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "write the golden files of blockstest")
+
+// Load reads and parses a json fixture.
+func Load(t testing.TB, path string) []blocks.Block {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("blockstest: %s", err)
+	}
+	doc, err := blocks.Parse(raw)
+	if err != nil {
+		t.Fatalf("blockstest: cannot parse %s: %s", path, err)
+	}
+	return doc
+}
+
+// Golden compares got with the content of the golden file and reports a diff
+// on mismatches, a trailing newline of the file is ignored. With -update the
+// golden file is written instead.
+func Golden(t testing.TB, path string, got string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("blockstest: %s", err)
+		}
+		if err := os.WriteFile(path, []byte(got+"\n"), 0o644); err != nil {
+			t.Fatalf("blockstest: %s", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("blockstest: %s, run with -update to create it", err)
+	}
+	assert.Equal(t, strings.TrimSuffix(string(want), "\n"), got, "golden file %s differs, run with -update to accept the output", path)
+}
+
+// Run renders every json fixture in dir as subtest and compares the output
+// with the golden file of the same name ending in .html.
+func Run(t *testing.T, r *blocks.Renderer, dir string) {
+	t.Helper()
+	fixtures, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("blockstest: %s", err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("blockstest: no fixtures in %s", dir)
+	}
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		t.Run(name, func(t *testing.T) {
+			Golden(t, strings.TrimSuffix(fixture, ".json")+".html", r.Render(Load(t, fixture)))
+		})
+	}
+}
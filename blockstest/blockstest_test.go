@@ -0,0 +1,32 @@
+package blockstest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	Run(t, blocks.New(), "testdata")
+}
+
+func TestLoad(t *testing.T) {
+	doc := Load(t, "testdata/quote.json")
+	assert.Len(t, doc, 1)
+	assert.Equal(t, blocks.BlockTypeQuote, doc[0].Type)
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden", "out.html")
+
+	*update = true
+	defer func() { *update = false }()
+	Golden(t, path, "<p>\n  new\n</p>")
+
+	out, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>\n  new\n</p>\n", string(out))
+}
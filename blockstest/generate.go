@@ -0,0 +1,170 @@
+package blockstest
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+)
+
+// GenerateOptions configures the documents of Generate.
+type GenerateOptions struct {
+	// Seed makes the generated documents reproducible
+	Seed uint64
+	// Blocks is the number of top level blocks, defaults to 20
+	Blocks int
+	// Depth is the maximum nesting of lists, defaults to 3
+	Depth int
+	// Mix weights the top level block types, defaults to DefaultMix. A mix
+	// without positive weights for the types of DefaultMix falls back to it.
+	Mix map[blocks.BlockType]int
+	// Modifiers is the probability of each text modifier like bold or italic
+	// being set on a text, from 0 to 1
+	Modifiers float64
+}
+
+// DefaultMix resembles the block mix of a typical article.
+var DefaultMix = map[blocks.BlockType]int{
+	blocks.BlockTypeParagraph: 10,
+	blocks.BlockTypeHeading:   3,
+	blocks.BlockTypeList:      2,
+	blocks.BlockTypeQuote:     1,
+	blocks.BlockTypeCode:      1,
+	blocks.BlockTypeImage:     1,
+}
+
+var words = strings.Fields("lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor incididunt ut labore et dolore magna aliqua gopher strapi blocks render")
+
+// Generate returns a randomized but valid document, for benchmarks and fuzz
+// seeds.
+func Generate(opts GenerateOptions) []blocks.Block {
+	if opts.Blocks <= 0 {
+		opts.Blocks = 20
+	}
+	if opts.Depth <= 0 {
+		opts.Depth = 3
+	}
+	types, total := mixTypes(opts.Mix)
+	if total == 0 {
+		opts.Mix = DefaultMix
+		types, total = mixTypes(opts.Mix)
+	}
+	g := generator{rnd: rand.New(rand.NewPCG(opts.Seed, opts.Seed)), opts: opts}
+
+	doc := make([]blocks.Block, 0, opts.Blocks)
+	for range opts.Blocks {
+		pick := g.rnd.IntN(total)
+		for _, t := range types {
+			if pick < opts.Mix[t] {
+				doc = append(doc, g.block(t))
+				break
+			}
+			pick -= opts.Mix[t]
+		}
+	}
+	return doc
+}
+
+// mixTypes returns the block types of the mix Generate supports and the sum
+// of their weights
+func mixTypes(mix map[blocks.BlockType]int) ([]blocks.BlockType, int) {
+	// sorted, so the same seed generates the same document
+	types := []blocks.BlockType{}
+	total := 0
+	for _, t := range []blocks.BlockType{
+		blocks.BlockTypeParagraph, blocks.BlockTypeHeading, blocks.BlockTypeList,
+		blocks.BlockTypeQuote, blocks.BlockTypeCode, blocks.BlockTypeImage,
+	} {
+		if mix[t] > 0 {
+			types = append(types, t)
+			total += mix[t]
+		}
+	}
+	return types, total
+}
+
+type generator struct {
+	rnd  *rand.Rand
+	opts GenerateOptions
+}
+
+func (g generator) block(t blocks.BlockType) blocks.Block {
+	switch t {
+	case blocks.BlockTypeHeading:
+		return blocks.Block{Type: t, Level: ptr(1 + g.rnd.IntN(6)), Children: g.inline(false)}
+	case blocks.BlockTypeList:
+		return g.list(1)
+	case blocks.BlockTypeQuote:
+		return blocks.Block{Type: t, Children: g.inline(false)}
+	case blocks.BlockTypeCode:
+		return blocks.Block{Type: t, Language: ptr("go"), Children: []blocks.Block{g.text(false)}}
+	case blocks.BlockTypeImage:
+		return blocks.Block{Type: t, Children: []blocks.Block{{Type: blocks.BlockTypeText, Text: ptr("")}}, Image: &blocks.Image{
+			Name:            "gopher.png",
+			AlternativeText: g.words(3),
+			URL:             "https://example.com/uploads/gopher.png",
+			Width:           640,
+			Height:          480,
+			Mime:            "image/png",
+		}}
+	}
+	return blocks.Block{Type: blocks.BlockTypeParagraph, Children: g.inline(true)}
+}
+
+func (g generator) list(depth int) blocks.Block {
+	format := blocks.ListFormatUnordered
+	if g.rnd.IntN(2) == 0 {
+		format = blocks.ListFormatOrdered
+	}
+	list := blocks.Block{Type: blocks.BlockTypeList, Format: ptr(string(format))}
+	for range 1 + g.rnd.IntN(4) {
+		if depth < g.opts.Depth && g.rnd.IntN(4) == 0 {
+			list.Children = append(list.Children, g.list(depth+1))
+			continue
+		}
+		list.Children = append(list.Children, blocks.Block{Type: blocks.BlockTypeListItem, Children: g.inline(true)})
+	}
+	return list
+}
+
+// inline returns text and, if allowed, link children
+func (g generator) inline(links bool) []blocks.Block {
+	children := []blocks.Block{}
+	for range 1 + g.rnd.IntN(4) {
+		if links && g.rnd.IntN(5) == 0 {
+			children = append(children, blocks.Block{
+				Type:     blocks.BlockTypeLink,
+				URL:      ptr("https://example.com/" + g.words(1)),
+				Children: []blocks.Block{g.text(true)},
+			})
+			continue
+		}
+		children = append(children, g.text(true))
+	}
+	return children
+}
+
+func (g generator) text(modifiers bool) blocks.Block {
+	b := blocks.Block{Type: blocks.BlockTypeText, Text: ptr(g.words(2+g.rnd.IntN(10)) + " ")}
+	if !modifiers {
+		return b
+	}
+	for _, modifier := range []**bool{&b.Bold, &b.Italic, &b.Underline, &b.StrikeThrough, &b.Code} {
+		if g.rnd.Float64() < g.opts.Modifiers {
+			*modifier = ptr(true)
+		}
+	}
+	return b
+}
+
+func (g generator) words(n int) string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = words[g.rnd.IntN(len(words))]
+	}
+	return strings.Join(out, " ")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,74 @@
+package blockstest
+
+import (
+	"encoding/json"
+	"testing"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+
+	for seed := range uint64(20) {
+		doc := Generate(GenerateOptions{Seed: seed, Modifiers: 0.3})
+		assert.Len(t, doc, 20)
+
+		assert.Empty(t, blocks.Validate(withoutNulls(t, doc)), "seed %d", seed)
+
+		_, warnings := blocks.RenderWithWarnings(doc)
+		assert.Empty(t, warnings, "seed %d", seed)
+	}
+
+	assert.Equal(t, Generate(GenerateOptions{Seed: 7}), Generate(GenerateOptions{Seed: 7}))
+	assert.NotEqual(t, Generate(GenerateOptions{Seed: 7}), Generate(GenerateOptions{Seed: 8}))
+
+	doc := Generate(GenerateOptions{Blocks: 5, Mix: map[blocks.BlockType]int{blocks.BlockTypeCode: 1}})
+	for _, b := range doc {
+		assert.Equal(t, blocks.BlockTypeCode, b.Type)
+	}
+
+	for _, mix := range []map[blocks.BlockType]int{
+		{blocks.BlockTypeMention: 1},
+		{blocks.BlockTypeParagraph: 0, blocks.BlockTypeCode: -1},
+	} {
+		assert.Equal(t, Generate(GenerateOptions{Seed: 3}), Generate(GenerateOptions{Seed: 3, Mix: mix}), "falls back to DefaultMix")
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	doc := Generate(GenerateOptions{Seed: 1, Blocks: 200, Modifiers: 0.2})
+	r := blocks.New()
+	b.ResetTimer()
+	for range b.N {
+		r.Render(doc)
+	}
+}
+
+// withoutNulls encodes the blocks like strapi does, without the unset fields
+func withoutNulls(t *testing.T, doc []blocks.Block) []byte {
+	raw, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	var tree any
+	assert.NoError(t, json.Unmarshal(raw, &tree))
+	var drop func(v any)
+	drop = func(v any) {
+		switch v := v.(type) {
+		case []any:
+			for _, c := range v {
+				drop(c)
+			}
+		case map[string]any:
+			for k, c := range v {
+				if c == nil {
+					delete(v, k)
+				}
+				drop(c)
+			}
+		}
+	}
+	drop(tree)
+	raw, err = json.Marshal(tree)
+	assert.NoError(t, err)
+	return raw
+}
@@ -0,0 +1,121 @@
+package blocks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+)
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+var errInvalidBlurhash = errors.New("invalid blurhash")
+
+// blurhashDataURI decodes a blurhash into a small png data uri.
+func blurhashDataURI(hash string, width, height int) (string, error) {
+	img, err := decodeBlurhash(hash, width, height)
+	if err != nil {
+		return "", err
+	}
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func decodeBlurhash(hash string, width, height int) (image.Image, error) {
+	if len(hash) < 6 {
+		return nil, errInvalidBlurhash
+	}
+	sizeFlag, err := decode83(hash[0:1])
+	if err != nil {
+		return nil, err
+	}
+	numY := sizeFlag/9 + 1
+	numX := sizeFlag%9 + 1
+	if len(hash) != 4+2*numX*numY {
+		return nil, errInvalidBlurhash
+	}
+
+	quantisedMaximum, err := decode83(hash[1:2])
+	if err != nil {
+		return nil, err
+	}
+	maximum := float64(quantisedMaximum+1) / 166
+
+	colors := make([][3]float64, numX*numY)
+	for i := range colors {
+		if i == 0 {
+			v, err := decode83(hash[2:6])
+			if err != nil {
+				return nil, err
+			}
+			colors[i] = [3]float64{srgbToLinear(v >> 16), srgbToLinear(v >> 8 & 255), srgbToLinear(v & 255)}
+			continue
+		}
+		v, err := decode83(hash[4+i*2 : 6+i*2])
+		if err != nil {
+			return nil, err
+		}
+		colors[i] = [3]float64{
+			signPow((float64(v/(19*19))-9)/9, 2) * maximum,
+			signPow((float64(v/19%19)-9)/9, 2) * maximum,
+			signPow((float64(v%19)-9)/9, 2) * maximum,
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var r, g, b float64
+			for j := 0; j < numY; j++ {
+				for i := 0; i < numX; i++ {
+					basis := math.Cos(math.Pi*float64(x*i)/float64(width)) * math.Cos(math.Pi*float64(y*j)/float64(height))
+					c := colors[i+j*numX]
+					r += c[0] * basis
+					g += c[1] * basis
+					b += c[2] * basis
+				}
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: linearToSrgb(r), G: linearToSrgb(g), B: linearToSrgb(b), A: 255})
+		}
+	}
+	return img, nil
+}
+
+func decode83(s string) (int, error) {
+	v := 0
+	for _, c := range s {
+		i := strings.IndexRune(base83Chars, c)
+		if i < 0 {
+			return 0, errInvalidBlurhash
+		}
+		v = v*83 + i
+	}
+	return v, nil
+}
+
+func srgbToLinear(v int) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+func linearToSrgb(v float64) uint8 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return uint8(v*12.92*255 + 0.5)
+	}
+	return uint8((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
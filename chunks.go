@@ -0,0 +1,107 @@
+package blocks
+
+import (
+	"slices"
+	"strings"
+)
+
+// ChunkOptions configures the size of the chunks of Chunks. Sizes are given
+// in words, as a rough approximation of the tokens of embedding models.
+type ChunkOptions struct {
+	// MaxWords defaults to 200
+	MaxWords int
+	// Overlap is the number of words repeated from the end of the previous
+	// chunk, it defaults to 0 and is capped below MaxWords.
+	Overlap int
+}
+
+// Chunk is a piece of the plain text of a document.
+type Chunk struct {
+	Text string
+	// Headings are the headings the chunk is nested in, outermost first
+	Headings []string
+	// Paths point to the blocks the text was taken from, see Walk
+	Paths [][]int
+}
+
+// Chunks splits the plain text of a document into chunks for embedding
+// models. Chunks never span headings and list items are split
+// individually.
+func Chunks(blocks []Block, opts ChunkOptions) []Chunk {
+	if opts.MaxWords <= 0 {
+		opts.MaxWords = 200
+	}
+	opts.Overlap = min(max(opts.Overlap, 0), opts.MaxWords-1)
+	c := chunker{opts: opts}
+	for i, b := range blocks {
+		c.block([]int{i}, b)
+	}
+	c.flush(false)
+	return c.chunks
+}
+
+type chunker struct {
+	opts     ChunkOptions
+	chunks   []Chunk
+	headings []string
+	levels   []int
+	// words of the current chunk with the path of their block
+	words []string
+	paths [][]int
+	// fresh counts the words of the current chunk which are not overlap
+	fresh int
+}
+
+func (c *chunker) block(path []int, b Block) {
+	switch b.Type {
+	case BlockTypeHeading:
+		c.flush(false)
+		level := 1
+		if b.Level != nil {
+			level = *b.Level
+		}
+		for len(c.levels) > 0 && c.levels[len(c.levels)-1] >= level {
+			c.levels = c.levels[:len(c.levels)-1]
+			c.headings = c.headings[:len(c.headings)-1]
+		}
+		c.levels = append(c.levels, level)
+		c.headings = append(c.headings, strings.TrimSpace(b.PlainText()))
+	case BlockTypeList:
+		for i, item := range b.Children {
+			c.block(append(slices.Clip(path), i), item)
+		}
+	default:
+		for _, word := range strings.Fields(b.PlainText()) {
+			if c.fresh > 0 && len(c.words) >= c.opts.MaxWords {
+				c.flush(true)
+			}
+			c.words = append(c.words, word)
+			c.paths = append(c.paths, path)
+			c.fresh++
+		}
+	}
+}
+
+// flush ends the current chunk, keeping the overlap for the next one if the
+// chunk is split within a section.
+func (c *chunker) flush(overlap bool) {
+	if c.fresh == 0 {
+		c.words, c.paths = nil, nil
+		return
+	}
+	chunk := Chunk{Text: strings.Join(c.words, " "), Headings: slices.Clone(c.headings)}
+	for _, p := range c.paths {
+		if len(chunk.Paths) == 0 || !slices.Equal(chunk.Paths[len(chunk.Paths)-1], p) {
+			chunk.Paths = append(chunk.Paths, p)
+		}
+	}
+	c.chunks = append(c.chunks, chunk)
+
+	keep := 0
+	if overlap {
+		keep = c.opts.Overlap
+	}
+	c.words = slices.Clone(c.words[len(c.words)-keep:])
+	c.paths = slices.Clone(c.paths[len(c.paths)-keep:])
+	c.fresh = 0
+}
@@ -0,0 +1,39 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunks(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro text"),
+		heading(2, "Setup"),
+		paragraph("one two three four five"),
+		{Type: BlockTypeList, Format: ptr(string(ListFormatUnordered)), Children: []Block{
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeText, Text: ptr("six seven")}}},
+		}},
+		heading(3, "Details"),
+		paragraph("eight"),
+		heading(2, "Usage"),
+		heading(3, "Empty"),
+		paragraph("nine ten"),
+	}
+
+	assert.Equal(t, []Chunk{
+		{Text: "intro text", Paths: [][]int{{0}}},
+		{Text: "one two three", Headings: []string{"Setup"}, Paths: [][]int{{2}}},
+		{Text: "two three four", Headings: []string{"Setup"}, Paths: [][]int{{2}}},
+		{Text: "three four five", Headings: []string{"Setup"}, Paths: [][]int{{2}}},
+		{Text: "four five six", Headings: []string{"Setup"}, Paths: [][]int{{2}, {3, 0}}},
+		{Text: "five six seven", Headings: []string{"Setup"}, Paths: [][]int{{2}, {3, 0}}},
+		{Text: "eight", Headings: []string{"Setup", "Details"}, Paths: [][]int{{5}}},
+		{Text: "nine ten", Headings: []string{"Usage", "Empty"}, Paths: [][]int{{8}}},
+	}, Chunks(doc, ChunkOptions{MaxWords: 3, Overlap: 2}))
+
+	chunks := Chunks(doc, ChunkOptions{})
+	assert.Len(t, chunks, 4)
+	assert.Equal(t, "one two three four five six seven", chunks[1].Text)
+}
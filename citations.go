@@ -0,0 +1,127 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+)
+
+// blockTypeReferences is the references section generated by Citations,
+// blockTypeReference its entries with the id as url
+const blockTypeReferences BlockType = "citation-references"
+const blockTypeReference BlockType = "citation-reference"
+
+// Reference is an entry of the bibliography of Citations.
+type Reference struct {
+	// Key is the name citations reference the entry by
+	Key  string
+	Text string
+	// URL links the text when set
+	URL string
+}
+
+// CitationOptions configures Citations.
+type CitationOptions struct {
+	// References is the bibliography supplied alongside the blocks, a
+	// trailing bibliography block adds its entries.
+	References []Reference
+	// Title is the heading of the references section, defaults to
+	// "References". The section class is set with ElementReferences.
+	Title string
+	// IDPrefix prefixes the anchor ids of the references, defaults to "ref-".
+	IDPrefix string
+}
+
+// Citations returns a transformer numbering the citations of the document in
+// the order they are first cited. Citations become bracketed numbers like
+// [1] linking to the references section appended to the document, which
+// lists the cited references first, followed by the others. Citations of
+// unknown references are left as they are.
+func Citations(opts CitationOptions) Transformer {
+	if opts.Title == "" {
+		opts.Title = "References"
+	}
+	if opts.IDPrefix == "" {
+		opts.IDPrefix = "ref-"
+	}
+	return TransformerFunc(func(blocks []Block) []Block {
+		entries := map[string][]Block{}
+		keys := []string{}
+		add := func(key string, children []Block) {
+			if _, ok := entries[key]; !ok && key != "" {
+				entries[key] = children
+				keys = append(keys, key)
+			}
+		}
+		for _, ref := range opts.References {
+			add(ref.Key, ref.blocks())
+		}
+		if n := len(blocks); n > 0 && blocks[n-1].Type == BlockTypeBibliography {
+			for _, e := range blocks[n-1].Children {
+				if e.Type == BlockTypeBibliographyEntry {
+					add(deref(e.Target), e.Children)
+				}
+			}
+			blocks = blocks[:n-1]
+		}
+		if len(keys) == 0 {
+			return blocks
+		}
+
+		numbers := map[string]int{}
+		cited := []string{}
+		var cite func(blocks []Block) []Block
+		cite = func(blocks []Block) []Block {
+			if blocks == nil {
+				return nil
+			}
+			out := make([]Block, 0, len(blocks))
+			for _, b := range blocks {
+				key := deref(b.Target)
+				if b.Type != BlockTypeCitation || entries[key] == nil {
+					b.Children = cite(b.Children)
+					out = append(out, b)
+					continue
+				}
+				if numbers[key] == 0 {
+					cited = append(cited, key)
+					numbers[key] = len(cited)
+				}
+				href := "#" + opts.IDPrefix + Slug(key)
+				label := fmt.Sprintf("[%d]", numbers[key])
+				out = append(out, Block{Type: BlockTypeLink, URL: &href, Children: []Block{{Type: BlockTypeText, Text: &label}}})
+			}
+			return out
+		}
+		blocks = cite(blocks)
+
+		list := []Block{}
+		for _, key := range append(cited, keys...) {
+			if numbers[key] < 0 {
+				continue
+			}
+			numbers[key] = -1
+			id := opts.IDPrefix + Slug(key)
+			list = append(list, Block{Type: blockTypeReference, URL: &id, Children: entries[key]})
+		}
+		title := opts.Title
+		return append(blocks, Block{Type: blockTypeReferences, Title: &title, Children: list})
+	})
+}
+
+func (ref Reference) blocks() []Block {
+	text := []Block{{Type: BlockTypeText, Text: &ref.Text}}
+	if ref.URL == "" {
+		return text
+	}
+	return []Block{{Type: BlockTypeLink, URL: &ref.URL, Children: text}}
+}
+
+func (r *Renderer) renderReferences(b Block) string {
+	return fmt.Sprintf("<section%s><h2>%s</h2><ol>%s</ol></section>",
+		r.attrs(ElementReferences), html.EscapeString(deref(b.Title)), r.internalRender(b.Children))
+}
+
+// renderCitation renders citations Citations did not resolve as their key
+func (r *Renderer) renderCitation(b Block) string {
+	return fmt.Sprintf("[%s]", html.EscapeString(r.unresolvedReference(b)))
+}
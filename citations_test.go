@@ -0,0 +1,67 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCitations(t *testing.T) {
+
+	raw := []byte(`[
+		{"type": "paragraph", "children": [
+			{"type": "text", "text": "Premature optimization"},
+			{"type": "citation", "target": "knuth74"},
+			{"type": "text", "text": " is evil, literate programming"},
+			{"type": "citation", "target": "knuth84"},
+			{"type": "citation", "target": "knuth74"},
+			{"type": "citation", "target": "missing"}
+		]},
+		{"type": "bibliography", "children": [
+			{"type": "bibliography-entry", "target": "knuth84", "children": [{"type": "text", "text": "Knuth, Literate Programming, 1984"}]},
+			{"type": "bibliography-entry", "target": "dijkstra68", "children": [{"type": "text", "text": "Dijkstra, Go To Statement Considered Harmful, 1968"}]}
+		]}
+	]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	r := New(WithTransformers(Citations(CitationOptions{References: []Reference{
+		{Key: "knuth74", Text: "Knuth, Structured Programming with go to Statements, 1974", URL: "https://doi.org/10.1145/356635.356640"},
+	}})))
+	out, warnings := r.RenderWithWarnings(doc)
+	assert.Equal(t, `<p>
+  Premature optimization
+  <a href="#ref-knuth74">
+    [1]
+  </a>
+  is evil, literate programming
+  <a href="#ref-knuth84">
+    [2]
+  </a>
+  <a href="#ref-knuth74">
+    [1]
+  </a>
+  [missing]
+</p>
+<section class="references">
+  <h2>
+    References
+  </h2>
+  <ol>
+    <li id="ref-knuth74">
+      <a href="https://doi.org/10.1145/356635.356640">
+        Knuth, Structured Programming with go to Statements, 1974
+      </a>
+    </li>
+    <li id="ref-knuth84">
+      Knuth, Literate Programming, 1984
+    </li>
+    <li id="ref-dijkstra68">
+      Dijkstra, Go To Statement Considered Harmful, 1968
+    </li>
+  </ol>
+</section>`, out)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, WarningUnresolvedReference, warnings[0].Code)
+}
@@ -0,0 +1,114 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Element names a piece of the rendered markup that can carry a class.
+type Element string
+
+const ElementDocument Element = "document"
+const ElementParagraph Element = "paragraph"
+const ElementHeading Element = "heading"
+const ElementList Element = "list"
+const ElementListItem Element = "list-item"
+const ElementLink Element = "link"
+const ElementImage Element = "image"
+const ElementQuote Element = "quote"
+const ElementQuoteFigure Element = "quote-figure"
+const ElementPre Element = "pre"
+const ElementFigure Element = "figure"
+const ElementFigcaption Element = "figcaption"
+const ElementMention Element = "mention"
+const ElementCodeBlock Element = "code-block"
+const ElementCodeLine Element = "code-line"
+const ElementCodeLineNumber Element = "code-line-number"
+const ElementCodeHighlight Element = "code-highlight"
+const ElementMark Element = "mark"
+const ElementKeyboard Element = "kbd"
+const ElementAbbreviation Element = "abbreviation"
+const ElementDefinitionList Element = "definition-list"
+const ElementDefinitionTerm Element = "definition-term"
+const ElementDefinitionDescription Element = "definition-description"
+const ElementDetails Element = "details"
+const ElementSummary Element = "summary"
+const ElementColumns Element = "columns"
+const ElementColumn Element = "column"
+const ElementEmbed Element = "embed"
+const ElementEmbedFrame Element = "embed-frame"
+const ElementGallery Element = "gallery"
+const ElementGalleryItem Element = "gallery-item"
+const ElementReferences Element = "references"
+const ElementPrintURL Element = "print-url"
+const ElementAnnotation Element = "annotation"
+
+// Classes maps elements to the class attribute they are rendered with.
+type Classes map[Element]string
+
+func defaultClasses() Classes {
+	return Classes{
+		ElementMention:        "mention",
+		ElementCodeLine:       "line",
+		ElementCodeLineNumber: "line-number",
+		ElementCodeHighlight:  "highlight",
+		ElementCodeBlock:      "code-block",
+		ElementColumns:        "columns",
+		ElementColumn:         "column",
+		ElementEmbed:          "embed",
+		ElementEmbedFrame:     "embed-frame",
+		ElementGallery:        "gallery",
+		ElementReferences:     "references",
+		ElementPrintURL:       "print-url",
+		ElementAnnotation:     "annotation",
+	}
+}
+
+// WithClasses sets the classes of the given elements, other elements keep
+// their classes.
+func WithClasses(c Classes) Option {
+	return func(r *Renderer) {
+		if r.classes == nil {
+			r.classes = Classes{}
+		}
+		for el, class := range c {
+			r.classes[el] = class
+		}
+	}
+}
+
+// attrs returns the class attribute and the extra attributes of the
+// elements, including the leading space. In inline style mode the style
+// attribute is returned instead of the class.
+func (r *Renderer) attrs(els ...Element) string {
+	return r.attrsStyle("", els...)
+}
+
+// attrsStyle is attrs with an additional inline style, which is merged with
+// the styles of the elements in inline style mode.
+func (r *Renderer) attrsStyle(style string, els ...Element) string {
+	var attrs string
+	if r.styles != nil {
+		for _, el := range els {
+			style = strings.TrimSpace(style + " " + r.styles[el])
+		}
+	} else {
+		classes := []string{}
+		for _, el := range els {
+			if class := r.classes[el]; class != "" {
+				classes = append(classes, class)
+			}
+		}
+		if len(classes) > 0 {
+			attrs = fmt.Sprintf(` class="%s"`, html.EscapeString(strings.Join(classes, " ")))
+		}
+	}
+	if style != "" {
+		attrs += fmt.Sprintf(` style="%s"`, html.EscapeString(style)) + r.nonceAttr()
+	}
+	for _, el := range els {
+		attrs += r.elementAttributes(el).String()
+	}
+	return attrs
+}
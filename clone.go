@@ -0,0 +1,70 @@
+package blocks
+
+import (
+	"maps"
+	"slices"
+)
+
+// With returns a copy of the renderer with the options applied on top of its
+// configuration, for cheap per-request variants of a shared renderer. Block
+// renderers pointing to the original renderer are pointed to the copy, other
+// custom renderers are shared.
+func (r *Renderer) With(opts ...Option) *Renderer {
+	c := *r
+	clone := &c
+	clone.ctx = nil
+	clone.path = nil
+	clone.warnings = nil
+
+	// options modify these in place
+	clone.classes = maps.Clone(r.classes)
+	clone.styles = maps.Clone(r.styles)
+	clone.attributes = maps.Clone(r.attributes)
+	clone.components = maps.Clone(r.components)
+	clone.linkRewriters = slices.Clip(r.linkRewriters)
+	clone.transformers = slices.Clip(r.transformers)
+	clone.blockRenderers = slices.Clip(r.blockRenderers)
+
+	clone.ParagraphRenderer = rebind(r.ParagraphRenderer, r, clone)
+	clone.TextRenderer = rebind(r.TextRenderer, r, clone)
+	clone.ListRenderer = rebind(r.ListRenderer, r, clone)
+	clone.ListItemRenderer = rebind(r.ListItemRenderer, r, clone)
+	clone.HeadingRenderer = rebind(r.HeadingRenderer, r, clone)
+	clone.LinkRenderer = rebind(r.LinkRenderer, r, clone)
+	clone.ImageRenderer = rebind(r.ImageRenderer, r, clone)
+	clone.QuoteRenderer = rebind(r.QuoteRenderer, r, clone)
+	clone.CodeRenderer = rebind(r.CodeRenderer, r, clone)
+	clone.MentionRenderer = rebind(r.MentionRenderer, r, clone)
+	clone.MathRenderer = rebind(r.MathRenderer, r, clone)
+	clone.AbbreviationRenderer = rebind(r.AbbreviationRenderer, r, clone)
+	clone.DefinitionListRenderer = rebind(r.DefinitionListRenderer, r, clone)
+	clone.DefinitionTermRenderer = rebind(r.DefinitionTermRenderer, r, clone)
+	clone.DefinitionDescriptionRenderer = rebind(r.DefinitionDescriptionRenderer, r, clone)
+	clone.DetailsRenderer = rebind(r.DetailsRenderer, r, clone)
+	clone.ColumnsRenderer = rebind(r.ColumnsRenderer, r, clone)
+	clone.ColumnRenderer = rebind(r.ColumnRenderer, r, clone)
+	clone.HTMLRenderer = rebind(r.HTMLRenderer, r, clone)
+	clone.GalleryRenderer = rebind(r.GalleryRenderer, r, clone)
+	clone.AnnotationRenderer = rebind(r.AnnotationRenderer, r, clone)
+	clone.ModifierRenderer = rebind(r.ModifierRenderer, r, clone)
+
+	for _, opt := range opts {
+		opt(clone)
+	}
+	return clone
+}
+
+func rebind[T any](field T, old, clone *Renderer) T {
+	switch v := any(field).(type) {
+	case *Renderer:
+		if v == old {
+			return any(clone).(T)
+		}
+	case templateRenderer:
+		if v.r == old {
+			v.r = clone
+			return any(v).(T)
+		}
+	}
+	return field
+}
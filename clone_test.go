@@ -0,0 +1,47 @@
+package blocks
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type shortQuote struct{}
+
+func (shortQuote) RenderQuote(b Block) string {
+	return "<q>quote</q>"
+}
+
+func TestRenderer_With(t *testing.T) {
+
+	doc := []Block{
+		paragraph("text"),
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", Width: 4, Height: 3}},
+		{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quote")}}},
+	}
+
+	base := New(WithClasses(Classes{ElementParagraph: "lead"}), WithAspectRatio())
+	base.QuoteRenderer = shortQuote{}
+	want := base.Render(doc)
+
+	derived := base.With(WithNonce("abc"), WithClasses(Classes{ElementParagraph: "intro"}))
+	out := derived.Render(doc)
+	assert.Contains(t, out, `<p class="intro">`)
+	assert.Contains(t, out, `nonce="abc"`)
+	assert.Contains(t, out, "<q>\n  quote\n</q>")
+
+	assert.Equal(t, want, base.Render(doc))
+	assert.Same(t, derived, derived.ParagraphRenderer)
+	assert.Same(t, base, base.ParagraphRenderer)
+}
+
+func TestRenderer_WithTemplates(t *testing.T) {
+
+	base := New(WithTemplates(fstest.MapFS{"paragraph.html": {Data: []byte(`<p>{{.Children}}</p>`)}}))
+	derived := base.With(WithPlaceholders(Placeholders{MissingText: "[missing]"}))
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeText}}}}
+	assert.Equal(t, "<p>\n  [missing]\n</p>", derived.Render(doc))
+	assert.Equal(t, "<p></p>", base.Render(doc))
+}
@@ -0,0 +1,49 @@
+// Command blocksd serves the renderer over gRPC, see the blocksgrpc package
+// for the service definition:
+//
+//	blocksd -addr :50051 -theme tailwind-prose
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"github.com/cdreier/strapi-blocks-go-renderer/blocksgrpc"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":50051", "listen address")
+	theme := flag.String("theme", blocks.ThemePlain, "default theme, requests may choose another one")
+	flag.Parse()
+
+	if err := run(*addr, *theme); err != nil {
+		fmt.Fprintln(os.Stderr, "blocksd:", err)
+		os.Exit(1)
+	}
+}
+
+func run(addr, theme string) error {
+	if _, ok := blocks.Themes[theme]; !ok {
+		return fmt.Errorf("unknown theme %q", theme)
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer()
+	blocksgrpc.RegisterBlocksServiceServer(srv, blocksgrpc.NewServer(blocks.WithTheme(theme), blocks.WithRecover(true)))
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		srv.GracefulStop()
+	}()
+	return srv.Serve(lis)
+}
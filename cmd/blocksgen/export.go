@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+)
+
+// exportConfig holds the flags of the export command
+type exportConfig struct {
+	API        string
+	Token      string
+	Collection string
+	Field      string
+	SlugField  string
+	PageSize   int
+	Out        string
+	AssetsURL  string
+	Theme      string
+}
+
+func exportMain(args []string) error {
+	cfg := exportConfig{}
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.StringVar(&cfg.API, "api", "http://localhost:1337", "url of the strapi server")
+	flags.StringVar(&cfg.Token, "token", os.Getenv("STRAPI_TOKEN"), "api token, defaults to $STRAPI_TOKEN")
+	flags.StringVar(&cfg.Collection, "collection", "", "plural api id of the collection, like articles")
+	flags.StringVar(&cfg.Field, "field", "content", "blocks field of the entries")
+	flags.StringVar(&cfg.SlugField, "slug", "slug", "field naming the html files, the document id is used for entries without it")
+	flags.IntVar(&cfg.PageSize, "page-size", 100, "entries fetched per request")
+	flags.StringVar(&cfg.Out, "out", "public", "output directory")
+	flags.StringVar(&cfg.AssetsURL, "assets-url", "/uploads", "url the mirrored uploads in the uploads directory are served from")
+	flags.StringVar(&cfg.Theme, "theme", blocks.ThemePlain, "theme the html is rendered with")
+	flags.Parse(args)
+	return runExport(context.Background(), http.DefaultClient, cfg)
+}
+
+// entry is an entry of the collection
+type entry struct {
+	ID     string
+	Slug   string
+	Blocks []blocks.Block
+}
+
+// manifestEntry describes an exported entry in index.json
+type manifestEntry struct {
+	ID          string   `json:"id"`
+	Slug        string   `json:"slug"`
+	Path        string   `json:"path,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	ReadingTime int      `json:"readingTime,omitempty"`
+	Assets      int      `json:"assets,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// manifest is written to index.json next to the html files
+type manifest struct {
+	Collection string          `json:"collection"`
+	Entries    []manifestEntry `json:"entries"`
+}
+
+// runExport renders every entry of the collection into <slug>/index.html,
+// mirrors the uploads into the uploads directory and writes the manifest.
+// Failed entries are listed in the manifest and fail the export after all
+// entries are written.
+func runExport(ctx context.Context, client *http.Client, cfg exportConfig) error {
+	if cfg.Collection == "" {
+		return fmt.Errorf("no collection given")
+	}
+	if _, ok := blocks.Themes[cfg.Theme]; !ok {
+		return fmt.Errorf("unknown theme %q", cfg.Theme)
+	}
+	entries, err := fetchEntries(ctx, client, cfg)
+	if err != nil {
+		return err
+	}
+
+	store := blocks.DirStore{Dir: filepath.Join(cfg.Out, "uploads"), BaseURL: cfg.AssetsURL}
+	index := manifest{Collection: cfg.Collection, Entries: make([]manifestEntry, len(entries))}
+	docs := make([]blocks.Document, len(entries))
+	for i, e := range entries {
+		index.Entries[i] = manifestEntry{ID: e.ID, Slug: e.Slug}
+		mirrored, assets, err := blocks.Mirror(ctx, e.Blocks, store, blocks.MirrorOptions{Client: client, BaseURL: cfg.API})
+		if err != nil {
+			index.Entries[i].Error = err.Error()
+		}
+		index.Entries[i].Assets = len(assets)
+		docs[i] = blocks.Document{ID: e.ID, Blocks: mirrored}
+	}
+
+	r := blocks.New(blocks.WithTheme(cfg.Theme))
+	failed := 0
+	for i, res := range r.RenderBatch(ctx, docs) {
+		m := &index.Entries[i]
+		for _, w := range res.Warnings {
+			m.Warnings = append(m.Warnings, w.Message)
+		}
+		if m.Error == "" && res.Err != nil {
+			m.Error = res.Err.Error()
+		}
+		if m.Error != "" {
+			failed++
+			continue
+		}
+		fm := blocks.NewFrontMatter(docs[i].Blocks, 0)
+		m.Title, m.ReadingTime = fm.Title, fm.ReadingTime
+		m.Path = m.Slug + "/index.html"
+		if err := writePage(filepath.Join(cfg.Out, filepath.FromSlash(m.Path)), fm.Title, r.Stylesheet(), res.HTML); err != nil {
+			return err
+		}
+	}
+
+	raw, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(cfg.Out, "index.json"), raw, 0o644); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d entries failed, see index.json", failed, len(entries))
+	}
+	return nil
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+{{.Content}}
+</body>
+</html>
+`))
+
+func writePage(file, title, style, content string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	err = pageTemplate.Execute(f, map[string]any{"Title": title, "Style": template.CSS(style), "Content": template.HTML(content)})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// fetchEntries pages through the collection of the content api. Entries of
+// strapi v4 are unwrapped from their attributes and the blocks are
+// normalized with Migrate.
+func fetchEntries(ctx context.Context, client *http.Client, cfg exportConfig) ([]entry, error) {
+	entries := []entry{}
+	slugs := map[string]bool{}
+	for p, pages := 1, 1; p <= pages; p++ {
+		var res struct {
+			Data []map[string]json.RawMessage `json:"data"`
+			Meta struct {
+				Pagination struct {
+					PageCount int `json:"pageCount"`
+				} `json:"pagination"`
+			} `json:"meta"`
+		}
+		if err := getJSON(ctx, client, cfg, p, &res); err != nil {
+			return nil, err
+		}
+		pages = res.Meta.Pagination.PageCount
+		for _, data := range res.Data {
+			e, err := parseEntry(data, cfg)
+			if err != nil {
+				return nil, err
+			}
+			if slugs[e.Slug] {
+				return nil, fmt.Errorf("%s: duplicate slug %q", cfg.Collection, e.Slug)
+			}
+			slugs[e.Slug] = true
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, cfg exportConfig, page int, v any) error {
+	query := url.Values{}
+	query.Set("pagination[page]", strconv.Itoa(page))
+	query.Set("pagination[pageSize]", strconv.Itoa(cfg.PageSize))
+	u := strings.TrimSuffix(cfg.API, "/") + "/api/" + url.PathEscape(cfg.Collection) + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: %s", cfg.Collection, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func parseEntry(data map[string]json.RawMessage, cfg exportConfig) (entry, error) {
+	fields := data
+	if attributes, ok := data["attributes"]; ok {
+		fields = nil
+		if err := json.Unmarshal(attributes, &fields); err != nil {
+			return entry{}, err
+		}
+	}
+	e := entry{ID: jsonString(data["documentId"])}
+	if e.ID == "" {
+		e.ID = jsonString(data["id"])
+	}
+	e.Slug = blocks.Slug(jsonString(fields[cfg.SlugField]))
+	if e.Slug == "" {
+		e.Slug = blocks.Slug(e.ID)
+	}
+	raw, ok := fields[cfg.Field]
+	if !ok || string(raw) == "null" {
+		return e, nil
+	}
+	doc, _, err := blocks.Migrate(raw)
+	if err != nil {
+		return entry{}, fmt.Errorf("entry %s: %s: %w", e.ID, cfg.Field, err)
+	}
+	e.Blocks = doc
+	return e, nil
+}
+
+// jsonString returns strings and numbers as text
+func jsonString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunExport(t *testing.T) {
+	pages := []string{
+		`{"data": [
+			{"id": 1, "documentId": "a1", "slug": "Hello World", "content": [
+				{"type": "heading", "level": 1, "children": [{"type": "text", "text": "Hello"}]},
+				{"type": "image", "image": {"name": "a.jpg", "url": "/uploads/a.jpg"}, "children": []}
+			]},
+			{"id": 2, "documentId": "b2", "content": null}
+		], "meta": {"pagination": {"page": 1, "pageCount": 2}}}`,
+		`{"data": [
+			{"id": 3, "attributes": {"slug": "broken", "content": [
+				{"type": "image", "image": {"data": {"id": 9, "attributes": {"url": "/uploads/gone.jpg"}}}, "children": []}
+			]}}
+		], "meta": {"pagination": {"page": 2, "pageCount": 2}}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/articles":
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			assert.Equal(t, "10", r.URL.Query().Get("pagination[pageSize]"))
+			var page int
+			fmt.Sscan(r.URL.Query().Get("pagination[page]"), &page)
+			w.Write([]byte(pages[page-1]))
+		case "/uploads/a.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("jpeg"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	out := t.TempDir()
+	cfg := exportConfig{API: srv.URL, Token: "secret", Collection: "articles", Field: "content", SlugField: "slug", PageSize: 10, Out: out, AssetsURL: "/uploads", Theme: "plain"}
+	err := runExport(context.Background(), srv.Client(), cfg)
+	assert.EqualError(t, err, "1 of 3 entries failed, see index.json")
+
+	html, err := os.ReadFile(filepath.Join(out, "hello-world", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(html), "<title>Hello</title>")
+	src := regexp.MustCompile(`src="/uploads/([0-9a-f]+\.jpg)"`).FindSubmatch(html)
+	assert.NotNil(t, src)
+	asset, err := os.ReadFile(filepath.Join(out, "uploads", string(src[1])))
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", string(asset))
+	assert.FileExists(t, filepath.Join(out, "b2", "index.html"))
+	assert.NoFileExists(t, filepath.Join(out, "broken", "index.html"))
+
+	raw, err := os.ReadFile(filepath.Join(out, "index.json"))
+	assert.NoError(t, err)
+	index := manifest{}
+	assert.NoError(t, json.Unmarshal(raw, &index))
+	assert.Equal(t, "articles", index.Collection)
+	assert.Len(t, index.Entries, 3)
+	assert.Equal(t, manifestEntry{ID: "a1", Slug: "hello-world", Path: "hello-world/index.html", Title: "Hello", ReadingTime: 1, Assets: 1}, index.Entries[0])
+	assert.Equal(t, "b2/index.html", index.Entries[1].Path)
+	assert.Equal(t, "3", index.Entries[2].ID)
+	assert.Contains(t, index.Entries[2].Error, "404")
+}
+
+func TestRunExport_options(t *testing.T) {
+	assert.EqualError(t, runExport(context.Background(), http.DefaultClient, exportConfig{Theme: "plain"}), "no collection given")
+	assert.EqualError(t, runExport(context.Background(), http.DefaultClient, exportConfig{Collection: "articles", Theme: "neon"}), `unknown theme "neon"`)
+}
@@ -0,0 +1,126 @@
+// Command blocksgen renders strapi blocks json files at build time into a go
+// file holding the html as constants, for binaries baking in a snapshot of
+// the CMS content:
+//
+//	//go:generate go run github.com/cdreier/strapi-blocks-go-renderer/cmd/blocksgen -pkg content -out content_gen.go about.json home.json
+//
+// Every file becomes a constant named after the file, about.json is rendered
+// into the constant About.
+//
+// The export command renders a whole collection of the strapi content api
+// into a static html file tree with the uploads mirrored next to it, plus
+// an index.json manifest of the entries:
+//
+//	blocksgen export -api https://cms.example.com -collection articles -field content -out public
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := exportMain(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "blocksgen export:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated file, defaults to $GOPACKAGE")
+	out := flag.String("out", "blocks_gen.go", "generated file")
+	theme := flag.String("theme", blocks.ThemePlain, "theme the html is rendered with")
+	flag.Parse()
+
+	if err := run(*pkg, *out, *theme, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "blocksgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(pkg, out, theme string, files []string) error {
+	if pkg == "" {
+		return fmt.Errorf("no package given")
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no input files given")
+	}
+	if _, ok := blocks.Themes[theme]; !ok {
+		return fmt.Errorf("unknown theme %q", theme)
+	}
+	src, err := generate(pkg, blocks.New(blocks.WithTheme(theme)), files)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+// generate renders the files into the source of a go file
+func generate(pkg string, r *blocks.Renderer, files []string) ([]byte, error) {
+	src := bytes.Buffer{}
+	fmt.Fprintf(&src, "// Code generated by blocksgen; DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	names := map[string]string{}
+	for _, file := range files {
+		name := constName(file)
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("%s and %s both map to the constant %s", other, file, name)
+		}
+		names[name] = file
+
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := blocks.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		html := strings.Builder{}
+		if err := r.RenderTo(&html, doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		fmt.Fprintf(&src, "// %s is rendered from %s.\nconst %s = %s\n\n", name, filepath.ToSlash(file), name, quote(html.String()))
+	}
+	return format.Source(src.Bytes())
+}
+
+// constName returns the exported constant name for the file, my-page.json
+// becomes MyPage
+func constName(file string) string {
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	name := strings.Builder{}
+	upper := true
+	for _, c := range base {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			upper = true
+			continue
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		name.WriteRune(c)
+	}
+	if name.Len() == 0 || !unicode.IsLetter([]rune(name.String())[0]) {
+		return "Blocks" + name.String()
+	}
+	return name.String()
+}
+
+// quote prefers raw strings, which keep the generated html readable
+func quote(s string) string {
+	if strings.Contains(s, "`") || strings.Contains(s, "\r") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("content", blocks.New(), []string{"testdata/home.json", "testdata/about-us.json"})
+	assert.NoError(t, err)
+	assert.Equal(t, "// Code generated by blocksgen; DO NOT EDIT.\n\npackage content\n\n"+
+		"// Home is rendered from testdata/home.json.\nconst Home = `<h1>\n  Home\n</h1>`\n\n"+
+		"// AboutUs is rendered from testdata/about-us.json.\nconst AboutUs = \"<p>\\n  About `us`\\n</p>\"\n", string(src))
+}
+
+func TestGenerate_duplicateNames(t *testing.T) {
+	_, err := generate("content", blocks.New(), []string{"testdata/home.json", "other/home.json"})
+	assert.ErrorContains(t, err, "both map to the constant Home")
+}
+
+func TestRun(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "gen.go")
+	assert.ErrorContains(t, run("content", out, "neon", []string{"testdata/home.json"}), `unknown theme "neon"`)
+	assert.NoError(t, run("content", out, blocks.ThemeTailwindProse, []string{"testdata/home.json"}))
+	src, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(src), `<article class="prose">`)
+}
+
+func TestConstName(t *testing.T) {
+	assert.Equal(t, "MyPage", constName("content/my-page.json"))
+	assert.Equal(t, "Blocks404", constName("404.json"))
+}
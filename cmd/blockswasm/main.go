@@ -0,0 +1,96 @@
+//go:build js && wasm
+
+// Command blockswasm exports the renderer to javascript, so browsers and edge
+// functions render previews with the same logic as the go backend:
+//
+//	GOOS=js GOARCH=wasm go build -o blocks.wasm ./cmd/blockswasm
+//
+// The module is started with the wasm_exec.js of the go installation, found
+// in $(go env GOROOT)/lib/wasm or misc/wasm before go 1.24, and registers
+// the global strapiBlocks object:
+//
+//	const { html, warnings, error } = strapiBlocks.render(entry.content, { theme: "tailwind-prose" })
+//
+// The blocks are passed as json string or as the parsed value. Only
+// syscall/js is used, so tinygo builds work as far as the dependencies of the
+// renderer support it.
+package main
+
+import (
+	"syscall/js"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+)
+
+func main() {
+	js.Global().Set("strapiBlocks", js.ValueOf(map[string]any{
+		"render":    js.FuncOf(render),
+		"validate":  js.FuncOf(validate),
+		"plainText": js.FuncOf(plainText),
+	}))
+	select {}
+}
+
+// render(blocks, options) returns {html, warnings, error}
+func render(_ js.Value, args []js.Value) any {
+	r := blocks.New()
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		if theme := args[1].Get("theme"); theme.Type() == js.TypeString {
+			if _, ok := blocks.Themes[theme.String()]; !ok {
+				return result("unknown theme " + theme.String())
+			}
+			r = blocks.New(blocks.WithTheme(theme.String()))
+		}
+	}
+	doc, err := parse(args)
+	if err != nil {
+		return result(err.Error())
+	}
+	html, warnings := r.RenderWithWarnings(doc)
+	list := make([]any, 0, len(warnings))
+	for _, w := range warnings {
+		path := make([]any, len(w.Path))
+		for i, p := range w.Path {
+			path[i] = p
+		}
+		list = append(list, map[string]any{"code": w.Code, "message": w.Message, "path": path})
+	}
+	return map[string]any{"html": html, "warnings": list, "error": nil}
+}
+
+// validate(blocks) returns a list of {path, message}, empty for valid blocks
+func validate(_ js.Value, args []js.Value) any {
+	list := []any{}
+	for _, e := range blocks.Validate([]byte(jsonArg(args))) {
+		list = append(list, map[string]any{"path": e.Path, "message": e.Message})
+	}
+	return list
+}
+
+// plainText(blocks) returns the text without markup
+func plainText(_ js.Value, args []js.Value) any {
+	doc, err := parse(args)
+	if err != nil {
+		return ""
+	}
+	return blocks.PlainText(doc)
+}
+
+func result(err string) map[string]any {
+	return map[string]any{"html": "", "warnings": []any{}, "error": err}
+}
+
+func parse(args []js.Value) ([]blocks.Block, error) {
+	return blocks.Parse([]byte(jsonArg(args)))
+}
+
+// jsonArg returns the first argument as json, parsed values are stringified
+func jsonArg(args []js.Value) string {
+	if len(args) == 0 {
+		return "null"
+	}
+	if args[0].Type() == js.TypeString {
+		return args[0].String()
+	}
+	return js.Global().Get("JSON").Call("stringify", args[0]).String()
+}
@@ -0,0 +1,37 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const doc = `[{"type": "heading", "level": 1, "children": [{"type": "text", "text": "Hello"}]}, {"type": "image", "children": []}]`
+
+func TestRender(t *testing.T) {
+	res := js.ValueOf(render(js.Undefined(), []js.Value{js.ValueOf(doc)}))
+	assert.Equal(t, "<h1>\n  Hello\n</h1>\nmissing image", res.Get("html").String())
+	assert.Equal(t, 1, res.Get("warnings").Length())
+	assert.Equal(t, "missing-image", res.Get("warnings").Index(0).Get("code").String())
+	assert.True(t, res.Get("error").IsNull())
+
+	parsed := js.Global().Get("JSON").Call("parse", doc)
+	options := js.ValueOf(map[string]any{"theme": "tailwind-prose"})
+	res = js.ValueOf(render(js.Undefined(), []js.Value{parsed, options}))
+	assert.Contains(t, res.Get("html").String(), `<article class="prose">`)
+
+	res = js.ValueOf(render(js.Undefined(), []js.Value{js.ValueOf("{")}))
+	assert.NotEmpty(t, res.Get("error").String())
+}
+
+func TestValidate(t *testing.T) {
+	assert.Equal(t, 0, js.ValueOf(validate(js.Undefined(), []js.Value{js.ValueOf(`[]`)})).Length())
+	assert.Positive(t, js.ValueOf(validate(js.Undefined(), []js.Value{js.ValueOf(doc)})).Length())
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "Hello", plainText(js.Undefined(), []js.Value{js.ValueOf(doc)}))
+}
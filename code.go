@@ -0,0 +1,130 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// WithMermaid renders code blocks with the mermaid language as diagrams. With
+// a nil renderer the source is emitted as <pre class="mermaid"> for
+// mermaid.js, otherwise render is called to produce the diagram server side,
+// for example as svg.
+func WithMermaid(render func(src string) (string, error)) Option {
+	return func(r *Renderer) {
+		r.mermaid = true
+		r.diagramRenderer = render
+	}
+}
+
+func (r *Renderer) mermaidDiagram(src string) string {
+	if r.diagramRenderer != nil {
+		out, err := r.diagramRenderer(src)
+		if err == nil {
+			return r.withNonce(out)
+		}
+		r.warn(WarningDiagramError, "cannot render mermaid diagram: %s", err)
+	}
+	return fmt.Sprintf(`<pre class="mermaid"%s>%s</pre>`, r.ariaRole("img", "diagram"), html.EscapeString(src))
+}
+
+// WithCodeLineNumbers renders code blocks line by line with a line number
+// gutter.
+func WithCodeLineNumbers() Option {
+	return func(r *Renderer) {
+		r.lineNumbers = true
+	}
+}
+
+// WithCodeHighlights highlights the returned line numbers of a code block,
+// counting from 1. Without a hook the highlightLines attribute of the block
+// is used.
+func WithCodeHighlights(lines func(b Block) []int) Option {
+	return func(r *Renderer) {
+		r.highlightLines = lines
+	}
+}
+
+func (r *Renderer) codeLines(b Block) string {
+	lines := strings.Split(b.PlainText(), "\n")
+	var highlighted map[int]bool
+	if r.highlightLines != nil {
+		highlighted = map[int]bool{}
+		for _, l := range r.highlightLines(b) {
+			highlighted[l] = true
+		}
+	} else {
+		highlighted = ParseLineRanges(deref(b.HighlightLines), len(lines))
+	}
+
+	out := strings.Builder{}
+	for i, line := range lines {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		attrs := r.attrs(ElementCodeLine)
+		if highlighted[i+1] {
+			attrs = r.attrs(ElementCodeLine, ElementCodeHighlight)
+		}
+		fmt.Fprintf(&out, `<span%s>`, attrs)
+		if r.lineNumbers {
+			fmt.Fprintf(&out, `<span%s>%d</span>`, r.attrs(ElementCodeLineNumber), i+1)
+		}
+		out.WriteString(html.EscapeString(line))
+		out.WriteString("</span>")
+	}
+	return fmt.Sprintf("<pre%s><code>%s</code></pre>", r.attrs(ElementPre), out.String())
+}
+
+// ParseLineRanges parses line ranges like "1,3-5" into the set of line
+// numbers. Ranges are clamped to the lines of the code, invalid, negative and
+// reversed parts are ignored.
+func ParseLineRanges(ranges string, lines int) map[int]bool {
+	set := map[int]bool{}
+	for _, part := range strings.Split(ranges, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(to)); err != nil {
+				continue
+			}
+		}
+		if start < 0 || end < start {
+			continue
+		}
+		for l := max(start, 1); l <= min(end, lines); l++ {
+			set[l] = true
+		}
+	}
+	return set
+}
+
+const DefaultCopyButton = `<button type="button" data-copy-code>Copy</button>`
+
+// CodeCopyOptions configures the container code blocks are wrapped in, so
+// frontends can attach copy buttons.
+type CodeCopyOptions struct {
+	// Button is the html placed after the code, defaults to DefaultCopyButton.
+	// Set it to an html comment to only emit the container.
+	Button string
+}
+
+// WithCodeCopy wraps code blocks in a container carrying the source in a
+// data-code attribute, the container class is set with ElementCodeBlock.
+func WithCodeCopy(opts CodeCopyOptions) Option {
+	if opts.Button == "" {
+		opts.Button = DefaultCopyButton
+	}
+	return func(r *Renderer) {
+		r.codeCopy = &opts
+	}
+}
+
+func (r *Renderer) copyableCode(b Block, code string) string {
+	return fmt.Sprintf(`<div%s data-code="%s">%s%s</div>`, r.attrs(ElementCodeBlock), html.EscapeString(b.PlainText()), code, r.codeCopy.Button)
+}
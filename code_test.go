@@ -0,0 +1,86 @@
+package blocks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func codeBlock(language, code string) Block {
+	b := Block{Type: BlockTypeCode, Children: []Block{{Type: BlockTypeText, Text: &code}}}
+	if language != "" {
+		b.Language = &language
+	}
+	return b
+}
+
+func TestWithMermaid(t *testing.T) {
+
+	doc := []Block{codeBlock("mermaid", "graph TD\n  A-->B")}
+
+	assert.Equal(t, "<pre><code>graph TD\n  A-->B</code></pre>", New().Render(doc))
+	assert.Equal(t, "<pre class=\"mermaid\">graph TD\n  A--&gt;B</pre>", New(WithMermaid(nil)).Render(doc))
+
+	r := New(WithMermaid(func(src string) (string, error) {
+		return "<svg></svg>", nil
+	}))
+	assert.Equal(t, "<svg></svg>", r.Render(doc))
+	assert.Equal(t, "<pre><code>plain</code></pre>", r.Render([]Block{codeBlock("go", "plain")}))
+
+	r = New(WithMermaid(func(src string) (string, error) {
+		return "", errors.New("syntax error")
+	}))
+	assert.Equal(t, "<pre class=\"mermaid\">graph TD\n  A--&gt;B</pre>", r.Render(doc))
+	assert.Equal(t, []Warning{{Code: WarningDiagramError, Message: "cannot render mermaid diagram: syntax error", Path: []int{0}}}, r.Warnings())
+}
+
+func TestWithCodeLineNumbers(t *testing.T) {
+
+	doc := []Block{codeBlock("go", "a := 1\nb := a < 2\nreturn b")}
+
+	assert.Equal(t, "<pre><code>"+
+		`<span class="line"><span class="line-number">1</span>a := 1</span>`+"\n"+
+		`<span class="line"><span class="line-number">2</span>b := a &lt; 2</span>`+"\n"+
+		`<span class="line"><span class="line-number">3</span>return b</span>`+
+		"</code></pre>", New(WithCodeLineNumbers()).Render(doc))
+
+	doc[0].HighlightLines = ptr("2-3")
+	assert.Equal(t, "<pre><code>"+
+		`<span class="line">a := 1</span>`+"\n"+
+		`<span class="line highlight">b := a &lt; 2</span>`+"\n"+
+		`<span class="line highlight">return b</span>`+
+		"</code></pre>", New().Render(doc))
+
+	r := New(WithCodeHighlights(func(b Block) []int { return []int{1} }), WithClasses(Classes{ElementCodeHighlight: "hl"}))
+	assert.Equal(t, "<pre><code>"+
+		`<span class="line hl">a := 1</span>`+"\n"+
+		`<span class="line">b := a &lt; 2</span>`+"\n"+
+		`<span class="line">return b</span>`+
+		"</code></pre>", r.Render(doc))
+}
+
+func TestParseLineRanges(t *testing.T) {
+	assert.Equal(t, map[int]bool{1: true, 3: true, 4: true, 5: true, 8: true}, ParseLineRanges("1, 3-5,x,8", 10))
+	assert.Empty(t, ParseLineRanges("", 10))
+	assert.Equal(t, map[int]bool{1: true, 2: true}, ParseLineRanges("0-50000000", 2))
+	assert.Empty(t, ParseLineRanges("5-3,-2,3-4", 2))
+}
+
+func TestWithCodeCopy(t *testing.T) {
+
+	doc := []Block{codeBlock("", `fmt.Println("hi")`)}
+
+	assert.Equal(t, `<div class="code-block" data-code="fmt.Println(&#34;hi&#34;)">
+  <pre><code>fmt.Println("hi")</code></pre>
+  <button type="button" data-copy-code>
+    Copy
+  </button>
+</div>`, New(WithCodeCopy(CodeCopyOptions{})).Render(doc))
+
+	r := New(WithCodeCopy(CodeCopyOptions{Button: "<!-- copy -->"}), WithClasses(Classes{ElementCodeBlock: "snippet"}))
+	assert.Equal(t, `<div class="snippet" data-code="fmt.Println(&#34;hi&#34;)">
+  <pre><code>fmt.Println("hi")</code></pre>
+  <!-- copy -->
+</div>`, r.Render(doc))
+}
@@ -0,0 +1,68 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// TextColorOptions configures how the color and backgroundColor of texts,
+// written by some rich text plugins, are rendered.
+type TextColorOptions struct {
+	// Classes maps colors to the class rendered for them, for example
+	// "#ff0000" to "text-red". Background colors are looked up in
+	// BackgroundClasses.
+	Classes           map[string]string
+	BackgroundClasses map[string]string
+	// Styles renders colors without a class as inline style. Only hex, rgb,
+	// hsl and named colors are allowed, other values record a warning.
+	Styles bool
+}
+
+// WithTextColors wraps texts with a color or background color in a span
+// carrying the class or the inline style of the color. Without the option
+// colors are ignored.
+func WithTextColors(opts TextColorOptions) Option {
+	return func(r *Renderer) {
+		r.textColors = &opts
+	}
+}
+
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|(rgb|rgba|hsl|hsla)\([0-9.,%\s/]+\))$`)
+
+func (r *Renderer) textColor(b Block, content string) string {
+	classes, styles := []string{}, []string{}
+	color := func(value *string, mapped map[string]string, property string) {
+		if value == nil || *value == "" {
+			return
+		}
+		v := strings.TrimSpace(*value)
+		if class, ok := mapped[v]; ok {
+			classes = append(classes, class)
+			return
+		}
+		if !r.textColors.Styles {
+			return
+		}
+		if !colorPattern.MatchString(v) {
+			r.warn(WarningInvalidColor, "invalid %s %q", property, v)
+			return
+		}
+		styles = append(styles, fmt.Sprintf("%s: %s;", property, v))
+	}
+	color(b.Color, r.textColors.Classes, "color")
+	color(b.Background, r.textColors.BackgroundClasses, "background-color")
+
+	if len(classes) == 0 && len(styles) == 0 {
+		return content
+	}
+	attrs := ""
+	if len(classes) > 0 {
+		attrs += fmt.Sprintf(` class="%s"`, html.EscapeString(strings.Join(classes, " ")))
+	}
+	if len(styles) > 0 {
+		attrs += fmt.Sprintf(` style="%s"`, strings.Join(styles, " ")) + r.nonceAttr()
+	}
+	return fmt.Sprintf("<span%s>%s</span>", attrs, content)
+}
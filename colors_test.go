@@ -0,0 +1,52 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTextColors(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("red"), Color: ptr("#f00"), Bold: ptr(true)},
+		{Type: BlockTypeText, Text: ptr("brand"), Color: ptr("brand"), Background: ptr("rgb(0, 0, 0)")},
+		{Type: BlockTypeText, Text: ptr("evil"), Color: ptr("red;background:url(x)")},
+	}}}
+
+	assert.Equal(t, `<p>
+  <strong>
+    red
+  </strong>
+  brandevil
+</p>`, Render(doc), "colors are ignored without the option")
+
+	r := New(WithTextColors(TextColorOptions{
+		Classes: map[string]string{"brand": "text-brand"},
+		Styles:  true,
+	}))
+	out, warnings := r.RenderWithWarnings(doc)
+	assert.Equal(t, `<p>
+  <span style="color: #f00;">
+    <strong>
+      red
+    </strong>
+  </span>
+  <span class="text-brand" style="background-color: rgb(0, 0, 0);">
+    brand
+  </span>
+  evil
+</p>`, out)
+	assert.Equal(t, []Warning{{Code: WarningInvalidColor, Message: `invalid color "red;background:url(x)"`, Path: []int{0, 2}}}, warnings)
+
+	r = New(WithTextColors(TextColorOptions{Classes: map[string]string{"brand": "text-brand"}}))
+	assert.Equal(t, `<p>
+  <strong>
+    red
+  </strong>
+  <span class="text-brand">
+    brand
+  </span>
+  evil
+</p>`, r.Render(doc))
+}
@@ -0,0 +1,22 @@
+package blocks
+
+import (
+	"fmt"
+)
+
+// RenderColumns renders the columns blocks of layout plugins, every child is
+// a column holding blocks. The number of columns is set as data-columns, for
+// grid based styles.
+func (r *Renderer) RenderColumns(b Block) string {
+	columns := 0
+	for _, c := range b.Children {
+		if c.Type == BlockTypeColumn {
+			columns++
+		}
+	}
+	return fmt.Sprintf(`<div%s data-columns="%d">%s</div>`, r.attrs(ElementColumns), columns, r.internalRender(b.Children))
+}
+
+func (r *Renderer) RenderColumn(b Block) string {
+	return fmt.Sprintf("<div%s>%s</div>", r.attrs(ElementColumn), r.internalRender(b.Children))
+}
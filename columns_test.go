@@ -0,0 +1,35 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderColumns(t *testing.T) {
+
+	raw := []byte(`[{"type": "columns", "children": [
+		{"type": "column", "children": [{"type": "paragraph", "children": [{"type": "text", "text": "left"}]}]},
+		{"type": "column", "children": [{"type": "heading", "level": 2, "children": [{"type": "text", "text": "right"}]}]}
+	]}]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `<div class="columns" data-columns="2">
+  <div class="column">
+    <p>
+      left
+    </p>
+  </div>
+  <div class="column">
+    <h2>
+      right
+    </h2>
+  </div>
+</div>`, Render(doc))
+
+	r := New(WithClasses(Classes{ElementColumns: "grid grid-cols-2", ElementColumn: ""}))
+	assert.Contains(t, r.Render(doc), `<div class="grid grid-cols-2" data-columns="2">
+  <div>`)
+}
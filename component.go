@@ -0,0 +1,45 @@
+package blocks
+
+import (
+	"context"
+	"io"
+)
+
+// ComponentFunc implements the templ.Component interface. Rendered blocks can
+// be embedded in templ files with @blocks.Component(doc) and are streamed
+// into the response writer.
+type ComponentFunc func(ctx context.Context, w io.Writer) error
+
+func (f ComponentFunc) Render(ctx context.Context, w io.Writer) error {
+	return f(ctx, w)
+}
+
+// Component returns a templ compatible component rendering the blocks with
+// the default renderer.
+func Component(blocks []Block) ComponentFunc {
+	return New().Component(blocks)
+}
+
+func (r *Renderer) Component(blocks []Block) ComponentFunc {
+	return func(ctx context.Context, w io.Writer) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return r.renderTo(ctx, w, blocks)
+	}
+}
+
+// RenderTo writes the rendered blocks to w. It fails without writing if the
+// document exceeds the limits of WithLimits.
+func (r *Renderer) RenderTo(w io.Writer, blocks []Block) error {
+	return r.renderTo(context.Background(), w, blocks)
+}
+
+func (r *Renderer) renderTo(ctx context.Context, w io.Writer, blocks []Block) error {
+	out, err := r.render(ctx, blocks)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
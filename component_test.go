@@ -0,0 +1,31 @@
+package blocks
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// same method set as templ.Component
+type templComponent interface {
+	Render(ctx context.Context, w io.Writer) error
+}
+
+func TestComponent(t *testing.T) {
+
+	text := "hello"
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeText, Text: &text}}}}
+
+	var c templComponent = Component(doc)
+
+	out := strings.Builder{}
+	assert.NoError(t, c.Render(context.Background(), &out))
+	assert.Equal(t, "<p>\n  hello\n</p>", out.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, c.Render(ctx, io.Discard), context.Canceled)
+}
@@ -0,0 +1,83 @@
+package blocks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stylesheet returns a minimal stylesheet for the markup of the given theme,
+// so standalone exports look reasonable without a css framework.
+func Stylesheet(theme string) string {
+	return New(WithTheme(theme)).Stylesheet()
+}
+
+// Stylesheet returns a minimal stylesheet matching the elements and classes
+// the renderer produces with its current configuration.
+func (r *Renderer) Stylesheet() string {
+	var copyButton string
+	if block := r.selector(ElementCodeBlock, ""); block != "" {
+		copyButton = block + " > button"
+	}
+	rules := []struct {
+		selectors    []string
+		declarations string
+	}{
+		{[]string{r.selector(ElementDocument, r.wrapper)}, DefaultStyles[ElementDocument]},
+		{[]string{r.selector(ElementImage, "img")}, DefaultStyles[ElementImage]},
+		{[]string{r.selector(ElementFigure, "figure"), r.selector(ElementQuoteFigure, "figure")}, DefaultStyles[ElementFigure]},
+		{[]string{r.selector(ElementFigcaption, "figcaption")}, DefaultStyles[ElementFigcaption]},
+		{[]string{r.selector(ElementList, "ul"), r.selector(ElementList, "ol")}, DefaultStyles[ElementList]},
+		{[]string{r.selector(ElementQuote, "blockquote")}, DefaultStyles[ElementQuote]},
+		{[]string{r.selector(ElementPre, "pre")}, DefaultStyles[ElementPre]},
+		{[]string{"code"}, "font-family: ui-monospace, SFMono-Regular, Menlo, monospace; font-size: 0.9em;"},
+		{[]string{r.selector(ElementCodeHighlight, "")}, DefaultStyles[ElementCodeHighlight]},
+		{[]string{r.selector(ElementCodeLineNumber, "")}, DefaultStyles[ElementCodeLineNumber]},
+		{[]string{r.selector(ElementCodeBlock, "")}, DefaultStyles[ElementCodeBlock]},
+		{[]string{copyButton}, "position: absolute; top: 0.5em; right: 0.5em;"},
+		{[]string{r.selector(ElementMention, "")}, DefaultStyles[ElementMention]},
+		{[]string{".math.display"}, "overflow-x: auto;"},
+	}
+
+	out := strings.Builder{}
+	for _, rule := range rules {
+		selectors := []string{}
+		for _, s := range rule.selectors {
+			// elements without tag and class can not be selected
+			if s != "" {
+				selectors = append(selectors, s)
+			}
+		}
+		if len(selectors) > 0 {
+			fmt.Fprintf(&out, "%s { %s }\n", strings.Join(selectors, ", "), rule.declarations)
+		}
+	}
+	return out.String()
+}
+
+// selector selects the element by its tag and classes.
+func (r *Renderer) selector(el Element, tag string) string {
+	classes := strings.Fields(r.classes[el])
+	if tag == "" && len(classes) == 0 {
+		return ""
+	}
+	out := strings.Builder{}
+	out.WriteString(tag)
+	for _, class := range classes {
+		out.WriteString(".")
+		out.WriteString(cssEscape(class))
+	}
+	return out.String()
+}
+
+// cssEscape escapes the characters of a class that have a meaning in css
+// selectors, like the colon of tailwind variants.
+func cssEscape(s string) string {
+	out := strings.Builder{}
+	for _, c := range s {
+		if !(c == '-' || c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c > 0x7f) {
+			out.WriteRune('\\')
+		}
+		out.WriteRune(c)
+	}
+	return out.String()
+}
@@ -0,0 +1,30 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStylesheet(t *testing.T) {
+
+	css := Stylesheet(ThemePlain)
+	assert.Contains(t, css, "ul, ol { margin: 0 0 1em; padding-left: 1.5em; }\n")
+	assert.Contains(t, css, "blockquote { ")
+	assert.Contains(t, css, ".code-block > button { ")
+	assert.Contains(t, css, ".line-number { ")
+	assert.NotContains(t, css, "max-width: 65ch")
+
+	css = Stylesheet(ThemeBootstrap5)
+	assert.Contains(t, css, "blockquote.blockquote { ")
+	assert.Contains(t, css, "pre.bg-light.p-3.rounded { ")
+	assert.Contains(t, css, "figure.figure, figure { ")
+
+	css = Stylesheet(ThemeTailwindProse)
+	assert.Contains(t, css, "article.prose { max-width: 65ch; line-height: 1.6; }\n")
+
+	r := New(WithClasses(Classes{ElementImage: "md:w-1/2", ElementCodeBlock: ""}))
+	css = r.Stylesheet()
+	assert.Contains(t, css, `img.md\:w-1\/2 { `)
+	assert.NotContains(t, css, "button")
+}
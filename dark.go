@@ -0,0 +1,47 @@
+package blocks
+
+import (
+	"path"
+	"strings"
+)
+
+// WithDarkImages renders images with a dark mode variant as <picture> with a
+// prefers-color-scheme: dark source, for diagrams with light and dark
+// versions. The variant function returns the url of the dark variant, or an
+// empty string for images without, a nil function uses DarkImageField.
+func WithDarkImages(variant func(img Image) string) Option {
+	if variant == nil {
+		variant = DarkImageField
+	}
+	return func(r *Renderer) {
+		r.darkImage = variant
+	}
+}
+
+// DarkImageField uses the dark media field of the image, an additional media
+// field populated alongside the image.
+func DarkImageField(img Image) string {
+	if img.Dark == nil {
+		return ""
+	}
+	return img.Dark.URL
+}
+
+// DarkImageSuffix expects the dark variants next to the images with the
+// suffix before the extension, like diagram-dark.png for diagram.png with
+// the suffix "-dark". Urls already ending in the suffix are variants
+// themselves and get none.
+func DarkImageSuffix(suffix string) func(img Image) string {
+	return func(img Image) string {
+		base, query, _ := strings.Cut(img.URL, "?")
+		ext := path.Ext(base)
+		if strings.HasSuffix(strings.TrimSuffix(base, ext), suffix) {
+			return ""
+		}
+		dark := strings.TrimSuffix(base, ext) + suffix + ext
+		if query != "" {
+			dark += "?" + query
+		}
+		return dark
+	}
+}
@@ -0,0 +1,37 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDarkImages(t *testing.T) {
+
+	raw := []byte(`[{"type": "image", "children": [], "image": {"url": "/diagram.svg", "alternativeText": "diagram", "dark": {"url": "/diagram-night.svg"}}}]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `<picture>
+  <source media="(prefers-color-scheme: dark)" srcset="/diagram-night.svg" />
+  <img src="/diagram.svg" alt="diagram" />
+</picture>`, New(WithDarkImages(nil)).Render(doc))
+}
+
+func TestDarkImageSuffix(t *testing.T) {
+
+	variant := DarkImageSuffix("-dark")
+	assert.Equal(t, "/uploads/chart-dark.png?v=2", variant(Image{URL: "/uploads/chart.png?v=2"}))
+	assert.Equal(t, "", variant(Image{URL: "/uploads/chart-dark.png"}))
+
+	img := Image{URL: "/chart.png", AlternativeText: "chart", Formats: map[string]ImageFormat{
+		"webp": {URL: "/chart.webp", Mime: "image/webp", Width: 500},
+	}}
+	r := New(WithDarkImages(variant), WithPicture(PictureOptions{Types: []string{"image/webp"}}))
+	assert.Equal(t, `<picture>
+  <source media="(prefers-color-scheme: dark)" srcset="/chart-dark.png" />
+  <source type="image/webp" srcset="/chart.webp 500w" />
+  <img src="/chart.png" alt="chart" />
+</picture>`, r.Render([]Block{{Type: BlockTypeImage, Image: &img}}))
+}
@@ -0,0 +1,19 @@
+package blocks
+
+import (
+	"fmt"
+)
+
+// RenderDefinitionList renders definition lists of editor extensions, their
+// children are definition-term and definition-description blocks.
+func (r *Renderer) RenderDefinitionList(b Block) string {
+	return fmt.Sprintf("<dl%s>%s</dl>", r.attrs(ElementDefinitionList), r.internalRender(b.Children))
+}
+
+func (r *Renderer) RenderDefinitionTerm(b Block) string {
+	return fmt.Sprintf("<dt%s>%s</dt>", r.attrs(ElementDefinitionTerm), r.internalRender(b.Children))
+}
+
+func (r *Renderer) RenderDefinitionDescription(b Block) string {
+	return fmt.Sprintf("<dd%s>%s</dd>", r.attrs(ElementDefinitionDescription), r.internalRender(b.Children))
+}
@@ -0,0 +1,48 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDefinitionList(t *testing.T) {
+
+	raw := []byte(`[{"type": "definition-list", "children": [
+		{"type": "definition-term", "children": [{"type": "text", "text": "Block"}]},
+		{"type": "definition-description", "children": [{"type": "text", "text": "A node of the editor"}]}
+	]}]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	r := New(WithClasses(Classes{ElementDefinitionTerm: "term"}))
+	assert.Equal(t, `<dl>
+  <dt class="term">
+    Block
+  </dt>
+  <dd>
+    A node of the editor
+  </dd>
+</dl>`, r.Render(doc))
+
+	r.DefinitionTermRenderer = termFunc(func(b Block) string {
+		return "<dt><strong>" + r.RenderChildren(b) + "</strong></dt>"
+	})
+	assert.Equal(t, `<dl>
+  <dt>
+    <strong>
+      Block
+    </strong>
+  </dt>
+  <dd>
+    A node of the editor
+  </dd>
+</dl>`, r.Render(doc))
+}
+
+type termFunc func(b Block) string
+
+func (f termFunc) RenderDefinitionTerm(b Block) string {
+	return f(b)
+}
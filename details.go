@@ -0,0 +1,17 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+)
+
+// RenderDetails renders collapsible blocks of editor plugins, the summary is
+// the always visible text and the children are shown when expanded.
+func (r *Renderer) RenderDetails(b Block) string {
+	open := ""
+	if deref(b.Open) || r.print {
+		open = " open"
+	}
+	return fmt.Sprintf("<details%s%s><summary%s>%s</summary>%s</details>",
+		r.attrs(ElementDetails), open, r.attrs(ElementSummary), r.interpolate(html.EscapeString(deref(b.Summary))), r.internalRender(b.Children))
+}
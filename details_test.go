@@ -0,0 +1,28 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDetails(t *testing.T) {
+
+	raw := []byte(`[{"type": "details", "summary": "Is it <free>?", "open": true, "children": [
+		{"type": "paragraph", "children": [{"type": "text", "text": "Yes."}]}
+	]}]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	r := New(WithClasses(Classes{ElementDetails: "faq"}))
+	assert.Equal(t, `<details class="faq" open>
+  <summary>
+    Is it &lt;free&gt;?
+  </summary>
+  <p>
+    Yes.
+  </p>
+</details>`, r.Render(doc))
+	assert.Equal(t, "Is it <free>?\nYes.", PlainText(doc))
+}
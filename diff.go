@@ -0,0 +1,141 @@
+package blocks
+
+import (
+	"reflect"
+)
+
+type ChangeType string
+
+const ChangeAdded ChangeType = "added"
+const ChangeRemoved ChangeType = "removed"
+const ChangeModified ChangeType = "modified"
+const ChangeText ChangeType = "text"
+
+// Change describes a single difference between two block trees. Path points
+// into the new tree, except for removed blocks where it points into the old
+// tree. Old is nil for added blocks, New is nil for removed blocks.
+type Change struct {
+	Type ChangeType
+	Path []int
+	Old  *Block
+	New  *Block
+}
+
+// Diff compares two revisions of a document. Blocks of the same type at the
+// same position are compared attribute by attribute and their children are
+// diffed recursively, other blocks are reported as added or removed.
+func Diff(before, after []Block) []Change {
+	return diffLevel(before, after, nil, nil)
+}
+
+func diffLevel(before, after []Block, oldParent, newParent []int) []Change {
+	changes := []Change{}
+	for _, p := range align(before, after) {
+		switch {
+		case p.old == nil:
+			changes = append(changes, Change{Type: ChangeAdded, Path: childPath(newParent, p.newIndex), New: p.new})
+		case p.new == nil:
+			changes = append(changes, Change{Type: ChangeRemoved, Path: childPath(oldParent, p.oldIndex), Old: p.old})
+		case !p.equal:
+			changes = append(changes, diffBlock(*p.old, *p.new, childPath(oldParent, p.oldIndex), childPath(newParent, p.newIndex))...)
+		}
+	}
+	return changes
+}
+
+func diffBlock(o, n Block, oldPath, newPath []int) []Change {
+	changes := []Change{}
+	if !equalAttributes(o, n) {
+		changes = append(changes, Change{Type: ChangeModified, Path: newPath, Old: &o, New: &n})
+	}
+	if o.Type == BlockTypeText && !reflect.DeepEqual(o.Text, n.Text) {
+		changes = append(changes, Change{Type: ChangeText, Path: newPath, Old: &o, New: &n})
+	}
+	return append(changes, diffLevel(o.Children, n.Children, oldPath, newPath)...)
+}
+
+func equalAttributes(a, b Block) bool {
+	a.Children, b.Children = nil, nil
+	a.Text, b.Text = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+func childPath(parent []int, i int) []int {
+	return append(append([]int{}, parent...), i)
+}
+
+// pair is one step of an alignment between two lists of blocks. Either both
+// blocks are set, then they are equal or of the same type, or only one side
+// is set for removed and added blocks.
+type pair struct {
+	old, new           *Block
+	oldIndex, newIndex int
+	equal              bool
+}
+
+// align matches equal blocks by their longest common subsequence. Unmatched
+// blocks between two matches are paired by position if their types match.
+func align(before, after []Block) []pair {
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if reflect.DeepEqual(before[i], after[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	pairs := []pair{}
+	var removed, added []int
+	flush := func() {
+		n := min(len(removed), len(added))
+		for k := 0; k < n; k++ {
+			o, a := removed[k], added[k]
+			if before[o].Type == after[a].Type {
+				pairs = append(pairs, pair{old: &before[o], new: &after[a], oldIndex: o, newIndex: a})
+				continue
+			}
+			pairs = append(pairs,
+				pair{old: &before[o], oldIndex: o},
+				pair{new: &after[a], newIndex: a},
+			)
+		}
+		for _, o := range removed[n:] {
+			pairs = append(pairs, pair{old: &before[o], oldIndex: o})
+		}
+		for _, a := range added[n:] {
+			pairs = append(pairs, pair{new: &after[a], newIndex: a})
+		}
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case reflect.DeepEqual(before[i], after[j]):
+			flush()
+			pairs = append(pairs, pair{old: &before[i], new: &after[j], oldIndex: i, newIndex: j, equal: true})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			removed = append(removed, i)
+			i++
+		default:
+			added = append(added, j)
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		removed = append(removed, i)
+	}
+	for ; j < len(after); j++ {
+		added = append(added, j)
+	}
+	flush()
+	return pairs
+}
@@ -0,0 +1,111 @@
+package blocks
+
+import (
+	"regexp"
+)
+
+// internal block types wrapping the changed parts of a merged diff tree
+const blockTypeInserted BlockType = "diff-inserted"
+const blockTypeDeleted BlockType = "diff-deleted"
+
+var diffTokens = regexp.MustCompile(`\s+|[^\s]+`)
+
+// RenderDiff renders the new version of a document and marks the changes
+// compared to the old version with <ins> and <del>. Changed text is diffed
+// word by word.
+func (r *Renderer) RenderDiff(before, after []Block) string {
+	return r.Render(mergeDiff(before, after))
+}
+
+func RenderDiff(before, after []Block) string {
+	return New().RenderDiff(before, after)
+}
+
+func mergeDiff(before, after []Block) []Block {
+	merged := []Block{}
+	for _, p := range align(before, after) {
+		switch {
+		case p.old == nil:
+			merged = append(merged, Block{Type: blockTypeInserted, Children: []Block{*p.new}})
+		case p.new == nil:
+			merged = append(merged, Block{Type: blockTypeDeleted, Children: []Block{*p.old}})
+		case p.equal:
+			merged = append(merged, *p.new)
+		case p.new.Type == BlockTypeText:
+			merged = append(merged, mergeText(*p.old, *p.new)...)
+		default:
+			b := *p.new
+			b.Children = mergeDiff(p.old.Children, p.new.Children)
+			merged = append(merged, b)
+		}
+	}
+	return merged
+}
+
+// mergeText splits two versions of a text node into words and returns text
+// nodes for the unchanged words, wrapped ones for the deleted and inserted.
+func mergeText(o, n Block) []Block {
+	if (o.Text == nil) != (n.Text == nil) || !equalAttributes(o, n) {
+		return []Block{
+			{Type: blockTypeDeleted, Children: []Block{o}},
+			{Type: blockTypeInserted, Children: []Block{n}},
+		}
+	}
+	if o.Text == nil {
+		return []Block{n}
+	}
+
+	oldWords := diffTokens.FindAllString(*o.Text, -1)
+	newWords := diffTokens.FindAllString(*n.Text, -1)
+
+	lcs := make([][]int, len(oldWords)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newWords)+1)
+	}
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if oldWords[i] == newWords[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	out := []Block{}
+	emit := func(typ BlockType, word string) {
+		last := len(out) - 1
+		if last >= 0 && out[last].Type == typ {
+			if typ == BlockTypeText {
+				*out[last].Text += word
+			} else {
+				*out[last].Children[0].Text += word
+			}
+			return
+		}
+		text := n
+		text.Text = &word
+		if typ == BlockTypeText {
+			out = append(out, text)
+			return
+		}
+		out = append(out, Block{Type: typ, Children: []Block{text}})
+	}
+
+	i, j := 0, 0
+	for i < len(oldWords) || j < len(newWords) {
+		switch {
+		case i < len(oldWords) && j < len(newWords) && oldWords[i] == newWords[j]:
+			emit(BlockTypeText, newWords[j])
+			i++
+			j++
+		case j == len(newWords) || (i < len(oldWords) && lcs[i+1][j] >= lcs[i][j+1]):
+			emit(blockTypeDeleted, oldWords[i])
+			i++
+		default:
+			emit(blockTypeInserted, newWords[j])
+			j++
+		}
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDiff(t *testing.T) {
+
+	before := []Block{
+		paragraph("the quick brown fox"),
+		paragraph("removed"),
+	}
+	after := []Block{
+		paragraph("the slow brown fox jumps"),
+		{Type: BlockTypeHeading, Level: ptr(2), Children: []Block{{Type: BlockTypeText, Text: ptr("added")}}},
+	}
+
+	out := RenderDiff(before, after)
+
+	assert.Equal(t, `<p>
+  the
+  <del>
+    quick
+  </del>
+  <ins>
+    slow
+  </ins>
+  brown fox
+  <ins>
+    jumps
+  </ins>
+</p>
+<del>
+  <p>
+    removed
+  </p>
+</del>
+<ins>
+  <h2>
+    added
+  </h2>
+</ins>`, out)
+}
@@ -0,0 +1,63 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func paragraph(texts ...string) Block {
+	b := Block{Type: BlockTypeParagraph}
+	for _, t := range texts {
+		b.Children = append(b.Children, Block{Type: BlockTypeText, Text: ptr(t)})
+	}
+	return b
+}
+
+func TestDiff(t *testing.T) {
+
+	before := []Block{
+		{Type: BlockTypeHeading, Level: ptr(1), Children: []Block{{Type: BlockTypeText, Text: ptr("title")}}},
+		paragraph("first"),
+		paragraph("second"),
+		paragraph("third"),
+	}
+	after := []Block{
+		{Type: BlockTypeHeading, Level: ptr(2), Children: []Block{{Type: BlockTypeText, Text: ptr("title")}}},
+		paragraph("first"),
+		paragraph("second changed"),
+		{Type: BlockTypeQuote},
+	}
+
+	changes := Diff(before, after)
+
+	assert.Len(t, changes, 4)
+	assert.Equal(t, ChangeModified, changes[0].Type)
+	assert.Equal(t, []int{0}, changes[0].Path)
+
+	assert.Equal(t, ChangeText, changes[1].Type)
+	assert.Equal(t, []int{2, 0}, changes[1].Path)
+	assert.Equal(t, "second", *changes[1].Old.Text)
+	assert.Equal(t, "second changed", *changes[1].New.Text)
+
+	assert.Equal(t, ChangeRemoved, changes[2].Type)
+	assert.Equal(t, []int{3}, changes[2].Path)
+	assert.Equal(t, ChangeAdded, changes[3].Type)
+	assert.Equal(t, []int{3}, changes[3].Path)
+
+	assert.Empty(t, Diff(after, after))
+}
+
+func TestDiff_Insert(t *testing.T) {
+
+	before := []Block{paragraph("a"), paragraph("c")}
+	after := []Block{paragraph("a"), paragraph("b"), paragraph("c")}
+
+	changes := Diff(before, after)
+
+	assert.Equal(t, []Change{{Type: ChangeAdded, Path: []int{1}, New: &after[1]}}, changes)
+}
@@ -0,0 +1,205 @@
+package blocks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EmailWidth is the line width of the plaintext part of emails.
+const EmailWidth = 78
+
+// Email holds the two parts of a multipart/alternative message.
+type Email struct {
+	HTML string
+	Text string
+}
+
+// RenderEmail renders the html with inline styles and the plaintext
+// alternative of the blocks.
+func RenderEmail(blocks []Block) Email {
+	return New(WithInlineStyles(nil)).RenderEmail(blocks)
+}
+
+// RenderEmail renders the html with the renderer and the plaintext alternative
+// like EmailText. Both parts go through the transformers, variables and link
+// resolver of the renderer, the warnings are those of the html part.
+// Annotations are only part of the text with AnnotationsVisible.
+func (r *Renderer) RenderEmail(blocks []Block) Email {
+	out := r.Render(blocks)
+	warnings, logger := r.warnings, r.logger
+	r.logger = nil
+	e := emailText{
+		text: func(s string) string { return r.substitute(s, func(v string) string { return v }) },
+		url:  r.linkURL,
+		// comments are not shown by mail clients either
+		annotations: r.annotations == AnnotationsVisible,
+	}
+	text := e.render(r.transform(blocks))
+	r.warnings, r.logger = warnings, logger
+	return Email{HTML: out, Text: text}
+}
+
+// EmailText renders the blocks as plaintext wrapped at EmailWidth columns.
+// Links are numbered references like "text [1]", the urls are listed at the
+// end of the text. Code blocks are indented and not wrapped, annotations are
+// left out.
+func EmailText(blocks []Block) string {
+	e := emailText{
+		text: func(s string) string { return s },
+		url:  func(link, _ string) string { return link },
+	}
+	return e.render(blocks)
+}
+
+func (e *emailText) render(blocks []Block) string {
+	e.refs = map[string]int{}
+	parts := []string{}
+	for _, b := range blocks {
+		if b.Type == BlockTypeAnnotation && !e.annotations {
+			continue
+		}
+		if text := e.block(b); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if len(e.urls) > 0 {
+		lines := make([]string, 0, len(e.urls))
+		for i, url := range e.urls {
+			lines = append(lines, fmt.Sprintf("[%d] %s", i+1, url))
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+type emailText struct {
+	urls []string
+	refs map[string]int
+	// text and url prepare texts and link urls
+	text func(string) string
+	url  func(link, text string) string
+	// annotations keeps the annotation blocks
+	annotations bool
+}
+
+func (e *emailText) block(b Block) string {
+	switch b.Type {
+	case BlockTypeHeading:
+		text := strings.TrimSpace(e.inline(b.Children))
+		underline := "-"
+		if b.Level != nil && *b.Level == 1 {
+			underline = "="
+		}
+		return wrapText(text, EmailWidth, "", "") + "\n" + strings.Repeat(underline, min(len([]rune(text)), EmailWidth))
+	case BlockTypeList:
+		return e.list(b, "")
+	case BlockTypeQuote:
+		return wrapText(e.inline(b.Children), EmailWidth, "> ", "> ")
+	case BlockTypeCode:
+		lines := strings.Split(b.PlainText(), "\n")
+		for i, l := range lines {
+			lines[i] = strings.TrimRight("    "+l, " ")
+		}
+		return strings.Join(lines, "\n")
+	case BlockTypeImage:
+		return e.image(b)
+	}
+	return wrapText(e.inline(b.Children), EmailWidth, "", "")
+}
+
+func (e *emailText) list(b Block, indent string) string {
+	lines := []string{}
+	n := 0
+	for _, c := range b.Children {
+		if c.Type == BlockTypeList {
+			lines = append(lines, e.list(c, indent+"   "))
+			continue
+		}
+		n++
+		marker := "-  "
+		if b.Format != nil && *b.Format == string(ListFormatOrdered) {
+			marker = fmt.Sprintf("%d. ", n)
+		}
+		lines = append(lines, wrapText(e.inline(c.Children), EmailWidth, indent+marker, indent+strings.Repeat(" ", len(marker))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *emailText) inline(children []Block) string {
+	out := strings.Builder{}
+	for _, c := range children {
+		switch c.Type {
+		case BlockTypeAnnotation:
+			if e.annotations {
+				out.WriteString(e.inline(c.Children))
+			}
+		case BlockTypeText:
+			out.WriteString(e.text(deref(c.Text)))
+		case BlockTypeMention:
+			out.WriteString(c.PlainText())
+		case BlockTypeLink:
+			text := strings.TrimSpace(e.inline(c.Children))
+			url := deref(c.URL)
+			if url != "" {
+				url = e.url(url, text)
+			}
+			if url == "" || url == text {
+				out.WriteString(text)
+				continue
+			}
+			fmt.Fprintf(&out, "%s [%d]", text, e.ref(url))
+		case BlockTypeImage:
+			out.WriteString(e.image(c))
+		default:
+			out.WriteString(e.inline(c.Children))
+		}
+	}
+	return out.String()
+}
+
+func (e *emailText) image(b Block) string {
+	if b.Image == nil {
+		return ""
+	}
+	return fmt.Sprintf("[image: %s] [%d]", b.Image.AlternativeText, e.ref(b.Image.URL))
+}
+
+// ref returns the reference number of the url, urls are numbered once
+func (e *emailText) ref(url string) int {
+	if n, ok := e.refs[url]; ok {
+		return n
+	}
+	e.urls = append(e.urls, url)
+	e.refs[url] = len(e.urls)
+	return len(e.urls)
+}
+
+// wrapText wraps the words of the text at width columns. The first line
+// starts with first, following lines with prefix. Words longer than a line
+// are not broken.
+func wrapText(text string, width int, first, prefix string) string {
+	lines := []string{}
+	for _, para := range strings.Split(text, "\n") {
+		line := first
+		if len(lines) > 0 {
+			line = prefix
+		}
+		start := len(line)
+		for _, word := range strings.Fields(para) {
+			if len(line) > start && len([]rune(line))+1+len([]rune(word)) > width {
+				lines = append(lines, line)
+				line = prefix
+				start = len(line)
+			}
+			if len(line) > start {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	return strings.Join(lines, "\n")
+}
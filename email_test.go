@@ -0,0 +1,100 @@
+package blocks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailText(t *testing.T) {
+
+	long := strings.Repeat("lorem ipsum ", 10)
+	doc := []Block{
+		heading(1, "Newsletter"),
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr(long + "see the ")},
+			link("https://example.com/docs", "docs"),
+			{Type: BlockTypeText, Text: ptr(" and the ")},
+			link("https://example.com/docs", "documentation"),
+		}},
+		{Type: BlockTypeList, Format: ptr("ordered"), Children: []Block{
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeText, Text: ptr(long)}}},
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeText, Text: ptr("two")}}},
+		}},
+		{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quoted")}}},
+		codeBlock("go", "if ok {\n\treturn\n}"),
+		{Type: BlockTypeImage, Image: &Image{URL: "https://example.com/a.jpg", AlternativeText: "a cat"}},
+	}
+
+	assert.Equal(t, `Newsletter
+==========
+
+lorem ipsum lorem ipsum lorem ipsum lorem ipsum lorem ipsum lorem ipsum lorem
+ipsum lorem ipsum lorem ipsum lorem ipsum see the docs [1] and the
+documentation [1]
+
+1. lorem ipsum lorem ipsum lorem ipsum lorem ipsum lorem ipsum lorem ipsum
+   lorem ipsum lorem ipsum lorem ipsum lorem ipsum
+2. two
+
+> quoted
+
+    if ok {
+    	return
+    }
+
+[image: a cat] [2]
+
+[1] https://example.com/docs
+[2] https://example.com/a.jpg
+`, EmailText(doc))
+}
+
+func TestRenderEmail(t *testing.T) {
+	email := RenderEmail([]Block{paragraph("hello")})
+	assert.Equal(t, "hello\n", email.Text)
+	assert.Contains(t, email.HTML, `<p style="margin: 0 0 1em;">`)
+}
+
+// annotated is the annotation probe of the email and markdown tests
+var annotated = []Block{
+	{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("Public")},
+		{Type: BlockTypeAnnotation, Children: []Block{{Type: BlockTypeText, Text: ptr("SECRET draft note")}}},
+	}},
+	{Type: BlockTypeAnnotation, Children: []Block{{Type: BlockTypeText, Text: ptr("TOPSECRET")}}},
+}
+
+func TestRenderEmailAnnotations(t *testing.T) {
+	assert.Equal(t, "Public\n", EmailText(annotated))
+
+	email := New().RenderEmail(annotated)
+	assert.Equal(t, "Public\n", email.Text)
+	assert.NotContains(t, email.HTML, "SECRET")
+
+	email = New(WithAnnotations(AnnotationsVisible)).RenderEmail(annotated)
+	assert.Equal(t, "PublicSECRET draft note\n\nTOPSECRET\n", email.Text)
+}
+
+func TestRenderEmailTransformed(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("Hi {{name}}, what the heck. Read the ")},
+		link("strapi://article/42", "article"),
+	}}}
+	resolver := LinkResolverFunc(func(ref EntityRef) (string, bool) {
+		return "https://example.com/articles/" + ref.ID, true
+	})
+	r := New(
+		WithTransformers(FilterTexts(NewWordMask("heck"))),
+		WithVariables(map[string]string{"name": "Ann & Bo"}, MissingVariableKeep),
+		WithLinkResolver(resolver, "#"),
+	)
+	email := r.RenderEmail(doc)
+	assert.Contains(t, email.HTML, "what the ****")
+	assert.Contains(t, email.HTML, "Ann &amp; Bo")
+	assert.NotContains(t, email.Text, "heck")
+	assert.Equal(t, "Hi Ann & Bo, what the ****. Read the article [1]\n\n[1] https://example.com/articles/42\n", email.Text)
+	assert.Empty(t, r.Warnings())
+}
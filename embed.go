@@ -0,0 +1,120 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+)
+
+const DefaultEmbedSandbox = "allow-scripts allow-same-origin allow-presentation allow-popups"
+const DefaultEmbedReferrerPolicy = "strict-origin-when-cross-origin"
+const DefaultEmbedAspectRatio = "16 / 9"
+
+// EmbedOptions configures the iframes of html blocks.
+type EmbedOptions struct {
+	// Hosts are the hosts iframes may load, like www.youtube-nocookie.com.
+	// Iframes of other hosts or without https are removed.
+	Hosts []string
+	// Sandbox replaces the sandbox attribute of the iframes, defaults to
+	// DefaultEmbedSandbox.
+	Sandbox string
+	// ReferrerPolicy replaces the referrerpolicy attribute of the iframes,
+	// defaults to DefaultEmbedReferrerPolicy.
+	ReferrerPolicy string
+	// AspectRatio is the css aspect ratio of iframes without width and
+	// height, defaults to DefaultEmbedAspectRatio.
+	AspectRatio string
+}
+
+// WithEmbeds renders the iframes of html blocks with the embed options, each
+// iframe is wrapped in a responsive container keeping its aspect ratio. The
+// container class is set with ElementEmbed. The iframes are taken out before
+// the html is sanitized, see WithRawHTML.
+func WithEmbeds(opts EmbedOptions) Option {
+	if opts.Sandbox == "" {
+		opts.Sandbox = DefaultEmbedSandbox
+	}
+	if opts.ReferrerPolicy == "" {
+		opts.ReferrerPolicy = DefaultEmbedReferrerPolicy
+	}
+	if opts.AspectRatio == "" {
+		opts.AspectRatio = DefaultEmbedAspectRatio
+	}
+	return func(r *Renderer) {
+		r.embeds = &opts
+	}
+}
+
+// embedIframes renders the iframes of the fragment and passes the html in
+// between to clean.
+func (r *Renderer) embedIframes(fragment string, clean func(string) string) string {
+	out := strings.Builder{}
+	rest := strings.Builder{}
+	z := nethtml.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		if tt == nethtml.ErrorToken {
+			break
+		}
+		raw := string(z.Raw())
+		t := z.Token()
+		if t.Data != "iframe" || (tt != nethtml.StartTagToken && tt != nethtml.SelfClosingTagToken) {
+			rest.WriteString(raw)
+			continue
+		}
+		out.WriteString(clean(rest.String()))
+		rest.Reset()
+		if tt == nethtml.StartTagToken {
+			// the content of iframes is not rendered by browsers
+			for tt = z.Next(); tt != nethtml.ErrorToken; tt = z.Next() {
+				if tt == nethtml.EndTagToken && z.Token().Data == "iframe" {
+					break
+				}
+			}
+		}
+		out.WriteString(r.embed(t))
+	}
+	out.WriteString(clean(rest.String()))
+	return out.String()
+}
+
+func (r *Renderer) embed(t nethtml.Token) string {
+	if r.print {
+		return ""
+	}
+	attrs := map[string]string{}
+	for _, a := range t.Attr {
+		attrs[a.Key] = a.Val
+	}
+	src := attrs["src"]
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme != "https" || !slices.ContainsFunc(r.embeds.Hosts, func(h string) bool {
+		return strings.EqualFold(u.Hostname(), h)
+	}) {
+		r.warn(WarningBlockedEmbed, "iframe of %q is not allowed", src)
+		return ""
+	}
+
+	ratio := r.embeds.AspectRatio
+	width, werr := strconv.Atoi(attrs["width"])
+	height, herr := strconv.Atoi(attrs["height"])
+	if werr == nil && herr == nil && width > 0 && height > 0 {
+		ratio = fmt.Sprintf("%d / %d", width, height)
+	}
+
+	frame := strings.Builder{}
+	fmt.Fprintf(&frame, `<iframe src="%s"`, html.EscapeString(src))
+	for _, key := range []string{"title", "allow", "allowfullscreen", "loading"} {
+		if val, ok := attrs[key]; ok {
+			fmt.Fprintf(&frame, ` %s="%s"`, key, html.EscapeString(val))
+		}
+	}
+	fmt.Fprintf(&frame, ` sandbox="%s" referrerpolicy="%s"%s></iframe>`,
+		html.EscapeString(r.embeds.Sandbox), html.EscapeString(r.embeds.ReferrerPolicy), r.attrs(ElementEmbedFrame))
+	return fmt.Sprintf("<div%s>%s</div>", r.attrsStyle("aspect-ratio: "+ratio+";", ElementEmbed), frame.String())
+}
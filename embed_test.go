@@ -0,0 +1,47 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbeds(t *testing.T) {
+
+	src := `<p>Watch <b>this</b></p><iframe src="https://www.youtube-nocookie.com/embed/abc" width="640" height="480" sandbox="" onload="x()" allowfullscreen>fallback</iframe>` +
+		`<iframe src="https://evil.example.com/"></iframe><iframe src="https://www.youtube-nocookie.com/embed/def"></iframe>`
+	doc := []Block{{Type: BlockTypeHTML, HTML: &src}}
+
+	r := New(WithRawHTML(DefaultAllowlist), WithEmbeds(EmbedOptions{Hosts: []string{"www.youtube-nocookie.com"}}))
+	out, warnings := r.RenderWithWarnings(doc)
+	assert.Equal(t, `<p>
+  Watch
+  <b>
+    this
+  </b>
+</p>
+<div class="embed" style="aspect-ratio: 640 / 480;">
+  <iframe src="https://www.youtube-nocookie.com/embed/abc" allowfullscreen="" sandbox="allow-scripts allow-same-origin allow-presentation allow-popups" referrerpolicy="strict-origin-when-cross-origin" class="embed-frame"></iframe>
+</div>
+<div class="embed" style="aspect-ratio: 16 / 9;">
+  <iframe src="https://www.youtube-nocookie.com/embed/def" sandbox="allow-scripts allow-same-origin allow-presentation allow-popups" referrerpolicy="strict-origin-when-cross-origin" class="embed-frame"></iframe>
+</div>`, out)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, WarningBlockedEmbed, warnings[0].Code)
+}
+
+func TestEmbedsOptions(t *testing.T) {
+
+	src := `<iframe src="https://player.vimeo.com/video/1" title="Demo"></iframe>`
+	doc := []Block{{Type: BlockTypeHTML, HTML: &src}}
+
+	r := New(WithRawHTML(nil), WithEmbeds(EmbedOptions{
+		Hosts:          []string{"player.vimeo.com"},
+		Sandbox:        "allow-scripts",
+		ReferrerPolicy: "no-referrer",
+		AspectRatio:    "4 / 3",
+	}))
+	assert.Equal(t, `<div class="embed" style="aspect-ratio: 4 / 3;">
+  <iframe src="https://player.vimeo.com/video/1" title="Demo" sandbox="allow-scripts" referrerpolicy="no-referrer" class="embed-frame"></iframe>
+</div>`, r.Render(doc))
+}
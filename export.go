@@ -0,0 +1,97 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const DefaultWordsPerMinute = 200
+
+// FrontMatter holds the metadata static site generators like Hugo or Jekyll
+// read from the top of a markdown file.
+type FrontMatter struct {
+	Title       string
+	Images      []string
+	ReadingTime int
+}
+
+// NewFrontMatter derives the front matter from a document. The title is taken
+// from the first heading, the reading time is given in minutes.
+func NewFrontMatter(blocks []Block, wordsPerMinute int) FrontMatter {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	fm := FrontMatter{
+		ReadingTime: max(1, (Words(blocks)+wordsPerMinute-1)/wordsPerMinute),
+	}
+	Walk(blocks, func(_ []int, b Block) bool {
+		if b.Type == BlockTypeHeading && fm.Title == "" {
+			fm.Title = strings.TrimSpace(b.PlainText())
+		}
+		if b.Type == BlockTypeImage && b.Image != nil {
+			fm.Images = append(fm.Images, b.Image.URL)
+		}
+		return true
+	})
+	return fm
+}
+
+// YAML returns the front matter including the surrounding --- delimiters.
+func (fm FrontMatter) YAML() string {
+	out := strings.Builder{}
+	out.WriteString("---\n")
+	fmt.Fprintf(&out, "title: %s\n", strconv.Quote(fm.Title))
+	if len(fm.Images) == 0 {
+		out.WriteString("images: []\n")
+	} else {
+		out.WriteString("images:\n")
+		for _, img := range fm.Images {
+			fmt.Fprintf(&out, "  - %s\n", strconv.Quote(img))
+		}
+	}
+	fmt.Fprintf(&out, "readingTime: %d\n", fm.ReadingTime)
+	out.WriteString("---\n")
+	return out.String()
+}
+
+// MarkdownExporter writes documents as markdown files with YAML front matter.
+type MarkdownExporter struct {
+	// Dir is the target directory, it is created if it does not exist.
+	Dir            string
+	WordsPerMinute int
+}
+
+// Write writes a single document with its front matter to w.
+func (e MarkdownExporter) Write(w io.Writer, blocks []Block) error {
+	fm := NewFrontMatter(blocks, e.WordsPerMinute)
+	if _, err := io.WriteString(w, fm.YAML()+"\n"); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, Markdown(blocks))
+	return err
+}
+
+// Export writes the document to <Dir>/<name>.md and returns the file path.
+// Names must be local file names without path separators.
+func (e MarkdownExporter) Export(name string, blocks []Block) (string, error) {
+	if name == "" || name == ".." || strings.ContainsAny(name, `/\`) || !filepath.IsLocal(name+".md") {
+		return "", fmt.Errorf("blocks: invalid export name %q", name)
+	}
+	if err := os.MkdirAll(e.Dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(e.Dir, name+".md")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := e.Write(f, blocks); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
@@ -0,0 +1,91 @@
+package blocks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownExporter_Export(t *testing.T) {
+
+	var blocks []Block
+	json.Unmarshal(testInput, &blocks)
+
+	exporter := MarkdownExporter{Dir: t.TempDir()}
+	path, err := exporter.Export("post", blocks)
+	assert.NoError(t, err)
+
+	out, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(out), `---
+title: "now titles: header 1"
+images:
+  - "http://localhost:1337/uploads/cdreier_gopher_small_a32e6e2b51.jpg"
+readingTime: 1
+---
+`)
+	assert.Contains(t, string(out), "this is text with **bold** and _italic_  and <u>underlined</u> or even ~~striked~~\n")
+	assert.Contains(t, string(out), `- list 2
+  - sublist 1
+  - sublist 2
+- list 3`)
+	assert.Contains(t, string(out), `2. two
+   1. two.a
+3. three`)
+}
+
+func TestMarkdownAnnotations(t *testing.T) {
+	assert.Equal(t, "Public\n", Markdown(annotated))
+}
+
+func TestMarkdownExporterNames(t *testing.T) {
+
+	dir := t.TempDir()
+	exporter := MarkdownExporter{Dir: filepath.Join(dir, "posts")}
+	for _, name := range []string{"", "..", "../post", "a/../../post", `..\post`, "/etc/post", "a/b"} {
+		_, err := exporter.Export(name, nil)
+		assert.Error(t, err, name)
+	}
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+
+	path, err := exporter.Export("post-1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "posts", "post-1.md"), path)
+}
+
+func TestMarkdownLineMarkers(t *testing.T) {
+
+	doc := []Block{
+		paragraph("# not a heading"),
+		paragraph("- not a list"),
+		paragraph("+ not a list"),
+		paragraph("> not a quote"),
+		paragraph("1. not a list"),
+		paragraph("a\n2) not a list\n  ---"),
+		paragraph("in # the - middle"),
+		{Type: BlockTypeList, Format: ptr("unordered"), Children: []Block{{Type: BlockTypeListItem, Children: paragraph("# item").Children}}},
+	}
+	assert.Equal(t, `\# not a heading
+
+\- not a list
+
+\+ not a list
+
+\> not a quote
+
+1\. not a list
+
+a
+2\) not a list
+  \---
+
+in # the - middle
+
+- \# item
+`, Markdown(doc))
+}
@@ -0,0 +1,60 @@
+package blocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var ErrTooLarge = errors.New("response exceeds size limit")
+
+// fetch downloads a url, reading at most maxSize bytes.
+func fetch(ctx context.Context, client *http.Client, src string, maxSize int64) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("GET %s: %s", src, res.Status)
+	}
+	if res.ContentLength > maxSize {
+		return nil, "", fmt.Errorf("GET %s: %w", src, ErrTooLarge)
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", fmt.Errorf("GET %s: %w", src, ErrTooLarge)
+	}
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return data, contentType, nil
+}
+
+// resolveURL resolves src against base, relative upload urls like
+// /uploads/a.jpg are common with the local strapi upload provider.
+func resolveURL(base, src string) (string, error) {
+	if base == "" {
+		return src, nil
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	s, err := url.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(s).String(), nil
+}
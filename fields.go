@@ -0,0 +1,40 @@
+package blocks
+
+import (
+	"html/template"
+	"maps"
+	"slices"
+)
+
+// RenderFields renders several rich text fields of an entry, like intro and
+// body, in one call. The warnings of all fields are collected, each with the
+// name of its field.
+func (r *Renderer) RenderFields(fields map[string][]Block) map[string]string {
+	r.begin()
+	defer r.recoverRender(nil, nil)
+	out := make(map[string]string, len(fields))
+	// sorted, so the warnings are in a stable order
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		r.field = name
+		if r.checkLimits(fields[name]) != nil {
+			out[name] = ""
+			continue
+		}
+		out[name] = r.format(r.document(r.internalRender(r.transform(fields[name]))))
+	}
+	r.field = ""
+	return out
+}
+
+// RenderFieldsHTML is RenderFields for html/template.
+func (r *Renderer) RenderFieldsHTML(fields map[string][]Block) map[string]template.HTML {
+	out := map[string]template.HTML{}
+	for name, html := range r.RenderFields(fields) {
+		out[name] = template.HTML(html)
+	}
+	return out
+}
+
+func RenderFields(fields map[string][]Block) map[string]string {
+	return New().RenderFields(fields)
+}
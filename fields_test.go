@@ -0,0 +1,34 @@
+package blocks
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderFields(t *testing.T) {
+
+	fields := map[string][]Block{
+		"intro":   {paragraph("intro")},
+		"body":    {paragraph("body"), {Type: BlockTypeImage}},
+		"sidebar": {{Type: "video"}},
+	}
+
+	r := New()
+	out := r.RenderFields(fields)
+	assert.Equal(t, map[string]string{
+		"intro":   "<p>\n  intro\n</p>",
+		"body":    "<p>\n  body\n</p>\nmissing image",
+		"sidebar": "unsupported block type",
+	}, out)
+	assert.Equal(t, []Warning{
+		{Code: WarningMissingImage, Message: "image block without media", Path: []int{1}, Field: "body"},
+		{Code: WarningUnsupportedBlock, Message: `unsupported block type "video"`, Path: []int{0}, Field: "sidebar"},
+	}, r.Warnings())
+
+	assert.Equal(t, template.HTML("<p>\n  intro\n</p>"), r.RenderFieldsHTML(fields)["intro"])
+
+	r.Render(fields["sidebar"])
+	assert.Equal(t, "", r.Warnings()[0].Field)
+}
@@ -0,0 +1,174 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// blockTypeAnchor wraps the figures of NumberFigures, its url is the id
+const blockTypeAnchor BlockType = "figure-anchor"
+
+var figureTokenPattern = regexp.MustCompile(`\[fig:([\w-]+)\]`)
+
+// FigureOptions configures NumberFigures.
+type FigureOptions struct {
+	// Label is put in front of the figure numbers, defaults to "Figure".
+	Label string
+	// Key returns the name a figure is referenced by, defaults to the slug of
+	// the image name without extension, like "architecture" for
+	// "architecture.png". Figures without key can not be referenced.
+	Key func(b Block) string
+	// IDPrefix prefixes the anchor ids of the figures, defaults to "fig-".
+	IDPrefix string
+}
+
+type figureRef struct {
+	id    string
+	label string
+}
+
+// NumberFigures returns a transformer numbering the images of the document,
+// captions start with the label like "Figure 3: ". Cross-reference blocks and
+// [fig:key] tokens in texts become links to the figures, tokens of unknown
+// figures are left as they are. Images in running text are not numbered.
+func NumberFigures(opts FigureOptions) Transformer {
+	if opts.Label == "" {
+		opts.Label = "Figure"
+	}
+	if opts.Key == nil {
+		opts.Key = func(b Block) string {
+			name := b.Image.Name
+			return Slug(strings.TrimSuffix(name, path.Ext(name)))
+		}
+	}
+	if opts.IDPrefix == "" {
+		opts.IDPrefix = "fig-"
+	}
+	return TransformerFunc(func(blocks []Block) []Block {
+		figures := map[string]figureRef{}
+		ids := map[string]int{}
+		n := 0
+		var number func(blocks []Block, parent BlockType) []Block
+		number = func(blocks []Block, parent BlockType) []Block {
+			if blocks == nil {
+				return nil
+			}
+			out := make([]Block, 0, len(blocks))
+			for _, b := range blocks {
+				if b.Type != BlockTypeImage || b.Image == nil || parent == BlockTypeParagraph || parent == BlockTypeHeading || isInline(parent) {
+					b.Children = number(b.Children, b.Type)
+					out = append(out, b)
+					continue
+				}
+				n++
+				label := fmt.Sprintf("%s %d", opts.Label, n)
+				img := *b.Image
+				if img.Caption != "" {
+					img.Caption = label + ": " + img.Caption
+				} else {
+					img.Caption = label
+				}
+				key := opts.Key(b)
+				b.Image = &img
+				id := opts.IDPrefix + uniqueSlug(ids, fmt.Sprint(n))
+				if key != "" {
+					id = opts.IDPrefix + uniqueSlug(ids, key)
+					if _, taken := figures[key]; !taken {
+						figures[key] = figureRef{id: id, label: label}
+					}
+				}
+				out = append(out, Block{Type: blockTypeAnchor, URL: &id, Children: []Block{b}})
+			}
+			return out
+		}
+		return resolveFigures(number(blocks, ""), figures)
+	})
+}
+
+func resolveFigures(blocks []Block, figures map[string]figureRef) []Block {
+	if blocks == nil {
+		return nil
+	}
+	out := make([]Block, 0, len(blocks))
+	for _, b := range blocks {
+		switch b.Type {
+		case BlockTypeCode, BlockTypeLink:
+			out = append(out, b)
+		case BlockTypeCrossReference:
+			if f, ok := figures[deref(b.Target)]; ok {
+				out = append(out, figureLink(f, Block{Type: BlockTypeText}))
+			} else {
+				out = append(out, b)
+			}
+		case BlockTypeText:
+			out = append(out, resolveFigureTokens(b, figures)...)
+		default:
+			b.Children = resolveFigures(b.Children, figures)
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// resolveFigureTokens splits the text block at the tokens of known figures,
+// the parts keep the modifiers of the block.
+func resolveFigureTokens(b Block, figures map[string]figureRef) []Block {
+	if b.Text == nil || b.HasModifier(ModifierCode) {
+		return []Block{b}
+	}
+	text := *b.Text
+	part := func(s string) Block {
+		p := b
+		p.Text = &s
+		return p
+	}
+
+	out := []Block{}
+	start := 0
+	for _, loc := range figureTokenPattern.FindAllStringSubmatchIndex(text, -1) {
+		f, ok := figures[text[loc[2]:loc[3]]]
+		if !ok {
+			continue
+		}
+		if loc[0] > start {
+			out = append(out, part(text[start:loc[0]]))
+		}
+		out = append(out, figureLink(f, b))
+		start = loc[1]
+	}
+	if start == 0 {
+		return []Block{b}
+	}
+	if start < len(text) {
+		out = append(out, part(text[start:]))
+	}
+	return out
+}
+
+// figureLink links to the figure, the label keeps the modifiers of text
+func figureLink(f figureRef, text Block) Block {
+	href := "#" + f.id
+	label := f.label
+	text.Text = &label
+	return Block{Type: BlockTypeLink, URL: &href, Children: []Block{text}}
+}
+
+// renderCrossReference renders cross-references NumberFigures did not
+// resolve as their target.
+func (r *Renderer) renderCrossReference(b Block) string {
+	return html.EscapeString(r.unresolvedReference(b))
+}
+
+// unresolvedReference records the warning of a cross-reference or citation
+// which was not resolved and returns its target
+func (r *Renderer) unresolvedReference(b Block) string {
+	if b.Type == BlockTypeCitation {
+		r.warn(WarningUnresolvedReference, "citation of unknown reference %q", deref(b.Target))
+	} else {
+		r.warn(WarningUnresolvedReference, "cross-reference to unknown figure %q", deref(b.Target))
+	}
+	return deref(b.Target)
+}
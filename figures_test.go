@@ -0,0 +1,79 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberFigures(t *testing.T) {
+
+	raw := []byte(`[
+		{"type": "paragraph", "children": [
+			{"type": "text", "text": "As [fig:architecture] shows, see also "},
+			{"type": "cross-reference", "target": "chart"},
+			{"type": "text", "text": " and [fig:unknown]."}
+		]},
+		{"type": "image", "image": {"name": "architecture.png", "url": "/a.png", "alternativeText": "a", "caption": "Overview"}, "children": []},
+		{"type": "image", "image": {"name": "Chart.jpg", "url": "/c.jpg", "alternativeText": "c"}, "children": []}
+	]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	r := New(WithTransformers(NumberFigures(FigureOptions{})))
+	assert.Equal(t, `<p>
+  As
+  <a href="#fig-architecture">
+    Figure 1
+  </a>
+  shows, see also
+  <a href="#fig-chart">
+    Figure 2
+  </a>
+  and [fig:unknown].
+</p>
+<div id="fig-architecture">
+  <figure>
+    <img src="/a.png" alt="a" />
+    <figcaption>
+      Figure 1: Overview
+    </figcaption>
+  </figure>
+</div>
+<div id="fig-chart">
+  <figure>
+    <img src="/c.jpg" alt="c" />
+    <figcaption>
+      Figure 2
+    </figcaption>
+  </figure>
+</div>`, r.Render(doc))
+	assert.Equal(t, "Overview", doc[1].Image.Caption, "the document is not modified")
+}
+
+func TestUnresolvedCrossReference(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeCrossReference, Target: ptr("missing")},
+	}}}
+	out, warnings := New(WithTransformers(NumberFigures(FigureOptions{Label: "Abb."}))).RenderWithWarnings(doc)
+	assert.Equal(t, "<p>\n  missing\n</p>", out)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, WarningUnresolvedReference, warnings[0].Code)
+}
+
+func TestNumberFiguresEscapesIDs(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeImage, Image: &Image{Name: "a.png", URL: "/a.png"}},
+		{Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeCitation, Target: ptr("knuth")}}},
+	}
+	r := New(WithTransformers(
+		NumberFigures(FigureOptions{IDPrefix: `x"><script>`}),
+		Citations(CitationOptions{IDPrefix: `y"><script>`, References: []Reference{{Key: "knuth", Text: "Knuth"}}}),
+	))
+	out := r.Render(doc)
+	assert.Contains(t, out, `<div id="x&#34;&gt;&lt;script&gt;`)
+	assert.Contains(t, out, `<li id="y&#34;&gt;&lt;script&gt;knuth">`)
+}
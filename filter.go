@@ -0,0 +1,86 @@
+package blocks
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextFilter rewrites texts, for example to clean up user generated content.
+type TextFilter interface {
+	FilterText(text string) string
+}
+
+type TextFilterFunc func(text string) string
+
+func (f TextFilterFunc) FilterText(text string) string {
+	return f(text)
+}
+
+// FilterTexts applies the filters in order to all texts of the document.
+// Link urls are left as they are.
+func FilterTexts(filters ...TextFilter) Transformer {
+	return replaceFunc(func(text string) string {
+		for _, f := range filters {
+			text = f.FilterText(text)
+		}
+		return text
+	}, ReplaceOptions{})
+}
+
+// WordMask is a TextFilter masking whole words, ignoring case.
+type WordMask struct {
+	// Mask replaces every letter of a masked word, defaults to '*'
+	Mask rune
+	// KeepFirst keeps the first letter of masked words readable
+	KeepFirst bool
+
+	pattern *regexp.Regexp
+}
+
+func NewWordMask(words ...string) *WordMask {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	m := &WordMask{Mask: '*'}
+	if len(quoted) > 0 {
+		m.pattern = regexp.MustCompile(`(?i)(^|[^\pL\pN])(` + strings.Join(quoted, "|") + `)($|[^\pL\pN])`)
+	}
+	return m
+}
+
+func (m *WordMask) FilterText(text string) string {
+	if m.pattern == nil {
+		return text
+	}
+	mask := m.Mask
+	if mask == 0 {
+		mask = '*'
+	}
+	out := strings.Builder{}
+	last := 0
+	// the boundaries are part of the match, so adjacent words are found one
+	// by one
+	for {
+		loc := m.pattern.FindStringSubmatchIndex(text[last:])
+		if loc == nil {
+			break
+		}
+		from, to := last+loc[4], last+loc[5]
+		out.WriteString(text[last:from])
+		word := text[from:to]
+		n := utf8.RuneCountInString(word)
+		if m.KeepFirst {
+			first, _ := utf8.DecodeRuneInString(word)
+			out.WriteRune(first)
+			n--
+		}
+		out.WriteString(strings.Repeat(string(mask), n))
+		last = to
+	}
+	out.WriteString(text[last:])
+	return out.String()
+}
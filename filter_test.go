@@ -0,0 +1,39 @@
+package blocks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordMask(t *testing.T) {
+
+	m := NewWordMask("darn", "heck")
+	assert.Equal(t, "**** it, **** ****! darned", m.FilterText("Darn it, heck darn! darned"))
+
+	m.KeepFirst = true
+	m.Mask = '#'
+	assert.Equal(t, "h### no", m.FilterText("heck no"))
+
+	assert.Equal(t, "heck", NewWordMask().FilterText("heck"))
+}
+
+func TestFilterTexts(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("what the heck "), Italic: ptr(true)},
+		link("https://example.com/heck", "heck"),
+	}}}
+
+	upper := TextFilterFunc(strings.ToUpper)
+	r := New(WithTransformers(FilterTexts(NewWordMask("heck"), upper)))
+	assert.Equal(t, `<p>
+  <em>
+    WHAT THE ****
+  </em>
+  <a href="https://example.com/heck">
+    ****
+  </a>
+</p>`, r.Render(doc))
+}
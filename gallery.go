@@ -0,0 +1,89 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+const DefaultGalleryColumns = 3
+
+// GalleryOptions configures the grid of gallery blocks.
+type GalleryOptions struct {
+	// Columns is the number of grid columns, defaults to
+	// DefaultGalleryColumns.
+	Columns int
+	// Sizes is the sizes attribute of the images, defaults to the share of
+	// the viewport width of one column.
+	Sizes string
+}
+
+// WithGallery sets the grid of gallery blocks.
+func WithGallery(opts GalleryOptions) Option {
+	return func(r *Renderer) {
+		r.gallery = opts
+	}
+}
+
+// RenderGallery renders the images of gallery blocks as a grid of figures.
+// Images with formats get a srcset, so the browser loads the format fitting
+// the column width.
+func (r *Renderer) RenderGallery(b Block) string {
+	if !r.hasMedia(b) {
+		return r.placeholders.MissingImage
+	}
+	columns, sizes := r.galleryGrid()
+	figures := strings.Builder{}
+	for _, img := range b.Images {
+		extra := ""
+		if srcset := Srcset(img); srcset != "" {
+			extra = fmt.Sprintf(` srcset="%s" sizes="%s"`, html.EscapeString(srcset), html.EscapeString(sizes))
+		}
+		caption := ""
+		if img.Caption != "" {
+			caption = fmt.Sprintf("<figcaption%s>%s</figcaption>", r.attrs(ElementFigcaption), html.EscapeString(img.Caption))
+		}
+		fmt.Fprintf(&figures, "<figure%s>%s%s</figure>", r.attrs(ElementGalleryItem), r.image(img, extra), caption)
+	}
+	style := fmt.Sprintf("grid-template-columns: repeat(%d, 1fr);", columns)
+	return fmt.Sprintf(`<div%s data-columns="%d">%s</div>`, r.attrsStyle(style, ElementGallery), columns, figures.String())
+}
+
+// galleryGrid returns the columns and the sizes attribute with defaults
+func (r *Renderer) galleryGrid() (int, string) {
+	columns := r.gallery.Columns
+	if columns <= 0 {
+		columns = DefaultGalleryColumns
+	}
+	sizes := r.gallery.Sizes
+	if sizes == "" {
+		sizes = fmt.Sprintf("(max-width: 640px) 100vw, %dvw", 100/columns)
+	}
+	return columns, sizes
+}
+
+// Srcset lists the formats of the image and the original as srcset
+// candidates, smallest first. Formats of other mime types are left out.
+func Srcset(img Image) string {
+	formats := []ImageFormat{}
+	for _, f := range img.Formats {
+		if f.URL != "" && f.Width > 0 && (img.Mime == "" || f.Mime == "" || f.Mime == img.Mime) {
+			formats = append(formats, f)
+		}
+	}
+	if len(formats) == 0 {
+		return ""
+	}
+	if img.Width > 0 {
+		formats = append(formats, ImageFormat{URL: img.URL, Width: img.Width})
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		return formats[i].Width < formats[j].Width
+	})
+	candidates := make([]string, 0, len(formats))
+	for _, f := range formats {
+		candidates = append(candidates, fmt.Sprintf("%s %dw", f.URL, f.Width))
+	}
+	return strings.Join(candidates, ", ")
+}
@@ -0,0 +1,43 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderGallery(t *testing.T) {
+
+	raw := []byte(`[{"type": "gallery", "children": [], "images": [
+		{"url": "/a.jpg", "alternativeText": "a", "caption": "First", "width": 1200, "height": 800, "mime": "image/jpeg", "formats": {
+			"small": {"url": "/small_a.jpg", "mime": "image/jpeg", "width": 500, "height": 333},
+			"thumbnail": {"url": "/thumbnail_a.jpg", "mime": "image/jpeg", "width": 234, "height": 156},
+			"webp": {"url": "/a.webp", "mime": "image/webp", "width": 500, "height": 333}
+		}},
+		{"url": "/b.png", "alternativeText": "b"}
+	]}]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `<div class="gallery" style="grid-template-columns: repeat(2, 1fr);" data-columns="2">
+  <figure>
+    <img src="/a.jpg" alt="a" width="1200" height="800" srcset="/thumbnail_a.jpg 234w, /small_a.jpg 500w, /a.jpg 1200w" sizes="(max-width: 640px) 100vw, 50vw" />
+    <figcaption>
+      First
+    </figcaption>
+  </figure>
+  <figure>
+    <img src="/b.png" alt="b" />
+  </figure>
+</div>`, New(WithGallery(GalleryOptions{Columns: 2})).Render(doc))
+	assert.Equal(t, 2, Analyze(doc).Images)
+}
+
+func TestRenderGalleryWithoutImages(t *testing.T) {
+
+	out, warnings := New().RenderWithWarnings([]Block{{Type: BlockTypeGallery}})
+	assert.Equal(t, DefaultPlaceholders.MissingImage, out)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, WarningMissingImage, warnings[0].Code)
+}
@@ -0,0 +1,221 @@
+// Package gomponents renders strapi blocks into gomponents nodes, so the
+// content can be composed with existing component trees and benefits from the
+// escaping of gomponents.
+package gomponents
+
+import (
+	"strconv"
+	"strings"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	g "maragu.dev/gomponents"
+	h "maragu.dev/gomponents/html"
+)
+
+// Render returns all blocks as a single group node.
+func Render(bs []blocks.Block) g.Node {
+	return g.Group(Nodes(bs))
+}
+
+// Nodes returns one node per block.
+func Nodes(bs []blocks.Block) []g.Node {
+	return blocks.Build(Backend{}, bs)
+}
+
+// NodesWith returns one node per block, built with the options of the
+// renderer, see blocks.BuildWith.
+func NodesWith(r *blocks.Renderer, bs []blocks.Block) ([]g.Node, error) {
+	return blocks.BuildWith(r, Backend{}, bs)
+}
+
+// Block renders a single block and its children.
+func Block(b blocks.Block) g.Node {
+	return blocks.BuildBlock(Backend{}, b)
+}
+
+// Backend builds gomponents nodes, embed it to override single block types.
+type Backend struct{}
+
+func (Backend) Paragraph(b blocks.Block, children []g.Node) g.Node {
+	return h.P(children...)
+}
+
+func (Backend) LineBreak(b blocks.Block) g.Node {
+	return h.Br()
+}
+
+func (Backend) Text(b blocks.Block, text string) g.Node {
+	return g.Text(text)
+}
+
+func (Backend) Modifier(m blocks.Modifier, b blocks.Block, content g.Node) g.Node {
+	switch m {
+	case blocks.ModifierBold:
+		return h.Strong(content)
+	case blocks.ModifierItalic:
+		return h.Em(content)
+	case blocks.ModifierUnderline:
+		return h.U(content)
+	case blocks.ModifierStrikeThrough:
+		return h.Del(content)
+	case blocks.ModifierCode:
+		return h.Code(content)
+	case blocks.ModifierSuperscript:
+		return h.Sup(content)
+	case blocks.ModifierSubscript:
+		return h.Sub(content)
+	case blocks.ModifierKeyboard:
+		return h.Kbd(content)
+	case blocks.ModifierHighlight:
+		return h.Mark(g.If(b.Highlight.Color != "", h.DataAttr("color", b.Highlight.Color)), content)
+	}
+	return content
+}
+
+func (Backend) List(b blocks.Block, format blocks.ListFormat, children []g.Node) g.Node {
+	if format == blocks.ListFormatOrdered {
+		return h.Ol(children...)
+	}
+	return h.Ul(children...)
+}
+
+func (Backend) ListItem(b blocks.Block, children []g.Node) g.Node {
+	return h.Li(children...)
+}
+
+func (Backend) Heading(b blocks.Block, level int, children []g.Node) g.Node {
+	return g.El("h"+strconv.Itoa(level), children...)
+}
+
+func (Backend) Link(b blocks.Block, link blocks.ResolvedLink, children []g.Node) g.Node {
+	attrs := make([]g.Node, 0, len(link.Attributes))
+	for _, a := range link.Attributes {
+		if a.Value == "" {
+			attrs = append(attrs, g.Attr(a.Name))
+			continue
+		}
+		attrs = append(attrs, g.Attr(a.Name, a.Value))
+	}
+	a := h.A(h.Href(link.Href), g.If(link.Title != "", h.Title(link.Title)), g.Group(attrs), g.Group(children))
+	if link.Printed == "" {
+		return a
+	}
+	return g.Group{a, g.Text(" "), h.Span(g.Text("(" + link.Printed + ")"))}
+}
+
+func (Backend) Image(b blocks.Block, img blocks.Image) g.Node {
+	return imgNode(img)
+}
+
+func imgNode(img blocks.Image, attrs ...g.Node) g.Node {
+	return h.Img(
+		h.Src(img.URL),
+		h.Alt(img.AlternativeText),
+		g.If(img.Width > 0 && img.Height > 0, g.Group{
+			h.Width(strconv.Itoa(img.Width)),
+			h.Height(strconv.Itoa(img.Height)),
+		}),
+		g.Group(attrs),
+	)
+}
+
+func (Backend) Gallery(b blocks.Block, images []blocks.Image) g.Node {
+	figures := []g.Node{}
+	for _, img := range images {
+		srcset := blocks.Srcset(img)
+		figures = append(figures, h.Figure(
+			imgNode(img, g.If(srcset != "", h.SrcSet(srcset))),
+			g.If(img.Caption != "", h.FigCaption(g.Text(img.Caption))),
+		))
+	}
+	return h.Div(h.Class("gallery"), g.Group(figures))
+}
+
+func (Backend) Quote(b blocks.Block, children []g.Node) g.Node {
+	return h.BlockQuote(children...)
+}
+
+func (Backend) Code(b blocks.Block, children []g.Node) g.Node {
+	return h.Pre(h.Code(children...))
+}
+
+func (Backend) Mention(b blocks.Block, m blocks.Mention, url string) g.Node {
+	label := g.Text("@" + strings.TrimPrefix(m.Label(), "@"))
+	if url != "" {
+		return h.A(h.Href(url), label)
+	}
+	return h.Span(label)
+}
+
+func (Backend) Math(b blocks.Block, tex string, display bool) g.Node {
+	if display {
+		return h.Div(h.Class("math display"), g.Text(`\[`+tex+`\]`))
+	}
+	return h.Span(h.Class("math inline"), g.Text(`\(`+tex+`\)`))
+}
+
+// HTML inserts the html as is, it is only built with a renderer enabling raw
+// html, see blocks.WithRawHTML.
+func (Backend) HTML(b blocks.Block, html string) g.Node {
+	return g.Raw(html)
+}
+
+func (Backend) Annotation(b blocks.Block, children []g.Node) g.Node {
+	return h.Aside(g.Attr("role", "note"), g.Group(children))
+}
+
+func (Backend) Abbreviation(b blocks.Block, title string, children []g.Node) g.Node {
+	return h.Abbr(g.If(title != "", h.Title(title)), g.Group(children))
+}
+
+func (Backend) DefinitionList(b blocks.Block, children []g.Node) g.Node {
+	return h.Dl(children...)
+}
+
+func (Backend) DefinitionTerm(b blocks.Block, children []g.Node) g.Node {
+	return h.Dt(children...)
+}
+
+func (Backend) DefinitionDescription(b blocks.Block, children []g.Node) g.Node {
+	return h.Dd(children...)
+}
+
+func (Backend) Details(b blocks.Block, summary string, children []g.Node) g.Node {
+	return h.Details(g.If(b.Open != nil && *b.Open, g.Attr("open")), h.Summary(g.Text(summary)), g.Group(children))
+}
+
+func (Backend) Columns(b blocks.Block, children []g.Node) g.Node {
+	return h.Div(h.Class("columns"), h.DataAttr("columns", strconv.Itoa(len(children))), g.Group(children))
+}
+
+func (Backend) Column(b blocks.Block, children []g.Node) g.Node {
+	return h.Div(h.Class("column"), g.Group(children))
+}
+
+func (Backend) CrossReference(b blocks.Block, target string) g.Node {
+	return g.Text(target)
+}
+
+func (Backend) Citation(b blocks.Block, key string) g.Node {
+	return g.Text("[" + key + "]")
+}
+
+func (Backend) Figure(b blocks.Block, id string, children []g.Node) g.Node {
+	return h.Div(h.ID(id), g.Group(children))
+}
+
+func (Backend) References(b blocks.Block, title string, children []g.Node) g.Node {
+	return h.Section(h.H2(g.Text(title)), h.Ol(children...))
+}
+
+func (Backend) Reference(b blocks.Block, id string, children []g.Node) g.Node {
+	return h.Li(h.ID(id), g.Group(children))
+}
+
+func (Backend) Mark(b blocks.Block, children []g.Node) g.Node {
+	return h.Mark(children...)
+}
+
+func (Backend) Fallback(b blocks.Block, message string) g.Node {
+	return g.Text(message)
+}
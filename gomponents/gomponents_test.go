@@ -0,0 +1,84 @@
+package gomponents
+
+import (
+	"strings"
+	"testing"
+
+	blocks "github.com/cdreier/strapi-blocks-go-renderer"
+	"github.com/stretchr/testify/assert"
+	g "maragu.dev/gomponents"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestRender(t *testing.T) {
+
+	doc := []blocks.Block{
+		{Type: blocks.BlockTypeHeading, Level: ptr(2), Children: []blocks.Block{
+			{Type: blocks.BlockTypeText, Text: ptr("Title")},
+		}},
+		{Type: blocks.BlockTypeParagraph, Children: []blocks.Block{
+			{Type: blocks.BlockTypeText, Text: ptr("<script> and ")},
+			{Type: blocks.BlockTypeText, Text: ptr("bold"), Bold: ptr(true), Italic: ptr(true)},
+			{Type: blocks.BlockTypeLink, URL: ptr("https://example.com"), Children: []blocks.Block{
+				{Type: blocks.BlockTypeText, Text: ptr("link")},
+			}},
+		}},
+		{Type: blocks.BlockTypeParagraph, Children: []blocks.Block{
+			{Type: blocks.BlockTypeText, Text: ptr("")},
+		}},
+		{Type: blocks.BlockTypeImage, Image: &blocks.Image{URL: "/a.jpg", AlternativeText: "a", Width: 20, Height: 10}},
+	}
+
+	out := strings.Builder{}
+	err := Render(doc).Render(&out)
+	assert.NoError(t, err)
+
+	assert.Equal(t, `<h2>Title</h2><p>&lt;script&gt; and <em><strong>bold</strong></em><a href="https://example.com">link</a></p><br><img src="/a.jpg" alt="a" width="20" height="10">`, out.String())
+}
+
+func TestNodesWith(t *testing.T) {
+
+	doc := []blocks.Block{
+		{Type: blocks.BlockTypeParagraph, Children: []blocks.Block{
+			{Type: blocks.BlockTypeText, Text: ptr("x^2"), Math: ptr(true)},
+			{Type: blocks.BlockTypeMention, Mention: &blocks.Mention{Username: "rob"}},
+			{Type: blocks.BlockTypeText, Text: ptr("bold"), Bold: ptr(true), Italic: ptr(true)},
+		}},
+		{Type: blocks.BlockTypeHTML, HTML: ptr(`<b onclick="x()">html</b>`)},
+	}
+	r := blocks.New(
+		blocks.WithModifierOrder(blocks.ModifierItalic, blocks.ModifierBold),
+		blocks.WithRawHTML(blocks.DefaultAllowlist),
+		blocks.WithTransformers(blocks.Highlight("old")),
+	)
+
+	nodes, err := NodesWith(r, doc)
+	assert.NoError(t, err)
+	out := strings.Builder{}
+	err = g.Group(nodes).Render(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, `<p><span class="math inline">\(x^2\)</span><span>@rob</span><strong><em>b</em></strong><mark><strong><em>old</em></strong></mark></p><b>html</b>`, out.String())
+
+	out.Reset()
+	err = Render(doc[1:]).Render(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, "html blocks are not enabled", out.String())
+}
+
+func TestNodesWithLinks(t *testing.T) {
+
+	doc := []blocks.Block{{Type: blocks.BlockTypeParagraph, Children: []blocks.Block{
+		{Type: blocks.BlockTypeLink, URL: ptr("https://example.com"), Children: []blocks.Block{{Type: blocks.BlockTypeText, Text: ptr("example")}}},
+	}}}
+	r := blocks.New(blocks.WithLinkPolicy(blocks.LinkPolicy{ExternalTarget: "_blank"}), blocks.WithPrint())
+
+	nodes, err := NodesWith(r, doc)
+	assert.NoError(t, err)
+	out := strings.Builder{}
+	err = g.Group(nodes).Render(&out)
+	assert.NoError(t, err)
+	assert.Equal(t, `<p><a href="https://example.com" target="_blank" rel="noopener noreferrer">example</a> <span>(https://example.com)</span></p>`, out.String())
+}
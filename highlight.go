@@ -0,0 +1,100 @@
+package blocks
+
+import (
+	"strings"
+
+	"golang.org/x/text/transform"
+)
+
+// blockTypeMark wraps the matches of Highlight
+const blockTypeMark BlockType = "highlight-mark"
+
+// Highlight wraps the occurrences of the query in <mark> elements, ignoring
+// case and diacritics. Code blocks are left as they are, and matches spanning
+// texts with different modifiers are not found.
+func Highlight(query string) Transformer {
+	return TransformerFunc(func(blocks []Block) []Block {
+		folded, _ := fold(query)
+		if strings.TrimSpace(folded) == "" {
+			return blocks
+		}
+		return highlightBlocks(blocks, folded)
+	})
+}
+
+func highlightBlocks(blocks []Block, query string) []Block {
+	if blocks == nil {
+		return nil
+	}
+	out := make([]Block, 0, len(blocks))
+	for _, b := range blocks {
+		switch b.Type {
+		case BlockTypeCode:
+			out = append(out, b)
+		case BlockTypeText:
+			out = append(out, highlightText(b, query)...)
+		default:
+			b.Children = highlightBlocks(b.Children, query)
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// highlightText splits the text block at the matches, the parts keep the
+// modifiers of the block.
+func highlightText(b Block, query string) []Block {
+	if b.Text == nil || (b.Code != nil && *b.Code) {
+		return []Block{b}
+	}
+	text := *b.Text
+	folded, offsets := fold(text)
+	part := func(s string) Block {
+		p := b
+		p.Text = &s
+		return p
+	}
+
+	out := []Block{}
+	start := 0
+	for pos := 0; pos < len(folded); {
+		i := strings.Index(folded[pos:], query)
+		if i < 0 {
+			break
+		}
+		from, to := offsets[pos+i], offsets[pos+i+len(query)]
+		if from > start {
+			out = append(out, part(text[start:from]))
+		}
+		out = append(out, Block{Type: blockTypeMark, Children: []Block{part(text[from:to])}})
+		start = to
+		pos += i + len(query)
+	}
+	if start == 0 {
+		return []Block{b}
+	}
+	if start < len(text) {
+		out = append(out, part(text[start:]))
+	}
+	return out
+}
+
+// fold lowercases the text and removes diacritics. The offsets map every
+// byte of the folded text, and its end, to the byte in the original text.
+func fold(text string) (string, []int) {
+	folded := strings.Builder{}
+	offsets := make([]int, 0, len(text)+1)
+	for i, c := range text {
+		piece, _, err := transform.String(slugFolder, string(c))
+		if err != nil {
+			piece = string(c)
+		}
+		piece = strings.ToLower(piece)
+		for range len(piece) {
+			offsets = append(offsets, i)
+		}
+		folded.WriteString(piece)
+	}
+	offsets = append(offsets, len(text))
+	return folded.String(), offsets
+}
@@ -0,0 +1,53 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("Crème brûlée and creme "), Bold: ptr(true)},
+			{Type: BlockTypeLink, URL: ptr("/creme"), Children: []Block{{Type: BlockTypeText, Text: ptr("CREME")}}},
+		}},
+		codeBlock("", "creme := 1"),
+	}
+
+	r := New(WithTransformers(Highlight("crème")))
+	assert.Equal(t, `<p>
+  <mark>
+    <strong>
+      Crème
+    </strong>
+  </mark>
+  <strong>
+    brûlée and
+  </strong>
+  <mark>
+    <strong>
+      creme
+    </strong>
+  </mark>
+  <strong></strong>
+  <a href="/creme">
+    <mark>
+      CREME
+    </mark>
+  </a>
+</p><pre><code>creme := 1</code></pre>`, r.Render(doc))
+
+	assert.Equal(t, "Crème brûlée and creme ", *doc[0].Children[0].Text)
+	assert.Equal(t, Render(doc), New(WithTransformers(Highlight(" "))).Render(doc))
+}
+
+func TestWithTransformers(t *testing.T) {
+
+	drop := TransformerFunc(func(blocks []Block) []Block {
+		return blocks[1:]
+	})
+	r := New(WithTransformers(drop, drop))
+	assert.Equal(t, "<p>\n  three\n</p>", r.Render([]Block{paragraph("one"), paragraph("two"), paragraph("three")}))
+}
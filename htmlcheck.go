@@ -0,0 +1,83 @@
+package blocks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var voidElements = []string{"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr"}
+
+// blockElements close an open <p> in browsers, so they must not be nested in
+// paragraphs
+var blockElements = []string{"address", "article", "aside", "blockquote", "details", "dialog", "div", "dl", "fieldset",
+	"figcaption", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header", "hgroup", "hr", "main", "menu",
+	"nav", "ol", "p", "pre", "section", "table", "ul"}
+
+// WithHTMLValidation checks the html of every top level block and records
+// structural problems as warnings, like unclosed tags, stray end tags or
+// block elements inside paragraphs. It is meant for development and tests,
+// to catch bugs of custom renderers early.
+func WithHTMLValidation() Option {
+	return func(r *Renderer) {
+		r.validateHTML = true
+	}
+}
+
+func (r *Renderer) checkHTML(b Block, out string) {
+	for _, problem := range htmlProblems(out) {
+		r.warn(WarningInvalidHTML, "%s block renders invalid html: %s", b.Type, problem)
+	}
+}
+
+// htmlProblems returns the structural problems of the html fragment
+func htmlProblems(fragment string) []string {
+	problems := []string{}
+	open := []string{}
+	z := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		name, _ := z.TagName()
+		tag := string(name)
+		switch tt {
+		case html.StartTagToken:
+			if slices.Contains(open, "p") && slices.Contains(blockElements, tag) {
+				problems = append(problems, fmt.Sprintf("<%s> inside <p>", tag))
+			}
+			if tag == "a" && slices.Contains(open, "a") {
+				problems = append(problems, "<a> inside <a>")
+			}
+			if tag == "li" && (len(open) == 0 || !slices.Contains([]string{"ul", "ol", "menu"}, open[len(open)-1])) {
+				problems = append(problems, "<li> outside of a list")
+			}
+			if !slices.Contains(voidElements, tag) {
+				open = append(open, tag)
+			}
+		case html.EndTagToken:
+			if slices.Contains(voidElements, tag) {
+				continue
+			}
+			i := len(open) - 1
+			for i >= 0 && open[i] != tag {
+				i--
+			}
+			if i < 0 {
+				problems = append(problems, fmt.Sprintf("unexpected </%s>", tag))
+				continue
+			}
+			for _, unclosed := range slices.Backward(open[i+1:]) {
+				problems = append(problems, fmt.Sprintf("unclosed <%s>", unclosed))
+			}
+			open = open[:i]
+		}
+	}
+	for _, unclosed := range slices.Backward(open) {
+		problems = append(problems, fmt.Sprintf("unclosed <%s>", unclosed))
+	}
+	return problems
+}
@@ -0,0 +1,46 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLProblems(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		fragment string
+		problems []string
+	}{
+		{"valid", `<p>a<br/><img src="x"><a href="#">b</a></p><ul><li>c</li></ul>`, []string{}},
+		{"unclosed", `<p><strong>a</p>`, []string{"unclosed <strong>"}},
+		{"unexpected", `<p>a</p></div>`, []string{"unexpected </div>"}},
+		{"block in paragraph", `<p><div>a</div></p>`, []string{"<div> inside <p>"}},
+		{"nested links", `<a href="/a"><a href="/b">b</a></a>`, []string{"<a> inside <a>"}},
+		{"list item", `<li>a</li>`, []string{"<li> outside of a list"}},
+		{"unclosed at end", `<div><span>a`, []string{"unclosed <span>", "unclosed <div>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problems, htmlProblems(tt.fragment))
+		})
+	}
+}
+
+func TestHTMLValidation(t *testing.T) {
+
+	r := New(WithHTMLValidation())
+	WithBlockRenderers(BlockRendererFunc(func(b Block) (string, bool) {
+		if b.Type != BlockTypeQuote {
+			return "", false
+		}
+		return "<p><blockquote>" + r.RenderChildren(b) + "</blockquote>", true
+	}))(r)
+	doc := []Block{paragraph("fine"), {Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quote")}}}}
+	_, warnings := r.RenderWithWarnings(doc)
+	assert.Equal(t, []Warning{
+		{Code: WarningInvalidHTML, Message: "quote block renders invalid html: <blockquote> inside <p>", Path: []int{1}},
+		{Code: WarningInvalidHTML, Message: "quote block renders invalid html: unclosed <p>", Path: []int{1}},
+	}, warnings)
+}
@@ -0,0 +1,142 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// image renders the img tag with the picture and placeholder options, extra
+// attributes are added to the img tag.
+func (r *Renderer) image(img Image, extra string) string {
+	if r.print {
+		extra = ` loading="eager"` + extra
+	}
+	tag := r.imageTag(img, extra)
+	if r.picture != nil || r.darkImage != nil {
+		tag = r.pictureTag(img, tag)
+	}
+	if r.lqip {
+		tag = r.lowQualityPlaceholder(img, tag)
+	}
+	return tag
+}
+
+func (r *Renderer) imageTag(img Image, extra string) string {
+	attrs := fmt.Sprintf("src=%q alt=%q", r.imageSrc(img), img.AlternativeText)
+	var style string
+	if img.Width > 0 && img.Height > 0 {
+		attrs += fmt.Sprintf(` width="%d" height="%d"`, img.Width, img.Height)
+		if r.aspectRatio {
+			style = fmt.Sprintf("aspect-ratio: %d / %d;", img.Width, img.Height)
+		}
+	}
+	return fmt.Sprintf("<img %s%s%s />", attrs, extra, r.attrsStyle(style, ElementImage))
+}
+
+// imageSrc returns the url of the img tag
+func (r *Renderer) imageSrc(img Image) string {
+	if r.imageURLBuilder != nil {
+		return r.imageURLBuilder.ImageURL(img)
+	}
+	return img.URL
+}
+
+func (r *Renderer) pictureTag(img Image, tag string) string {
+	sources := strings.Builder{}
+	if r.darkImage != nil {
+		// media sources come first, the first matching source is used
+		if dark := r.darkImage(img); dark != "" {
+			fmt.Fprintf(&sources, `<source media="(prefers-color-scheme: dark)" srcset="%s" />`, html.EscapeString(dark))
+		}
+	}
+	for _, mime := range r.pictureTypes() {
+		var srcset string
+		if r.picture.SourceURL != nil {
+			srcset = r.picture.SourceURL(img, mime)
+		} else {
+			srcset = formatSrcset(img, mime)
+		}
+		if srcset != "" {
+			fmt.Fprintf(&sources, `<source type="%s" srcset="%s" />`, html.EscapeString(mime), html.EscapeString(srcset))
+		}
+	}
+	if sources.Len() == 0 {
+		return tag
+	}
+	return fmt.Sprintf("<picture>%s%s</picture>", sources.String(), tag)
+}
+
+func (r *Renderer) pictureTypes() []string {
+	if r.picture == nil {
+		return nil
+	}
+	return r.picture.Types
+}
+
+// formatSrcset lists all formats of the given mime type, smallest first
+func formatSrcset(img Image, mime string) string {
+	formats := []ImageFormat{}
+	for _, f := range img.Formats {
+		if f.Mime == mime && f.URL != "" {
+			formats = append(formats, f)
+		}
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		return formats[i].Width < formats[j].Width
+	})
+	candidates := make([]string, 0, len(formats))
+	for _, f := range formats {
+		if f.Width > 0 {
+			candidates = append(candidates, fmt.Sprintf("%s %dw", f.URL, f.Width))
+		} else {
+			candidates = append(candidates, f.URL)
+		}
+	}
+	return strings.Join(candidates, ", ")
+}
+
+func (r *Renderer) figure(img Image, content string) string {
+	figure, caption := r.ariaCaption()
+	attrs := r.attrs(ElementFigure)
+	if r.print {
+		attrs = r.attrsStyle(printFigureStyle, ElementFigure)
+	}
+	return fmt.Sprintf("<figure%s%s>%s<figcaption%s%s>%s</figcaption></figure>",
+		attrs, figure, content, r.attrs(ElementFigcaption), caption, html.EscapeString(img.Caption))
+}
+
+// size of the decoded blurhash, the browser scales it up smoothly
+const blurhashSize = 32
+
+// cssURLEscaper percent-encodes the characters ending a quoted css url
+var cssURLEscaper = strings.NewReplacer(
+	"'", "%27",
+	`"`, "%22",
+	"(", "%28",
+	")", "%29",
+	`\`, "%5C",
+	"\n", "%0A",
+	"\r", "%0D",
+)
+
+func (r *Renderer) lowQualityPlaceholder(img Image, tag string) string {
+	background := ""
+	if img.Blurhash != "" {
+		uri, err := blurhashDataURI(img.Blurhash, blurhashSize, blurhashSize)
+		if err == nil {
+			background = uri
+		} else {
+			r.warn(WarningInvalidBlurhash, "image %q has an invalid blurhash", img.Name)
+		}
+	}
+	if background == "" {
+		background = img.PreviewURL
+	}
+	if background == "" {
+		return tag
+	}
+	style := fmt.Sprintf("background-image: url('%s'); background-size: cover;", cssURLEscaper.Replace(background))
+	return fmt.Sprintf(`<div style="%s"%s>%s</div>`, html.EscapeString(style), r.nonceAttr(), tag)
+}
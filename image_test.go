@@ -0,0 +1,135 @@
+package blocks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLowQualityPlaceholders(t *testing.T) {
+
+	r := New(WithLowQualityPlaceholders())
+
+	out := r.Render([]Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg", PreviewURL: "/preview_a.jpg"}}})
+	assert.Equal(t, `<div style="background-image: url(&#39;/preview_a.jpg&#39;); background-size: cover;">
+  <img src="/a.jpg" alt="" />
+</div>`, out)
+
+	out = r.Render([]Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg", Blurhash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj"}}})
+	assert.True(t, strings.HasPrefix(out, `<div style="background-image: url(&#39;data:image/png;base64,`), out)
+	assert.Empty(t, r.Warnings())
+
+	out = r.Render([]Block{{Type: BlockTypeImage, Image: &Image{Name: "a.jpg", URL: "/a.jpg", Blurhash: "nope"}}})
+	assert.Equal(t, `<img src="/a.jpg" alt="" />`, out)
+	assert.Equal(t, []Warning{{Code: WarningInvalidBlurhash, Message: `image "a.jpg" has an invalid blurhash`, Path: []int{0}}}, r.Warnings())
+
+	out = New().Render([]Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg", PreviewURL: "/preview_a.jpg"}}})
+	assert.Equal(t, `<img src="/a.jpg" alt="" />`, out)
+
+	out = New(WithLowQualityPlaceholders(), WithNonce("n0nce")).Render([]Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg", PreviewURL: `/a.jpg'); background: url("/x\y")`}}})
+	assert.Equal(t, `<div style="background-image: url(&#39;/a.jpg%27%29; background: url%28%22/x%5Cy%22%29&#39;); background-size: cover;" nonce="n0nce">
+  <img src="/a.jpg" alt="" />
+</div>`, out)
+}
+
+func TestDecodeBlurhash(t *testing.T) {
+
+	img, err := decodeBlurhash("LEHV6nWB2yk8pyo0adR*.7kCMdnj", 4, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, img.Bounds().Dx())
+	assert.Equal(t, 3, img.Bounds().Dy())
+
+	_, err = decodeBlurhash("LEHV6nWB2yk8pyo0adR*.7kCMdn", 4, 3)
+	assert.ErrorIs(t, err, errInvalidBlurhash)
+}
+
+func TestRenderImage_Dimensions(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg", AlternativeText: "a", Width: 640, Height: 480}}}
+
+	assert.Equal(t, `<img src="/a.jpg" alt="a" width="640" height="480" />`, New().Render(doc))
+	assert.Equal(t, `<img src="/a.jpg" alt="a" width="640" height="480" style="aspect-ratio: 640 / 480;" />`, New(WithAspectRatio()).Render(doc))
+}
+
+func TestWithPicture(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg", Formats: map[string]ImageFormat{
+		"thumbnail":  {URL: "/thumbnail_a.jpg", Mime: "image/jpeg", Width: 156},
+		"small_webp": {URL: "/small_a.webp", Mime: "image/webp", Width: 500},
+		"thumb_webp": {URL: "/thumbnail_a.webp", Mime: "image/webp", Width: 156},
+	}}}}
+
+	out := New(WithPicture(PictureOptions{})).Render(doc)
+	assert.Equal(t, `<picture>
+  <source type="image/webp" srcset="/thumbnail_a.webp 156w, /small_a.webp 500w" />
+  <img src="/a.jpg" alt="" />
+</picture>`, out)
+
+	out = New(WithPicture(PictureOptions{
+		Types: []string{"image/avif"},
+		SourceURL: func(img Image, mime string) string {
+			return img.URL + "?format=avif"
+		},
+	})).Render(doc)
+	assert.Equal(t, `<picture>
+  <source type="image/avif" srcset="/a.jpg?format=avif" />
+  <img src="/a.jpg" alt="" />
+</picture>`, out)
+
+	out = New(WithPicture(PictureOptions{})).Render([]Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg"}}})
+	assert.Equal(t, `<img src="/a.jpg" alt="" />`, out)
+}
+
+func TestRenderImage_Caption(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeImage, Image: &Image{URL: "/a.jpg", AlternativeText: "a", Caption: "a gopher & friends"}}}
+
+	assert.Equal(t, `<figure>
+  <img src="/a.jpg" alt="a" />
+  <figcaption>
+    a gopher &amp; friends
+  </figcaption>
+</figure>`, New().Render(doc))
+
+	r := New(WithClasses(Classes{ElementFigure: "figure", ElementFigcaption: "figure-caption"}))
+	assert.Equal(t, `<figure class="figure">
+  <img src="/a.jpg" alt="a" />
+  <figcaption class="figure-caption">
+    a gopher &amp; friends
+  </figcaption>
+</figure>`, r.Render(doc))
+}
+
+func TestRenderImage_inline(t *testing.T) {
+
+	icon := Block{Type: BlockTypeImage, Image: &Image{URL: "/icon.svg", AlternativeText: "icon", Caption: "An icon"}}
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("Click ")},
+			icon,
+			{Type: BlockTypeLink, URL: ptr("/home"), Children: []Block{icon}},
+		}},
+		icon,
+	}
+
+	assert.Equal(t, `<p>
+  Click
+  <img src="/icon.svg" alt="icon" />
+  <a href="/home">
+    <img src="/icon.svg" alt="icon" />
+  </a>
+</p>
+<figure>
+  <img src="/icon.svg" alt="icon" />
+  <figcaption>
+    An icon
+  </figcaption>
+</figure>`, Render(doc))
+
+	assert.Empty(t, Validate([]byte(`[{"type": "paragraph", "children": [
+		{"type": "text", "text": "Click "},
+		{"type": "image", "image": {"url": "/icon.svg"}},
+		{"type": "link", "url": "/home", "children": [{"type": "image", "image": {"url": "/icon.svg"}}]}
+	]}]`)))
+}
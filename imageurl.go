@@ -0,0 +1,98 @@
+package blocks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// ImageURLBuilder returns the url an image is served from, for example a
+// resized and signed url of an image proxy.
+type ImageURLBuilder interface {
+	ImageURL(img Image) string
+}
+
+type ImageURLBuilderFunc func(img Image) string
+
+func (f ImageURLBuilderFunc) ImageURL(img Image) string {
+	return f(img)
+}
+
+// WithImageURLBuilder rewrites the src of all images.
+func WithImageURLBuilder(b ImageURLBuilder) Option {
+	return func(r *Renderer) {
+		r.imageURLBuilder = b
+	}
+}
+
+// ImgproxyURLBuilder builds imgproxy urls. Without a key the urls are
+// unsigned, which imgproxy only accepts when signing is disabled.
+type ImgproxyURLBuilder struct {
+	BaseURL string
+	// Key and Salt are the decoded values of IMGPROXY_KEY and IMGPROXY_SALT
+	Key  []byte
+	Salt []byte
+	// ResizingType is one of fit, fill, fill-down, force or auto, defaults to fit
+	ResizingType string
+	// Width and Height of the result, zero keeps the aspect ratio
+	Width  int
+	Height int
+	// Extension sets the output format, for example webp
+	Extension string
+}
+
+func (b ImgproxyURLBuilder) ImageURL(img Image) string {
+	resizingType := b.ResizingType
+	if resizingType == "" {
+		resizingType = "fit"
+	}
+	path := fmt.Sprintf("/rs:%s:%d:%d/%s", resizingType, b.Width, b.Height, base64.RawURLEncoding.EncodeToString([]byte(img.URL)))
+	if b.Extension != "" {
+		path += "." + b.Extension
+	}
+
+	signature := "insecure"
+	if len(b.Key) > 0 {
+		mac := hmac.New(sha256.New, b.Key)
+		mac.Write(b.Salt)
+		mac.Write([]byte(path))
+		signature = base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	}
+	return strings.TrimSuffix(b.BaseURL, "/") + "/" + signature + path
+}
+
+// ThumborURLBuilder builds thumbor urls. Without a key the urls are unsafe,
+// which thumbor only accepts with ALLOW_UNSAFE_URL.
+type ThumborURLBuilder struct {
+	BaseURL string
+	// Key is the SECURITY_KEY of the thumbor server
+	Key []byte
+	// Width and Height of the result, zero keeps the aspect ratio
+	Width  int
+	Height int
+	FitIn  bool
+	Smart  bool
+}
+
+func (b ThumborURLBuilder) ImageURL(img Image) string {
+	path := strings.Builder{}
+	if b.FitIn {
+		path.WriteString("fit-in/")
+	}
+	fmt.Fprintf(&path, "%dx%d/", b.Width, b.Height)
+	if b.Smart {
+		path.WriteString("smart/")
+	}
+	path.WriteString(img.URL)
+
+	signature := "unsafe"
+	if len(b.Key) > 0 {
+		mac := hmac.New(sha1.New, b.Key)
+		mac.Write([]byte(path.String()))
+		signature = base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	}
+	return strings.TrimSuffix(b.BaseURL, "/") + "/" + signature + "/" + path.String()
+}
@@ -0,0 +1,46 @@
+package blocks
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImgproxyURLBuilder(t *testing.T) {
+
+	key, _ := hex.DecodeString("943b421c9eb07c830af81030552c86009268de4e532ba2ee2eab8247c6da0881")
+	salt, _ := hex.DecodeString("520f986b998545b4785e0defbc4f3c1203f22de2374a3d53cb7a7fe9fea309c5")
+
+	b := ImgproxyURLBuilder{
+		BaseURL: "https://imgproxy.example.com/",
+		Key:     key,
+		Salt:    salt,
+		Width:   300,
+	}
+	img := Image{URL: "http://img.example.com/pretty/image.jpg"}
+
+	assert.Equal(t, "https://imgproxy.example.com/YRRYEm0s8ccBn8b28gYkIZlJ1AC-duZ4QJWY6LKcpX4/rs:fit:300:0/aHR0cDovL2ltZy5leGFtcGxlLmNvbS9wcmV0dHkvaW1hZ2UuanBn", b.ImageURL(img))
+
+	b.Key = nil
+	b.Extension = "webp"
+	assert.Equal(t, "https://imgproxy.example.com/insecure/rs:fit:300:0/aHR0cDovL2ltZy5leGFtcGxlLmNvbS9wcmV0dHkvaW1hZ2UuanBn.webp", b.ImageURL(img))
+}
+
+func TestThumborURLBuilder(t *testing.T) {
+
+	b := ThumborURLBuilder{
+		BaseURL: "https://thumbor.example.com",
+		Key:     []byte("MY_SECURE_KEY"),
+		Width:   300,
+		Height:  200,
+		Smart:   true,
+	}
+	img := Image{URL: "my.server.com/some/path/to/image.jpg"}
+
+	assert.Equal(t, "https://thumbor.example.com/OHHMqHwGrH1gubkMMveC8Ireg7A=/300x200/smart/my.server.com/some/path/to/image.jpg", b.ImageURL(img))
+
+	r := New(WithImageURLBuilder(ThumborURLBuilder{BaseURL: "https://thumbor.example.com", Width: 100}))
+	out := r.Render([]Block{{Type: BlockTypeImage, Image: &img}})
+	assert.Equal(t, `<img src="https://thumbor.example.com/unsafe/100x0/my.server.com/some/path/to/image.jpg" alt="" />`, out)
+}
@@ -0,0 +1,138 @@
+package blocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits protect against adversarial payloads. Zero values disable a limit.
+type Limits struct {
+	// MaxDepth is the maximum nesting of blocks, top level blocks have depth 1
+	MaxDepth int
+	// MaxBlocks is the maximum number of blocks in the whole document
+	MaxBlocks int
+	// MaxTextLength is the maximum number of characters of a single text
+	MaxTextLength int
+}
+
+var DefaultLimits = Limits{
+	MaxDepth:      32,
+	MaxBlocks:     100_000,
+	MaxTextLength: 1 << 20,
+}
+
+var ErrLimitExceeded = errors.New("blocks: limit exceeded")
+
+// LimitError reports which limit is exceeded by the block at Path.
+type LimitError struct {
+	Limit string
+	Max   int
+	Path  []int
+}
+
+func (e *LimitError) Error() string {
+	if e.Path == nil {
+		return fmt.Sprintf("blocks: document exceeds the %s of %d", e.Limit, e.Max)
+	}
+	return fmt.Sprintf("blocks: block %v exceeds the %s of %d", e.Path, e.Limit, e.Max)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// WithLimits makes renders fail on documents exceeding the limits. Render
+// returns an empty string and records a warning, RenderTo returns a
+// LimitError.
+func WithLimits(l Limits) Option {
+	return func(r *Renderer) {
+		r.limits = l
+	}
+}
+
+// checkLimits records a warning if the limits are exceeded
+func (r *Renderer) checkLimits(blocks []Block) error {
+	err := r.limits.Check(blocks)
+	if err != nil {
+		r.warn(WarningLimitExceeded, "%s", err)
+	}
+	return err
+}
+
+// Check returns a LimitError for the first block exceeding the limits.
+func (l Limits) Check(blocks []Block) error {
+	count := 0
+	return l.check(blocks, nil, &count)
+}
+
+func (l Limits) check(blocks []Block, parent []int, count *int) error {
+	for i, b := range blocks {
+		if err := l.checkBlock(b, append(append([]int{}, parent...), i), count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBlock checks the block at path and its children, count sums up the
+// blocks checked so far.
+func (l Limits) checkBlock(b Block, path []int, count *int) error {
+	*count++
+	if l.MaxBlocks > 0 && *count > l.MaxBlocks {
+		return &LimitError{Limit: "max blocks", Max: l.MaxBlocks, Path: path}
+	}
+	if l.MaxDepth > 0 && len(path) > l.MaxDepth {
+		return &LimitError{Limit: "max depth", Max: l.MaxDepth, Path: path}
+	}
+	if l.MaxTextLength > 0 && b.Text != nil && utf8.RuneCountInString(*b.Text) > l.MaxTextLength {
+		return &LimitError{Limit: "max text length", Max: l.MaxTextLength, Path: path}
+	}
+	return l.check(b.Children, path, count)
+}
+
+// ParseLimited is Parse enforcing the limits. The nesting is checked before
+// the blocks are decoded.
+func ParseLimited(raw []byte, l Limits) ([]Block, error) {
+	if l.MaxDepth > 0 {
+		if err := checkNesting(raw, l.MaxDepth, 0); err != nil {
+			return nil, err
+		}
+	}
+	blocks, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Check(blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// checkNesting checks the nesting of the json for blocks nested in outer
+// levels of arrays and objects.
+func checkNesting(raw []byte, maxDepth, outer int) error {
+	// every block level nests an array and an object, images add up to three
+	// levels for their formats
+	max := outer + 2*maxDepth + 3
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	depth := 0
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			// syntax errors are reported by Parse
+			return nil
+		}
+		switch t {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+			if depth > max {
+				return &LimitError{Limit: "max depth", Max: maxDepth}
+			}
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package blocks
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nested(depth int) []Block {
+	b := Block{Type: BlockTypeText, Text: ptr("deep")}
+	for range depth - 1 {
+		b = Block{Type: BlockTypeQuote, Children: []Block{b}}
+	}
+	return []Block{b}
+}
+
+func TestLimits_Check(t *testing.T) {
+
+	l := Limits{MaxDepth: 3, MaxBlocks: 4, MaxTextLength: 5}
+	assert.NoError(t, l.Check(nested(3)))
+
+	err := l.Check(nested(4))
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, &LimitError{Limit: "max depth", Max: 3, Path: []int{0, 0, 0, 0}}, err)
+	assert.EqualError(t, err, "blocks: block [0 0 0 0] exceeds the max depth of 3")
+
+	err = l.Check([]Block{paragraph("a", "b"), paragraph("c")})
+	assert.Equal(t, &LimitError{Limit: "max blocks", Max: 4, Path: []int{1, 0}}, err)
+
+	err = l.Check([]Block{paragraph("äöüäöü")})
+	assert.Equal(t, &LimitError{Limit: "max text length", Max: 5, Path: []int{0, 0}}, err)
+
+	assert.NoError(t, Limits{}.Check(nested(100)))
+}
+
+func TestParseLimited(t *testing.T) {
+
+	raw := strings.Repeat(`{"type":"quote","children":[`, 50) + `{"type":"text","text":"deep"}` + strings.Repeat(`]}`, 50)
+	_, err := ParseLimited([]byte("["+raw+"]"), DefaultLimits)
+	assert.EqualError(t, err, "blocks: document exceeds the max depth of 32")
+
+	blocks, err := ParseLimited(testInput, DefaultLimits)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, blocks)
+
+	_, err = ParseLimited([]byte(`[{"type":`), DefaultLimits)
+	assert.Error(t, err)
+}
+
+func TestWithLimits(t *testing.T) {
+
+	r := New(WithLimits(Limits{MaxDepth: 2}))
+	assert.Equal(t, "", r.Render(nested(3)))
+	assert.Equal(t, WarningLimitExceeded, r.Warnings()[0].Code)
+
+	out := bytes.Buffer{}
+	assert.ErrorIs(t, r.RenderTo(&out, nested(3)), ErrLimitExceeded)
+	assert.Empty(t, out.String())
+
+	assert.NoError(t, r.RenderTo(&out, nested(2)))
+	assert.Equal(t, "<blockquote>\n  deep\n</blockquote>", out.String())
+}
+
+func TestWithLimits_Partial(t *testing.T) {
+	r := New(WithLimits(Limits{MaxBlocks: 1}))
+	assert.Nil(t, r.RenderNodes(nested(2)))
+	assert.Equal(t, "", r.RenderRange(nested(2), 0, 1))
+	assert.Equal(t, "", r.RenderAt(nested(2), []int{0}))
+	assert.Equal(t, WarningLimitExceeded, r.Warnings()[0].Code)
+}
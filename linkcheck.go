@@ -0,0 +1,195 @@
+package blocks
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Link is a link of a document, the path points to the link block, see Walk.
+type Link struct {
+	URL  string
+	Text string
+	Path []int
+}
+
+// ExtractLinks returns the links of the document in document order.
+func ExtractLinks(blocks []Block) []Link {
+	links := []Link{}
+	Walk(blocks, func(path []int, b Block) bool {
+		if b.Type == BlockTypeLink && b.URL != nil {
+			links = append(links, Link{URL: *b.URL, Text: strings.TrimSpace(b.PlainText()), Path: path})
+		}
+		return true
+	})
+	return links
+}
+
+// CheckOptions configures CheckLinks and CheckMedia.
+type CheckOptions struct {
+	// Client defaults to http.DefaultClient, its redirect policy is replaced
+	Client *http.Client
+	// Timeout per request, defaults to 10 seconds
+	Timeout time.Duration
+	// Concurrency is the number of parallel requests, defaults to 8
+	Concurrency int
+	// BaseURL resolves relative urls, without it relative urls are not
+	// checked
+	BaseURL string
+	// CompareTypes reports media served with another content type than the
+	// mime type of the upload, for CheckMedia
+	CompareTypes bool
+}
+
+func (o CheckOptions) withDefaults() CheckOptions {
+	if o.Client == nil {
+		o.Client = http.DefaultClient
+	}
+	client := *o.Client
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	o.Client = &client
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Second
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 8
+	}
+	return o
+}
+
+// LinkResult is a dead or redirecting link.
+type LinkResult struct {
+	Link
+	// StatusCode is the status of the response, 0 if the request failed
+	StatusCode int
+	// Location is the target of redirects
+	Location string
+	Err      error
+}
+
+// LinkReport lists the problems of the links, in document order.
+type LinkReport struct {
+	Dead       []LinkResult
+	Redirected []LinkResult
+}
+
+// CheckLinks requests every http(s) link with HEAD, falling back to GET for
+// servers not supporting HEAD, for pre-publish validation jobs. Links failing
+// or answering with an error status are dead, links answering with a
+// redirect are reported with the target. Every url is requested once.
+func CheckLinks(ctx context.Context, links []Link, opts CheckOptions) LinkReport {
+	opts = opts.withDefaults()
+	urls := make([]string, len(links))
+	for i, l := range links {
+		urls[i] = opts.resolve(l.URL)
+	}
+	probes := opts.probeAll(ctx, urls, false)
+
+	report := LinkReport{}
+	for i, l := range links {
+		p, ok := probes[urls[i]]
+		if !ok {
+			continue
+		}
+		result := LinkResult{Link: l, StatusCode: p.status, Location: p.location, Err: p.err}
+		switch {
+		case p.dead():
+			report.Dead = append(report.Dead, result)
+		case p.status >= 300 && p.status < 400:
+			report.Redirected = append(report.Redirected, result)
+		}
+	}
+	return report
+}
+
+// probe is the response to a check request
+type probe struct {
+	status      int
+	location    string
+	contentType string
+	err         error
+}
+
+func (p probe) dead() bool {
+	return p.err != nil || p.status >= 400
+}
+
+// resolve returns the absolute http(s) url, or nothing for urls which are
+// not checked
+func (o CheckOptions) resolve(link string) string {
+	if strings.HasPrefix(link, "#") {
+		return ""
+	}
+	resolved, err := resolveURL(o.BaseURL, link)
+	if err != nil {
+		return ""
+	}
+	if scheme := linkScheme(resolved); scheme != "http" && scheme != "https" {
+		return ""
+	}
+	return resolved
+}
+
+// probeAll requests the urls with at most Concurrency requests at a time,
+// empty urls are skipped. With follow redirects are followed.
+func (o CheckOptions) probeAll(ctx context.Context, urls []string, follow bool) map[string]probe {
+	unique := []string{}
+	for _, u := range urls {
+		if u != "" && !slices.Contains(unique, u) {
+			unique = append(unique, u)
+		}
+	}
+	probes := map[string]probe{}
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, o.Concurrency)
+	for _, u := range unique {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			p := o.probe(ctx, u)
+			for target, hops := u, 0; follow && hops < 10 && p.status >= 300 && p.status < 400; hops++ {
+				next, err := resolveURL(target, p.location)
+				if err != nil || p.location == "" {
+					break
+				}
+				target, p = next, o.probe(ctx, next)
+			}
+			mu.Lock()
+			probes[u] = p
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return probes
+}
+
+func (o CheckOptions) probe(ctx context.Context, u string) probe {
+	p := o.request(ctx, http.MethodHead, u)
+	if p.status == http.StatusMethodNotAllowed || p.status == http.StatusNotImplemented {
+		return o.request(ctx, http.MethodGet, u)
+	}
+	return p
+}
+
+func (o CheckOptions) request(ctx context.Context, method, u string) probe {
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return probe{err: err}
+	}
+	res, err := o.Client.Do(req)
+	if err != nil {
+		return probe{err: err}
+	}
+	res.Body.Close()
+	return probe{status: res.StatusCode, location: res.Header.Get("Location"), contentType: res.Header.Get("Content-Type")}
+}
@@ -0,0 +1,63 @@
+package blocks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLinks(t *testing.T) {
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/ok":
+		case "/get-only":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			link("/ok", "ok"),
+			link("/moved", "moved"),
+			link("#top", "top"),
+			link("mailto:a@example.com", "mail"),
+		}},
+		{Type: BlockTypeList, Format: ptr("unordered"), Children: []Block{
+			{Type: BlockTypeListItem, Children: []Block{link(srv.URL+"/gone", "gone"), link("/get-only", "get")}},
+			{Type: BlockTypeListItem, Children: []Block{link("/gone", "gone again")}},
+		}},
+	}
+	links := ExtractLinks(doc)
+	assert.Len(t, links, 7)
+	assert.Equal(t, Link{URL: "/moved", Text: "moved", Path: []int{0, 1}}, links[1])
+
+	report := CheckLinks(context.Background(), links, CheckOptions{BaseURL: srv.URL, Concurrency: 2})
+	assert.Len(t, report.Dead, 2)
+	assert.Equal(t, []int{1, 0, 0}, report.Dead[0].Path)
+	assert.Equal(t, http.StatusNotFound, report.Dead[0].StatusCode)
+	assert.Equal(t, []int{1, 1, 0}, report.Dead[1].Path)
+	assert.Equal(t, []LinkResult{{Link: links[1], StatusCode: http.StatusMovedPermanently, Location: "/ok"}}, report.Redirected)
+	assert.Equal(t, int32(5), requests.Load(), "every url is requested once, HEAD is retried with GET")
+}
+
+func TestCheckLinksWithoutServer(t *testing.T) {
+
+	links := []Link{{URL: "http://127.0.0.1:1/", Path: []int{0}}, {URL: "/relative", Path: []int{1}}}
+	report := CheckLinks(context.Background(), links, CheckOptions{})
+	assert.Len(t, report.Dead, 1)
+	assert.Error(t, report.Dead[0].Err)
+}
@@ -0,0 +1,83 @@
+package blocks
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// LinkPolicy decides the attributes of links by their kind.
+type LinkPolicy struct {
+	// ExternalTarget is the target of external links, for example "_blank".
+	// Links are external when they are absolute http(s) urls to a host not in
+	// InternalHosts.
+	ExternalTarget string
+	// ExternalRel is the rel of external links, it defaults to
+	// "noopener noreferrer" for links with a target.
+	ExternalRel   string
+	InternalHosts []string
+	// ObfuscateEmails encodes the address of mailto links as html entities,
+	// in the href and in the link text, against simple scrapers.
+	ObfuscateEmails bool
+}
+
+// WithLinkPolicy applies the policy to every link. mailto: and tel: links
+// never get a target, they open the mail or phone app.
+func WithLinkPolicy(p LinkPolicy) Option {
+	if p.ExternalRel == "" && p.ExternalTarget != "" {
+		p.ExternalRel = "noopener noreferrer"
+	}
+	return func(r *Renderer) {
+		r.linkPolicy = &p
+	}
+}
+
+// linkScheme returns the lower case scheme of the link, or nothing for
+// relative links
+func linkScheme(link string) string {
+	scheme, _, ok := strings.Cut(link, ":")
+	if !ok || strings.ContainsAny(scheme, "/?#") {
+		return ""
+	}
+	return strings.ToLower(scheme)
+}
+
+// policyAttrs returns the attributes the link policy adds to the link
+func (r *Renderer) policyAttrs(link string) AttributeList {
+	if r.linkPolicy == nil || !isExternal(link, r.linkPolicy.InternalHosts) {
+		return nil
+	}
+	attrs := AttributeList{}
+	if r.linkPolicy.ExternalTarget != "" {
+		attrs = append(attrs, Attribute{Name: "target", Value: r.linkPolicy.ExternalTarget})
+	}
+	if r.linkPolicy.ExternalRel != "" {
+		attrs = append(attrs, Attribute{Name: "rel", Value: r.linkPolicy.ExternalRel})
+	}
+	return attrs
+}
+
+// obfuscateEmail encodes the address of a mailto link in the link and in the
+// rendered content of the link.
+func (r *Renderer) obfuscateEmail(link, content string) (string, string) {
+	if r.linkPolicy == nil || !r.linkPolicy.ObfuscateEmails || linkScheme(link) != "mailto" {
+		return link, content
+	}
+	address, _, _ := strings.Cut(link[len("mailto:"):], "?")
+	if unescaped, err := url.PathUnescape(address); err == nil {
+		address = unescaped
+	}
+	if address == "" {
+		return link, content
+	}
+	return entities(link), strings.ReplaceAll(content, address, entities(address))
+}
+
+// entities encodes every character as numeric html entity
+func entities(s string) string {
+	out := strings.Builder{}
+	for _, c := range s {
+		fmt.Fprintf(&out, "&#%d;", c)
+	}
+	return out.String()
+}
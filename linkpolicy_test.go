@@ -0,0 +1,54 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLinkPolicy(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeList, Format: ptr("unordered"), Children: []Block{
+		{Type: BlockTypeListItem, Children: []Block{link("https://example.com", "external")}},
+		{Type: BlockTypeListItem, Children: []Block{link("https://www.example.org/about", "internal")}},
+		{Type: BlockTypeListItem, Children: []Block{link("/about", "relative")}},
+		{Type: BlockTypeListItem, Children: []Block{link("tel:+49301234", "call")}},
+		{Type: BlockTypeListItem, Children: []Block{link("mailto:a@b.de?subject=Hi", "a@b.de")}},
+	}}}
+
+	r := New(WithLinkPolicy(LinkPolicy{ExternalTarget: "_blank", InternalHosts: []string{"www.example.org"}, ObfuscateEmails: true}))
+	assert.Equal(t, `<ul>
+  <li>
+    <a href="https://example.com" target="_blank" rel="noopener noreferrer">
+      external
+    </a>
+  </li>
+  <li>
+    <a href="https://www.example.org/about">
+      internal
+    </a>
+  </li>
+  <li>
+    <a href="/about">
+      relative
+    </a>
+  </li>
+  <li>
+    <a href="tel:+49301234">
+      call
+    </a>
+  </li>
+  <li>
+    <a href="&#109;&#97;&#105;&#108;&#116;&#111;&#58;&#97;&#64;&#98;&#46;&#100;&#101;&#63;&#115;&#117;&#98;&#106;&#101;&#99;&#116;&#61;&#72;&#105;">
+      &#97;&#64;&#98;&#46;&#100;&#101;
+    </a>
+  </li>
+</ul>`, r.Render(doc))
+}
+
+func TestLinkScheme(t *testing.T) {
+	assert.Equal(t, "mailto", linkScheme("MAILTO:a@b.de"))
+	assert.Equal(t, "tel", linkScheme("tel:+49"))
+	assert.Equal(t, "", linkScheme("/a:b"))
+	assert.Equal(t, "", linkScheme("about"))
+}
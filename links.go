@@ -0,0 +1,119 @@
+package blocks
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WithLinkRewriter rewrites the url of every link. The text is the plain
+// text of the link. Multiple rewriters run in the order they were added.
+func WithLinkRewriter(fn func(url, text string) string) Option {
+	return func(r *Renderer) {
+		r.linkRewriters = append(r.linkRewriters, fn)
+	}
+}
+
+// UTM holds the analytics parameters appended by UTMRewriter, empty values
+// are omitted.
+type UTM struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Term     string
+	Content  string
+}
+
+// UTMRewriter returns a link rewriter appending the UTM parameters to
+// external links. Relative links and links to one of the internal hosts are
+// kept as is, as are parameters already present on the link.
+func UTMRewriter(utm UTM, internalHosts ...string) func(url, text string) string {
+	params := [][2]string{
+		{"utm_source", utm.Source},
+		{"utm_medium", utm.Medium},
+		{"utm_campaign", utm.Campaign},
+		{"utm_term", utm.Term},
+		{"utm_content", utm.Content},
+	}
+	return func(link, text string) string {
+		if !isExternal(link, internalHosts) {
+			return link
+		}
+		u, err := url.Parse(link)
+		if err != nil {
+			return link
+		}
+		query := u.Query()
+		for _, p := range params {
+			if p[1] != "" && !query.Has(p[0]) {
+				query.Set(p[0], p[1])
+			}
+		}
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+}
+
+// isExternal reports whether the link is an absolute http(s) url to a host
+// not in the list of internal hosts.
+func isExternal(link string, internalHosts []string) bool {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	for _, h := range internalHosts {
+		if strings.EqualFold(u.Hostname(), h) {
+			return false
+		}
+	}
+	return true
+}
+
+// linkURL resolves the link and applies the rewriters
+func (r *Renderer) linkURL(link, text string) string {
+	url := r.resolveLink(link)
+	for _, rewrite := range r.linkRewriters {
+		url = rewrite(url, text)
+	}
+	return url
+}
+
+// ResolvedLink is a link block as the renderer emits it, built by the
+// backends of BuildWith.
+type ResolvedLink struct {
+	// URL is the resolved and rewritten url of the link, Placeholders.BrokenLink
+	// for links without url
+	URL string
+	// Href is the url the link points to, the tracking redirect of outbound
+	// links with WithLinkAnalytics
+	Href  string
+	Title string
+	// Attributes are those of the link policy, the link analytics and
+	// WithAccessibility
+	Attributes AttributeList
+	// Printed is the url written after the link text with WithPrint
+	Printed string
+}
+
+// resolveLinkBlock makes the decisions RenderLink and the backends share and
+// records the warning of links without url.
+func (r *Renderer) resolveLinkBlock(b Block) ResolvedLink {
+	text := b.PlainText()
+	link := ResolvedLink{URL: r.placeholders.BrokenLink, Title: deref(b.Title)}
+	if b.URL != nil {
+		if link.Title == "" {
+			link.Title = r.linkTitle(*b.URL)
+		}
+		link.URL = r.linkURL(*b.URL, strings.TrimSpace(text))
+	} else {
+		r.warn(WarningBrokenLink, "link without url")
+	}
+	href, analytics := r.outbound(link.URL, link.URL)
+	link.Href = href
+	link.Attributes = append(link.Attributes, r.policyAttrs(link.URL)...)
+	link.Attributes = append(link.Attributes, analytics...)
+	link.Attributes = append(link.Attributes, r.ariaLink(link.URL, text)...)
+	if r.print {
+		link.Printed = printedURL(link.URL, text)
+	}
+	return link
+}
@@ -0,0 +1,30 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func link(url, text string) Block {
+	return Block{Type: BlockTypeLink, URL: &url, Children: []Block{{Type: BlockTypeText, Text: &text}}}
+}
+
+func TestWithLinkRewriter(t *testing.T) {
+
+	r := New(WithLinkRewriter(func(url, text string) string {
+		return url + "#" + text
+	}))
+	assert.Equal(t, "<a href=\"/about#about us\">\n  about us\n</a>", r.Render([]Block{link("/about", "about us")}))
+}
+
+func TestUTMRewriter(t *testing.T) {
+
+	rewrite := UTMRewriter(UTM{Source: "blog", Medium: "web"}, "example.com")
+
+	assert.Equal(t, "https://other.com/page?utm_medium=web&utm_source=blog", rewrite("https://other.com/page", ""))
+	assert.Equal(t, "https://other.com/?a=1&utm_medium=web&utm_source=mail", rewrite("https://other.com/?a=1&utm_source=mail", ""))
+	assert.Equal(t, "https://EXAMPLE.com/page", rewrite("https://EXAMPLE.com/page", ""))
+	assert.Equal(t, "/relative", rewrite("/relative", ""))
+	assert.Equal(t, "mailto:a@example.com", rewrite("mailto:a@example.com", ""))
+}
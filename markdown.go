@@ -0,0 +1,165 @@
+package blocks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// lineMarker matches the markers of headings, lists, quotes and breaks at the
+// start of a line
+var lineMarker = regexp.MustCompile(`(?m)^( {0,3})([#>+-]|[0-9]{1,9}[.)])`)
+
+// escapeLineMarkers escapes the line markers of the text, so it is not read
+// as heading, list or quote
+func escapeLineMarkers(text string) string {
+	return lineMarker.ReplaceAllStringFunc(text, func(m string) string {
+		return m[:len(m)-1] + `\` + m[len(m)-1:]
+	})
+}
+
+// Markdown renders the blocks as CommonMark. Underlined, superscript,
+// subscript, keyboard and highlighted texts have no markdown equivalent and
+// are emitted as inline html. Annotations are left out, like in the html
+// output without WithAnnotations.
+func Markdown(blocks []Block) string {
+	parts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		if b.Type == BlockTypeAnnotation {
+			continue
+		}
+		if md := markdownBlock(b); md != "" {
+			parts = append(parts, md)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+func markdownBlock(b Block) string {
+	switch b.Type {
+	case BlockTypeParagraph:
+		return escapeLineMarkers(markdownInline(b.Children))
+	case BlockTypeHeading:
+		level := 1
+		if b.Level != nil && *b.Level > 1 {
+			level = min(*b.Level, 6)
+		}
+		return strings.Repeat("#", level) + " " + strings.TrimSpace(markdownInline(b.Children))
+	case BlockTypeList:
+		return markdownList(b, "")
+	case BlockTypeQuote:
+		lines := strings.Split(escapeLineMarkers(markdownInline(b.Children)), "\n")
+		for i, l := range lines {
+			lines[i] = "> " + l
+		}
+		return strings.Join(lines, "\n")
+	case BlockTypeCode:
+		return "```\n" + b.PlainText() + "\n```"
+	case BlockTypeImage:
+		return markdownImage(b)
+	}
+	return escapeLineMarkers(markdownInline(b.Children))
+}
+
+func markdownList(b Block, indent string) string {
+	lines := []string{}
+	marker := "- "
+	n := 0
+	for _, c := range b.Children {
+		if c.Type == BlockTypeList {
+			lines = append(lines, markdownList(c, indent+strings.Repeat(" ", len(marker))))
+			continue
+		}
+		n++
+		if b.Format != nil && *b.Format == string(ListFormatOrdered) {
+			marker = fmt.Sprintf("%d. ", n)
+		}
+		lines = append(lines, indent+marker+escapeLineMarkers(strings.TrimSpace(markdownInline(c.Children))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func markdownInline(children []Block) string {
+	out := strings.Builder{}
+	for _, c := range children {
+		switch c.Type {
+		case BlockTypeAnnotation:
+		case BlockTypeText:
+			out.WriteString(markdownText(c))
+		case BlockTypeLink:
+			url := "#"
+			if c.URL != nil {
+				url = *c.URL
+			}
+			fmt.Fprintf(&out, "[%s](%s)", markdownInline(c.Children), url)
+		case BlockTypeImage:
+			out.WriteString(markdownImage(c))
+		default:
+			out.WriteString(markdownInline(c.Children))
+		}
+	}
+	return out.String()
+}
+
+func markdownText(b Block) string {
+	if b.Text == nil {
+		return ""
+	}
+	text := *b.Text
+	core := strings.TrimSpace(text)
+	if core == "" {
+		return text
+	}
+	start := strings.Index(text, core)
+	lead, trail := text[:start], text[start+len(core):]
+
+	if b.Code != nil && *b.Code {
+		core = "`" + core + "`"
+	} else {
+		core = markdownEscaper.Replace(core)
+	}
+	if b.Underline != nil && *b.Underline {
+		core = "<u>" + core + "</u>"
+	}
+	if b.Superscript != nil && *b.Superscript {
+		core = "<sup>" + core + "</sup>"
+	}
+	if b.Subscript != nil && *b.Subscript {
+		core = "<sub>" + core + "</sub>"
+	}
+	if b.HasModifier(ModifierKeyboard) {
+		core = "<kbd>" + core + "</kbd>"
+	}
+	if b.HasModifier(ModifierHighlight) {
+		core = "<mark>" + core + "</mark>"
+	}
+	if b.StrikeThrough != nil && *b.StrikeThrough {
+		core = "~~" + core + "~~"
+	}
+	if b.Italic != nil && *b.Italic {
+		core = "_" + core + "_"
+	}
+	if b.Bold != nil && *b.Bold {
+		core = "**" + core + "**"
+	}
+	return lead + core + trail
+}
+
+func markdownImage(b Block) string {
+	if b.Image == nil {
+		return ""
+	}
+	return fmt.Sprintf("![%s](%s)", markdownEscaper.Replace(b.Image.AlternativeText), b.Image.URL)
+}
@@ -0,0 +1,34 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+)
+
+// WithMathRenderer renders math server side, for example with KaTeX. The
+// function receives the TeX source and returns html. If it fails, the KaTeX
+// compatible markup for client side rendering is emitted.
+func WithMathRenderer(fn func(tex string, display bool) (string, error)) Option {
+	return func(r *Renderer) {
+		r.mathRenderer = fn
+	}
+}
+
+func (r *Renderer) RenderMath(b Block) string {
+	return r.math(b.PlainText(), true)
+}
+
+// math renders tex with the delimiters of the KaTeX auto-render extension
+func (r *Renderer) math(tex string, display bool) string {
+	if r.mathRenderer != nil {
+		out, err := r.mathRenderer(tex, display)
+		if err == nil {
+			return r.withNonce(out)
+		}
+		r.warn(WarningMathError, "cannot render math %q: %s", tex, err)
+	}
+	if display {
+		return fmt.Sprintf(`<div class="math display"%s>\[%s\]</div>`, r.ariaRole("math", tex), html.EscapeString(tex))
+	}
+	return fmt.Sprintf(`<span class="math inline"%s>\(%s\)</span>`, r.ariaRole("math", tex), html.EscapeString(tex))
+}
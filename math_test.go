@@ -0,0 +1,46 @@
+package blocks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMath(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("where ")},
+			{Type: BlockTypeText, Text: ptr("a < b"), Math: ptr(true)},
+		}},
+		{Type: BlockTypeMath, Children: []Block{{Type: BlockTypeText, Text: ptr(`e^{i\pi} + 1 = 0`)}}},
+	}
+
+	assert.Equal(t, `<p>
+  where
+  <span class="math inline">
+    \(a &lt; b\)
+  </span>
+</p>
+<div class="math display">
+  \[e^{i\pi} + 1 = 0\]
+</div>`, New().Render(doc))
+
+	r := New(WithMathRenderer(func(tex string, display bool) (string, error) {
+		if display {
+			return "", errors.New("katex unavailable")
+		}
+		return "<katex>" + tex + "</katex>", nil
+	}))
+	assert.Equal(t, `<p>
+  where
+  <katex>
+    a < b
+  </katex>
+</p>
+<div class="math display">
+  \[e^{i\pi} + 1 = 0\]
+</div>`, r.Render(doc))
+	assert.Equal(t, []Warning{{Code: WarningMathError, Message: `cannot render math "e^{i\\pi} + 1 = 0": katex unavailable`, Path: []int{1}}}, r.Warnings())
+}
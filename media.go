@@ -0,0 +1,121 @@
+package blocks
+
+import (
+	"context"
+	"mime"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Media is an upload referenced by a document, the path points to the
+// referencing block, see Walk.
+type Media struct {
+	URL string
+	// Mime is the type strapi recorded for the upload, empty for files
+	// linked from the text
+	Mime string
+	Path []int
+}
+
+// ExtractMedia returns the uploads of the document in document order, the
+// images and gallery images with their formats, previews and dark variants,
+// and links to files below /uploads/.
+func ExtractMedia(blocks []Block) []Media {
+	media := []Media{}
+	add := func(path []int, u, mime string) {
+		if u != "" && !strings.HasPrefix(u, "data:") {
+			media = append(media, Media{URL: u, Mime: mime, Path: path})
+		}
+	}
+	var image func(path []int, img Image)
+	image = func(path []int, img Image) {
+		add(path, img.URL, img.Mime)
+		add(path, img.PreviewURL, "")
+		names := make([]string, 0, len(img.Formats))
+		for name := range img.Formats {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			add(path, img.Formats[name].URL, img.Formats[name].Mime)
+		}
+		if img.Dark != nil {
+			image(path, *img.Dark)
+		}
+	}
+	Walk(blocks, func(path []int, b Block) bool {
+		if b.Image != nil {
+			image(path, *b.Image)
+		}
+		for _, img := range b.Images {
+			image(path, img)
+		}
+		if b.Type == BlockTypeLink && b.URL != nil && isUploadLink(*b.URL) {
+			add(path, *b.URL, "")
+		}
+		return true
+	})
+	return media
+}
+
+func isUploadLink(link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && strings.HasPrefix(u.Path, "/uploads/")
+}
+
+// MediaResult is a missing upload or an upload served with another type.
+type MediaResult struct {
+	Media
+	// StatusCode is the status of the response, 0 if the request failed
+	StatusCode int
+	// ContentType is the type the server answered with
+	ContentType string
+	Err         error
+}
+
+// MediaReport lists the problems of the uploads, in document order.
+type MediaReport struct {
+	Missing []MediaResult
+	// Mismatched are only reported with CompareTypes
+	Mismatched []MediaResult
+}
+
+// CheckMedia requests every upload like CheckLinks, but follows redirects
+// as uploads are commonly served through a cdn. Uploads failing or
+// answering with an error status are missing. With CompareTypes uploads
+// whose content type differs from the recorded mime type are mismatched.
+func CheckMedia(ctx context.Context, media []Media, opts CheckOptions) MediaReport {
+	opts = opts.withDefaults()
+	urls := make([]string, len(media))
+	for i, m := range media {
+		urls[i] = opts.resolve(m.URL)
+	}
+	probes := opts.probeAll(ctx, urls, true)
+
+	report := MediaReport{}
+	for i, m := range media {
+		p, ok := probes[urls[i]]
+		if !ok {
+			continue
+		}
+		result := MediaResult{Media: m, StatusCode: p.status, ContentType: p.contentType, Err: p.err}
+		switch {
+		case p.dead():
+			report.Missing = append(report.Missing, result)
+		case opts.CompareTypes && m.Mime != "" && !sameMediaType(m.Mime, p.contentType):
+			report.Mismatched = append(report.Mismatched, result)
+		}
+	}
+	return report
+}
+
+// sameMediaType compares the types ignoring parameters like the charset
+func sameMediaType(a, b string) bool {
+	ta, _, err := mime.ParseMediaType(a)
+	if err != nil {
+		return false
+	}
+	tb, _, err := mime.ParseMediaType(b)
+	return err == nil && ta == tb
+}
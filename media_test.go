@@ -0,0 +1,56 @@
+package blocks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMedia(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/uploads/a.jpg", "/cdn/a_small.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+		case "/uploads/a_small.jpg":
+			http.Redirect(w, r, "/cdn/a_small.jpg", http.StatusFound)
+		case "/uploads/b.png":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	doc := []Block{
+		{Type: BlockTypeImage, Image: &Image{URL: "/uploads/a.jpg", Mime: "image/jpeg", Formats: map[string]ImageFormat{
+			"small": {URL: "/uploads/a_small.jpg", Mime: "image/jpeg"},
+		}}},
+		{Type: BlockTypeGallery, Images: []Image{{URL: "/uploads/b.png", Mime: "image/png"}, {URL: "/uploads/gone.png", Mime: "image/png"}}},
+		{Type: BlockTypeParagraph, Children: []Block{
+			link("/uploads/manual.pdf", "manual"),
+			link("/about", "about"),
+		}},
+	}
+	media := ExtractMedia(doc)
+	assert.Equal(t, []Media{
+		{URL: "/uploads/a.jpg", Mime: "image/jpeg", Path: []int{0}},
+		{URL: "/uploads/a_small.jpg", Mime: "image/jpeg", Path: []int{0}},
+		{URL: "/uploads/b.png", Mime: "image/png", Path: []int{1}},
+		{URL: "/uploads/gone.png", Mime: "image/png", Path: []int{1}},
+		{URL: "/uploads/manual.pdf", Path: []int{2, 0}},
+	}, media)
+
+	report := CheckMedia(context.Background(), media, CheckOptions{BaseURL: srv.URL})
+	assert.Len(t, report.Missing, 2)
+	assert.Equal(t, "/uploads/gone.png", report.Missing[0].URL)
+	assert.Equal(t, http.StatusNotFound, report.Missing[1].StatusCode)
+	assert.Empty(t, report.Mismatched)
+
+	report = CheckMedia(context.Background(), media, CheckOptions{BaseURL: srv.URL, CompareTypes: true})
+	assert.Len(t, report.Missing, 2)
+	assert.Equal(t, []MediaResult{{Media: media[2], StatusCode: http.StatusOK, ContentType: "text/html; charset=utf-8"}}, report.Mismatched)
+}
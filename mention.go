@@ -0,0 +1,88 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Mention is the user referenced by a mention node of an editor plugin.
+type Mention struct {
+	ID       MentionID `json:"id"`
+	Username string    `json:"username"`
+	Name     string    `json:"name"`
+}
+
+// MentionID accepts numeric and string ids.
+type MentionID string
+
+func (id *MentionID) UnmarshalJSON(data []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err == nil {
+		*id = MentionID(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*id = MentionID(s)
+	return nil
+}
+
+// Label returns the username, or the name for plugins without usernames.
+func (m Mention) Label() string {
+	if m.Username != "" {
+		return m.Username
+	}
+	return m.Name
+}
+
+// MentionResolver returns the profile url of a mentioned user.
+type MentionResolver interface {
+	ResolveMention(m Mention) (string, bool)
+}
+
+type MentionResolverFunc func(m Mention) (string, bool)
+
+func (f MentionResolverFunc) ResolveMention(m Mention) (string, bool) {
+	return f(m)
+}
+
+// WithMentionResolver links mentions to the profiles of the users. Mentions
+// that cannot be resolved are rendered as styled text.
+func WithMentionResolver(res MentionResolver) Option {
+	return func(r *Renderer) {
+		r.mentionResolver = res
+	}
+}
+
+func (r *Renderer) RenderMention(b Block) string {
+	url, ok := r.mentionURL(b)
+	if !ok {
+		return r.internalRender(b.Children)
+	}
+	label := html.EscapeString("@" + strings.TrimPrefix(b.Mention.Label(), "@"))
+	if url != "" {
+		return fmt.Sprintf(`<a href=%q%s>%s</a>`, url, r.attrs(ElementMention), label)
+	}
+	return fmt.Sprintf(`<span%s>%s</span>`, r.attrs(ElementMention), label)
+}
+
+// mentionURL returns the profile url of the mentioned user, empty if the user
+// cannot be resolved. Mentions without user are recorded as warning.
+func (r *Renderer) mentionURL(b Block) (string, bool) {
+	if b.Mention == nil {
+		r.warn(WarningMissingMention, "mention block without user")
+		return "", false
+	}
+	if r.mentionResolver == nil {
+		return "", true
+	}
+	url, ok := r.mentionResolver.ResolveMention(*b.Mention)
+	if !ok {
+		return "", true
+	}
+	return url, true
+}
@@ -0,0 +1,49 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMention(t *testing.T) {
+
+	doc, err := Parse([]byte(`[{"type": "paragraph", "children": [
+		{"type": "text", "text": "thanks "},
+		{"type": "mention", "mention": {"id": 1, "username": "alice"}, "children": [{"type": "text", "text": ""}]},
+		{"type": "text", "text": " and "},
+		{"type": "mention", "mention": {"id": "2", "name": "Bob"}, "children": [{"type": "text", "text": ""}]}
+	]}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, MentionID("1"), doc[0].Children[1].Mention.ID)
+
+	assert.Equal(t, `<p>
+  thanks
+  <span class="mention">
+    @alice
+  </span>
+  and
+  <span class="mention">
+    @Bob
+  </span>
+</p>`, New().Render(doc))
+
+	r := New(
+		WithMentionResolver(MentionResolverFunc(func(m Mention) (string, bool) {
+			return "/users/" + m.Username, m.Username != ""
+		})),
+		WithClasses(Classes{ElementMention: "user"}),
+	)
+	assert.Equal(t, `<p>
+  thanks
+  <a href="/users/alice" class="user">
+    @alice
+  </a>
+  and
+  <span class="user">
+    @Bob
+  </span>
+</p>`, r.Render(doc))
+
+	assert.Equal(t, "thanks @alice and @Bob", PlainText(doc))
+}
@@ -0,0 +1,161 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+type StrapiVersion string
+
+const StrapiUnknown StrapiVersion = "unknown"
+const StrapiV4 StrapiVersion = "v4"
+const StrapiV5 StrapiVersion = "v5"
+
+// MigrationReport describes which strapi version most likely produced a
+// payload and what was changed to normalize it.
+type MigrationReport struct {
+	Version StrapiVersion
+	Changes []MigrationChange
+}
+
+// MigrationChange is a single normalization, Path is a JSON pointer into the
+// original payload.
+type MigrationChange struct {
+	Path        string
+	Description string
+}
+
+// legacy snake_case media fields and their current names
+var mediaRenames = map[string]string{
+	"alternative_text": "alternativeText",
+	"preview_url":      "previewUrl",
+	"created_at":       "createdAt",
+	"updated_at":       "updatedAt",
+	"published_at":     "publishedAt",
+	"document_id":      "documentId",
+	"size_in_bytes":    "sizeInBytes",
+}
+
+// Migrate detects whether a payload was produced by strapi v4 or v5 and
+// normalizes it into the canonical Block model. Media objects wrapped in the
+// v4 {data: {attributes: {}}} envelope are unwrapped and legacy field names
+// are renamed, for the images of image and gallery blocks and their dark
+// variants.
+func Migrate(raw []byte) ([]Block, MigrationReport, error) {
+	var doc []any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, MigrationReport{}, err
+	}
+	m := migration{report: MigrationReport{Version: StrapiUnknown}}
+	for i, b := range doc {
+		m.block(b, fmt.Sprintf("/%d", i))
+	}
+
+	normalized, err := json.Marshal(doc)
+	if err != nil {
+		return nil, m.report, err
+	}
+	blocks, err := Parse(normalized)
+	return blocks, m.report, err
+}
+
+type migration struct {
+	report MigrationReport
+}
+
+func (m *migration) change(path, format string, args ...any) {
+	m.report.Changes = append(m.report.Changes, MigrationChange{Path: path, Description: fmt.Sprintf(format, args...)})
+}
+
+func (m *migration) detected(v StrapiVersion) {
+	// v5 only markers win over the weaker v4 hints
+	if m.report.Version == StrapiUnknown || v == StrapiV5 {
+		m.report.Version = v
+	}
+}
+
+func (m *migration) block(v any, path string) {
+	b, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	if img, ok := b["image"]; ok && img != nil {
+		b["image"] = m.media(img, path+"/image")
+	}
+	if images, ok := b["images"]; ok && images != nil {
+		b["images"] = m.mediaList(images, path+"/images")
+	}
+	if children, ok := b["children"].([]any); ok {
+		for i, c := range children {
+			m.block(c, fmt.Sprintf("%s/children/%d", path, i))
+		}
+	}
+}
+
+func (m *migration) media(v any, path string) any {
+	media, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+
+	if data, ok := media["data"].(map[string]any); ok && len(media) == 1 {
+		m.change(path, "unwrapped media data envelope")
+		media = data
+	}
+	// the entries of v4 media lists are not wrapped in data
+	if attributes, ok := media["attributes"].(map[string]any); ok && len(media) <= 2 {
+		m.detected(StrapiV4)
+		m.change(path, "flattened media attributes")
+		if id, ok := media["id"]; ok {
+			attributes["id"] = id
+		}
+		media = attributes
+	}
+
+	keys := make([]string, 0, len(media))
+	for k := range media {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		renamed, ok := mediaRenames[k]
+		if !ok {
+			continue
+		}
+		if _, exists := media[renamed]; !exists {
+			media[renamed] = media[k]
+		}
+		delete(media, k)
+		m.change(path+"/"+k, "renamed to %s", renamed)
+	}
+
+	if _, ok := media["documentId"]; ok {
+		m.detected(StrapiV5)
+	} else if _, ok := media["id"]; ok {
+		m.detected(StrapiV4)
+	}
+	if dark, ok := media["dark"]; ok && dark != nil {
+		media["dark"] = m.media(dark, path+"/dark")
+	}
+	return media
+}
+
+// mediaList normalizes the images of gallery blocks, v4 wraps the whole list
+// in a {data: []} envelope
+func (m *migration) mediaList(v any, path string) any {
+	if envelope, ok := v.(map[string]any); ok && len(envelope) == 1 {
+		if data, ok := envelope["data"].([]any); ok {
+			m.change(path, "unwrapped media data envelope")
+			v, path = data, path+"/data"
+		}
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return v
+	}
+	for i, img := range list {
+		list[i] = m.media(img, fmt.Sprintf("%s/%d", path, i))
+	}
+	return list
+}
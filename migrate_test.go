@@ -0,0 +1,71 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+
+	v4 := []byte(`[{
+		"type": "image",
+		"image": {"data": {"id": 3, "attributes": {"name": "a.jpg", "alternative_text": "gopher", "url": "/uploads/a.jpg"}}},
+		"children": [{"type": "text", "text": ""}]
+	}]`)
+
+	blocks, report, err := Migrate(v4)
+	assert.NoError(t, err)
+	assert.Equal(t, StrapiV4, report.Version)
+	assert.Equal(t, []MigrationChange{
+		{Path: "/0/image", Description: "unwrapped media data envelope"},
+		{Path: "/0/image", Description: "flattened media attributes"},
+		{Path: "/0/image/alternative_text", Description: "renamed to alternativeText"},
+	}, report.Changes)
+	assert.Equal(t, &Image{Name: "a.jpg", AlternativeText: "gopher", URL: "/uploads/a.jpg"}, blocks[0].Image)
+
+	v5 := []byte(`[{
+		"type": "image",
+		"image": {"id": 3, "documentId": "abc", "name": "a.jpg", "alternativeText": "gopher", "url": "/uploads/a.jpg"},
+		"children": [{"type": "text", "text": ""}]
+	}]`)
+
+	blocks, report, err = Migrate(v5)
+	assert.NoError(t, err)
+	assert.Equal(t, StrapiV5, report.Version)
+	assert.Empty(t, report.Changes)
+	assert.Equal(t, "gopher", blocks[0].Image.AlternativeText)
+
+	_, report, err = Migrate(testInput)
+	assert.NoError(t, err)
+	assert.Equal(t, StrapiUnknown, report.Version)
+}
+
+func TestMigrateGallery(t *testing.T) {
+
+	v4 := []byte(`[{
+		"type": "gallery",
+		"images": {"data": [
+			{"id": 1, "attributes": {"name": "a.jpg", "url": "/uploads/a.jpg", "alternative_text": "a"}},
+			{"id": 2, "attributes": {"name": "b.jpg", "url": "/uploads/b.jpg",
+				"dark": {"data": {"id": 3, "attributes": {"name": "b-dark.jpg", "url": "/uploads/b-dark.jpg"}}}}}
+		]},
+		"children": [{"type": "text", "text": ""}]
+	}]`)
+
+	blocks, report, err := Migrate(v4)
+	assert.NoError(t, err)
+	assert.Equal(t, StrapiV4, report.Version)
+	assert.Equal(t, []MigrationChange{
+		{Path: "/0/images", Description: "unwrapped media data envelope"},
+		{Path: "/0/images/data/0", Description: "flattened media attributes"},
+		{Path: "/0/images/data/0/alternative_text", Description: "renamed to alternativeText"},
+		{Path: "/0/images/data/1", Description: "flattened media attributes"},
+		{Path: "/0/images/data/1/dark", Description: "unwrapped media data envelope"},
+		{Path: "/0/images/data/1/dark", Description: "flattened media attributes"},
+	}, report.Changes)
+	assert.Equal(t, []Image{
+		{Name: "a.jpg", URL: "/uploads/a.jpg", AlternativeText: "a"},
+		{Name: "b.jpg", URL: "/uploads/b.jpg", Dark: &Image{Name: "b-dark.jpg", URL: "/uploads/b-dark.jpg"}},
+	}, blocks[0].Images)
+}
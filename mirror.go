@@ -0,0 +1,227 @@
+package blocks
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AssetStore persists a mirrored upload and returns the url it is served
+// from afterwards.
+type AssetStore interface {
+	Put(ctx context.Context, name, contentType string, data []byte) (string, error)
+}
+
+// DirStore writes assets to a local directory.
+type DirStore struct {
+	Dir string
+	// BaseURL is the public url of the directory
+	BaseURL string
+}
+
+func (s DirStore) Put(ctx context.Context, name, contentType string, data []byte) (string, error) {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(s.Dir, name), data, 0o644); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(s.BaseURL, "/") + "/" + url.PathEscape(name), nil
+}
+
+// ObjectPutter is the subset of an S3 compatible client needed to mirror
+// assets into a bucket.
+type ObjectPutter interface {
+	PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+}
+
+// BucketStore writes assets to an S3 compatible bucket.
+type BucketStore struct {
+	Bucket ObjectPutter
+	// Prefix is prepended to the object keys
+	Prefix string
+	// BaseURL is the public url of the bucket
+	BaseURL string
+}
+
+func (s BucketStore) Put(ctx context.Context, name, contentType string, data []byte) (string, error) {
+	key := s.Prefix + name
+	if err := s.Bucket.PutObject(ctx, key, contentType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(s.BaseURL, "/") + "/" + key, nil
+}
+
+// MirrorOptions configures how uploads are downloaded.
+type MirrorOptions struct {
+	// Client defaults to http.DefaultClient
+	Client *http.Client
+	// Timeout per asset, defaults to 30 seconds
+	Timeout time.Duration
+	// MaxSize per asset in bytes, defaults to 10 MB
+	MaxSize int64
+	// BaseURL is the url of the strapi server, used to resolve relative urls
+	BaseURL string
+	// UploadPath identifies links to uploaded files, defaults to /uploads/
+	UploadPath string
+}
+
+// MirroredAsset is an upload copied to the asset store.
+type MirroredAsset struct {
+	Source string
+	URL    string
+	// Path of the first block referencing the asset
+	Path []int
+}
+
+// Mirror downloads every upload referenced by the document, the images with
+// all their formats and links to uploaded files, into the store. It returns
+// a copy of the document pointing to the mirrored assets. The assets are
+// stored under a hash of their url with the extension of the upload.
+func Mirror(ctx context.Context, blocks []Block, store AssetStore, opts MirrorOptions) ([]Block, []MirroredAsset, error) {
+	m := mirror{
+		ctx:   ctx,
+		store: store,
+		opts:  opts.withDefaults(),
+		urls:  map[string]string{},
+	}
+	mirrored, err := mapBlocks(blocks, m.block)
+	return mirrored, m.assets, err
+}
+
+// RenderMirrored mirrors the assets of the document and renders it.
+func (r *Renderer) RenderMirrored(ctx context.Context, blocks []Block, store AssetStore, opts MirrorOptions) (string, []MirroredAsset, error) {
+	mirrored, assets, err := Mirror(ctx, blocks, store, opts)
+	if err != nil {
+		return "", assets, err
+	}
+	return r.Render(mirrored), assets, nil
+}
+
+func (o MirrorOptions) withDefaults() MirrorOptions {
+	offline := OfflineOptions{Client: o.Client, Timeout: o.Timeout, MaxSize: o.MaxSize}.withDefaults()
+	o.Client, o.Timeout, o.MaxSize = offline.Client, offline.Timeout, offline.MaxSize
+	if o.UploadPath == "" {
+		o.UploadPath = "/uploads/"
+	}
+	return o
+}
+
+type mirror struct {
+	ctx    context.Context
+	store  AssetStore
+	opts   MirrorOptions
+	urls   map[string]string
+	assets []MirroredAsset
+}
+
+func (m *mirror) block(p []int, b Block) (Block, error) {
+	if b.Image != nil {
+		img, err := m.image(p, *b.Image)
+		if err != nil {
+			return b, err
+		}
+		b.Image = &img
+	}
+	if b.Images != nil {
+		images := make([]Image, len(b.Images))
+		for i, img := range b.Images {
+			var err error
+			if images[i], err = m.image(p, img); err != nil {
+				return b, err
+			}
+		}
+		b.Images = images
+	}
+	if b.Type == BlockTypeLink && b.URL != nil && m.isUpload(*b.URL) {
+		u, err := m.asset(p, *b.URL)
+		if err != nil {
+			return b, err
+		}
+		b.URL = &u
+	}
+	return b, nil
+}
+
+// image mirrors the image with its preview, formats and dark variant
+func (m *mirror) image(p []int, img Image) (Image, error) {
+	var err error
+	if img.URL, err = m.asset(p, img.URL); err != nil {
+		return img, err
+	}
+	if img.PreviewURL, err = m.asset(p, img.PreviewURL); err != nil {
+		return img, err
+	}
+	if img.Formats != nil {
+		formats := make(map[string]ImageFormat, len(img.Formats))
+		for name, f := range img.Formats {
+			if f.URL, err = m.asset(p, f.URL); err != nil {
+				return img, err
+			}
+			formats[name] = f
+		}
+		img.Formats = formats
+	}
+	if img.Dark != nil {
+		dark, err := m.image(p, *img.Dark)
+		if err != nil {
+			return img, err
+		}
+		img.Dark = &dark
+	}
+	return img, nil
+}
+
+// assetName names the asset by a hash of its url, as uploads of different
+// folders or hosts may share their file name. The extension is kept for the
+// content type of static file servers.
+func assetName(u *url.URL) string {
+	sum := sha256.Sum256([]byte(u.String()))
+	return hex.EncodeToString(sum[:8]) + strings.ToLower(path.Ext(u.Path))
+}
+
+func (m *mirror) isUpload(src string) bool {
+	if strings.HasPrefix(src, m.opts.UploadPath) {
+		return true
+	}
+	return m.opts.BaseURL != "" && strings.HasPrefix(src, strings.TrimSuffix(m.opts.BaseURL, "/")+m.opts.UploadPath)
+}
+
+func (m *mirror) asset(p []int, src string) (string, error) {
+	if src == "" || strings.HasPrefix(src, "data:") {
+		return src, nil
+	}
+	if mirrored, ok := m.urls[src]; ok {
+		return mirrored, nil
+	}
+	resolved, err := resolveURL(m.opts.BaseURL, src)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(m.ctx, m.opts.Timeout)
+	defer cancel()
+	data, contentType, err := fetch(ctx, m.opts.Client, resolved, m.opts.MaxSize)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(resolved)
+	if err != nil {
+		return "", err
+	}
+	mirrored, err := m.store.Put(m.ctx, assetName(u), contentType, data)
+	if err != nil {
+		return "", err
+	}
+	m.urls[src] = mirrored
+	m.assets = append(m.assets, MirroredAsset{Source: src, URL: mirrored, Path: p})
+	return mirrored, nil
+}
@@ -0,0 +1,138 @@
+package blocks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryBucket map[string][]byte
+
+func (b memoryBucket) PutObject(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	data, err := io.ReadAll(body)
+	b[key] = data
+	return err
+}
+
+func TestMirror(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	doc := []Block{
+		{Type: BlockTypeImage, Image: &Image{URL: "/uploads/a.jpg", Formats: map[string]ImageFormat{
+			"thumbnail": {URL: "/uploads/thumbnail_a.jpg"},
+		}}},
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeLink, URL: ptr(srv.URL + "/uploads/file.pdf"), Children: []Block{{Type: BlockTypeText, Text: ptr("pdf")}}},
+			{Type: BlockTypeLink, URL: ptr("https://example.com"), Children: []Block{{Type: BlockTypeText, Text: ptr("external")}}},
+		}},
+	}
+
+	dir := t.TempDir()
+	mirrored, assets, err := Mirror(context.Background(), doc, DirStore{Dir: dir, BaseURL: "/static/"}, MirrorOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []MirroredAsset{
+		{Source: "/uploads/a.jpg", URL: storedURL(srv.URL, "a.jpg"), Path: []int{0}},
+		{Source: "/uploads/thumbnail_a.jpg", URL: storedURL(srv.URL, "thumbnail_a.jpg"), Path: []int{0}},
+		{Source: srv.URL + "/uploads/file.pdf", URL: storedURL(srv.URL, "file.pdf"), Path: []int{1, 0}},
+	}, assets)
+	assert.Equal(t, storedURL(srv.URL, "a.jpg"), mirrored[0].Image.URL)
+	assert.Equal(t, storedURL(srv.URL, "thumbnail_a.jpg"), mirrored[0].Image.Formats["thumbnail"].URL)
+	assert.Equal(t, storedURL(srv.URL, "file.pdf"), *mirrored[1].Children[0].URL)
+	assert.Equal(t, "https://example.com", *mirrored[1].Children[1].URL)
+	assert.Equal(t, "/uploads/a.jpg", doc[0].Image.URL)
+
+	data, err := os.ReadFile(filepath.Join(dir, storedName(srv.URL, "a.jpg")))
+	assert.NoError(t, err)
+	assert.Equal(t, "/uploads/a.jpg", string(data))
+
+	bucket := memoryBucket{}
+	out, _, err := New().RenderMirrored(context.Background(), doc[:1], BucketStore{Bucket: bucket, Prefix: "cms/", BaseURL: "https://cdn.example.com"}, MirrorOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, `<img src="https://cdn.example.com/cms/`+storedName(srv.URL, "a.jpg")+`" alt="" />`, out)
+	assert.Equal(t, []byte("/uploads/thumbnail_a.jpg"), bucket["cms/"+storedName(srv.URL, "thumbnail_a.jpg")])
+}
+
+func TestMirrorGallery(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	doc := []Block{{Type: BlockTypeGallery, Images: []Image{
+		{URL: "/uploads/a.jpg", Mime: "image/jpeg", Width: 1200, Formats: map[string]ImageFormat{
+			"small": {URL: "/uploads/small_a.jpg", Mime: "image/jpeg", Width: 500},
+		}},
+		{URL: "/uploads/b.jpg"},
+	}}}
+	mirrored, assets, err := Mirror(context.Background(), doc, DirStore{Dir: t.TempDir(), BaseURL: "/static/"}, MirrorOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+	assert.Len(t, assets, 3)
+	assert.Equal(t, storedURL(srv.URL, "a.jpg"), mirrored[0].Images[0].URL)
+	assert.Equal(t, storedURL(srv.URL, "small_a.jpg"), mirrored[0].Images[0].Formats["small"].URL)
+	assert.Equal(t, storedURL(srv.URL, "b.jpg"), mirrored[0].Images[1].URL)
+	assert.Equal(t, "/uploads/a.jpg", doc[0].Images[0].URL)
+	assert.NotContains(t, New().Render(mirrored), "/uploads/")
+}
+
+func TestMirrorDarkImage(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	doc := []Block{{Type: BlockTypeImage, Image: &Image{URL: "/uploads/diagram.svg", Dark: &Image{URL: "/uploads/diagram-night.svg"}}}}
+	mirrored, assets, err := Mirror(context.Background(), doc, DirStore{Dir: t.TempDir(), BaseURL: "/static/"}, MirrorOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+	assert.Len(t, assets, 2)
+	assert.Equal(t, storedURL(srv.URL, "diagram-night.svg"), mirrored[0].Image.Dark.URL)
+	assert.Equal(t, "/uploads/diagram-night.svg", doc[0].Image.Dark.URL)
+	assert.NotContains(t, New(WithDarkImages(nil)).Render(mirrored), "/uploads/")
+}
+
+// storedName is the name the mirror stores the upload under
+func storedName(baseURL, file string) string {
+	u, _ := url.Parse(baseURL + "/uploads/" + file)
+	return assetName(u)
+}
+
+func storedURL(baseURL, file string) string {
+	return "/static/" + storedName(baseURL, file)
+}
+
+func TestMirrorSameFileName(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	doc := []Block{
+		{Type: BlockTypeImage, Image: &Image{URL: "/uploads/a/logo.png"}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/uploads/b/logo.png"}},
+	}
+	dir := t.TempDir()
+	mirrored, _, err := Mirror(context.Background(), doc, DirStore{Dir: dir, BaseURL: "/static/"}, MirrorOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+	assert.NotEqual(t, mirrored[0].Image.URL, mirrored[1].Image.URL)
+	assert.True(t, strings.HasSuffix(mirrored[0].Image.URL, ".png"))
+	for i, b := range mirrored {
+		data, err := os.ReadFile(filepath.Join(dir, strings.TrimPrefix(b.Image.URL, "/static/")))
+		assert.NoError(t, err)
+		assert.Equal(t, doc[i].Image.URL, string(data))
+	}
+}
@@ -0,0 +1,136 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"slices"
+)
+
+// Modifier is a text formatting like bold or italic.
+type Modifier string
+
+const ModifierBold Modifier = "bold"
+const ModifierItalic Modifier = "italic"
+const ModifierUnderline Modifier = "underline"
+const ModifierStrikeThrough Modifier = "strikethrough"
+const ModifierCode Modifier = "code"
+const ModifierSuperscript Modifier = "superscript"
+const ModifierSubscript Modifier = "subscript"
+const ModifierHighlight Modifier = "highlight"
+const ModifierKeyboard Modifier = "kbd"
+
+// DefaultModifierOrder is the order modifiers are applied in, the first one is
+// the innermost.
+var DefaultModifierOrder = []Modifier{ModifierBold, ModifierItalic, ModifierUnderline, ModifierStrikeThrough, ModifierSuperscript, ModifierSubscript, ModifierKeyboard, ModifierCode, ModifierHighlight}
+
+// WithModifierOrder sets the order modifiers are nested in, the first one is
+// the innermost. Modifiers not in the list are ignored, so without any
+// modifier texts are rendered plain.
+func WithModifierOrder(order ...Modifier) Option {
+	return func(r *Renderer) {
+		r.modifiers = slices.Clone(order)
+	}
+}
+
+// ModifierRenderer wraps the rendered content of the text block b in the
+// markup of a modifier.
+type ModifierRenderer interface {
+	RenderModifier(m Modifier, b Block, content string) string
+}
+
+type ModifierRendererFunc func(m Modifier, b Block, content string) string
+
+func (f ModifierRendererFunc) RenderModifier(m Modifier, b Block, content string) string {
+	return f(m, b, content)
+}
+
+// HasModifier reports whether the modifier is set on the block.
+func (b Block) HasModifier(m Modifier) bool {
+	var set *bool
+	switch m {
+	case ModifierBold:
+		set = b.Bold
+	case ModifierItalic:
+		set = b.Italic
+	case ModifierUnderline:
+		set = b.Underline
+	case ModifierStrikeThrough:
+		set = b.StrikeThrough
+	case ModifierCode:
+		set = b.Code
+	case ModifierSuperscript:
+		set = b.Superscript
+	case ModifierSubscript:
+		set = b.Subscript
+	case ModifierKeyboard:
+		set = b.Keyboard
+	case ModifierHighlight:
+		return b.Highlight != nil && b.Highlight.Active
+	}
+	return deref(set)
+}
+
+func (r *Renderer) RenderModifier(m Modifier, b Block, content string) string {
+	switch m {
+	case ModifierBold:
+		return fmt.Sprintf("<strong>%s</strong>", content)
+	case ModifierItalic:
+		return fmt.Sprintf("<em>%s</em>", content)
+	case ModifierUnderline:
+		return fmt.Sprintf("<u>%s</u>", content)
+	case ModifierStrikeThrough:
+		return fmt.Sprintf("<del>%s</del>", content)
+	case ModifierCode:
+		return fmt.Sprintf("<code>%s</code>", content)
+	case ModifierSuperscript:
+		return fmt.Sprintf("<sup>%s</sup>", content)
+	case ModifierSubscript:
+		return fmt.Sprintf("<sub>%s</sub>", content)
+	case ModifierKeyboard:
+		return fmt.Sprintf("<kbd%s>%s</kbd>", r.attrs(ElementKeyboard), content)
+	case ModifierHighlight:
+		return fmt.Sprintf("<mark%s%s>%s</mark>", r.attrs(ElementMark), b.Highlight.colorAttr(), content)
+	}
+	return content
+}
+
+// Mark is the highlight mark of a text. Editor plugins write true or the name
+// of a color, which is rendered as data-color attribute of the <mark>.
+type Mark struct {
+	Active bool
+	Color  string
+}
+
+// UnmarshalJSON accepts booleans, color names and objects with a color.
+func (m *Mark) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.Active); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &m.Color); err == nil {
+		m.Active = m.Color != ""
+		return nil
+	}
+	var obj struct {
+		Color string `json:"color"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	m.Active, m.Color = true, obj.Color
+	return nil
+}
+
+func (m Mark) MarshalJSON() ([]byte, error) {
+	if m.Active && m.Color != "" {
+		return json.Marshal(m.Color)
+	}
+	return json.Marshal(m.Active)
+}
+
+func (m *Mark) colorAttr() string {
+	if m == nil || m.Color == "" {
+		return ""
+	}
+	return fmt.Sprintf(` data-color="%s"`, html.EscapeString(m.Color))
+}
@@ -0,0 +1,156 @@
+package blocks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModifierRenderer(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("text"), Bold: ptr(true), Italic: ptr(true), Code: ptr(false)},
+		{Type: BlockTypeText, Text: ptr("code"), Code: ptr(true)},
+	}}}
+
+	r := New()
+	r.ModifierRenderer = ModifierRendererFunc(func(m Modifier, b Block, content string) string {
+		if m == ModifierCode {
+			return r.RenderModifier(m, b, content)
+		}
+		return fmt.Sprintf(`<span class="%s">%s</span>`, m, content)
+	})
+	assert.Equal(t, `<p>
+  <span class="italic">
+    <span class="bold">
+      text
+    </span>
+  </span>
+  <code>
+    code
+  </code>
+</p>`, r.Render(doc))
+}
+
+func TestBlock_HasModifier(t *testing.T) {
+	b := Block{Type: BlockTypeText, Bold: ptr(true), Italic: ptr(false)}
+	assert.True(t, b.HasModifier(ModifierBold))
+	assert.False(t, b.HasModifier(ModifierItalic))
+	assert.False(t, b.HasModifier(ModifierUnderline))
+	assert.False(t, b.HasModifier("blink"))
+}
+
+func TestWithModifierOrder(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("text"), Bold: ptr(true), Italic: ptr(true), Underline: ptr(true)},
+	}}}
+
+	assert.Equal(t, `<p>
+  <em>
+    <u>
+      <strong>
+        text
+      </strong>
+    </u>
+  </em>
+</p>`, New(WithModifierOrder(ModifierBold, ModifierUnderline, ModifierItalic)).Render(doc))
+
+	assert.Equal(t, `<p>
+  <strong>
+    text
+  </strong>
+</p>`, New(WithModifierOrder(ModifierBold)).Render(doc))
+
+	assert.Equal(t, `<p>
+  text
+</p>`, New(WithModifierOrder()).Render(doc))
+}
+
+func TestSuperscriptSubscript(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("E=mc")},
+		{Type: BlockTypeText, Text: ptr("2"), Superscript: ptr(true)},
+		{Type: BlockTypeText, Text: ptr(" H")},
+		{Type: BlockTypeText, Text: ptr("2"), Subscript: ptr(true)},
+		{Type: BlockTypeText, Text: ptr("O")},
+	}}}
+
+	assert.Equal(t, `<p>
+  E=mc
+  <sup>
+    2
+  </sup>
+  H
+  <sub>
+    2
+  </sub>
+  O
+</p>`, Render(doc))
+	assert.Equal(t, "E=mc<sup>2</sup> H<sub>2</sub>O\n", Markdown(doc))
+
+	r := New()
+	r.ModifierRenderer = ModifierRendererFunc(func(m Modifier, b Block, content string) string {
+		if m == ModifierSuperscript {
+			return "^" + content
+		}
+		return r.RenderModifier(m, b, content)
+	})
+	assert.Contains(t, r.Render(doc), "E=mc^2 H\n")
+}
+
+func TestHighlightMark(t *testing.T) {
+
+	doc, err := Parse([]byte(`[{"type": "paragraph", "children": [
+		{"type": "text", "text": "plain", "highlight": false},
+		{"type": "text", "text": "marked", "highlight": true},
+		{"type": "text", "text": "yellow", "highlight": "yellow"},
+		{"type": "text", "text": "green", "bold": true, "highlight": {"color": "gr\"een"}}
+	]}]`))
+	assert.NoError(t, err)
+	assert.False(t, doc[0].Children[0].HasModifier(ModifierHighlight))
+
+	r := New(WithClasses(Classes{ElementMark: "hl"}))
+	assert.Equal(t, `<p>
+  plain
+  <mark class="hl">
+    marked
+  </mark>
+  <mark class="hl" data-color="yellow">
+    yellow
+  </mark>
+  <mark class="hl" data-color="gr&#34;een">
+    <strong>
+      green
+    </strong>
+  </mark>
+</p>`, r.Render(doc))
+
+	marked := doc[0].Children[1:]
+	assert.Equal(t, marked, NodesOf(marked).Blocks())
+}
+
+func TestKeyboard(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("Press ")},
+		{Type: BlockTypeText, Text: ptr("Ctrl"), Keyboard: ptr(true)},
+		{Type: BlockTypeText, Text: ptr("+")},
+		{Type: BlockTypeText, Text: ptr("C"), Keyboard: ptr(true)},
+	}}}
+
+	r := New(WithClasses(Classes{ElementKeyboard: "key"}))
+	assert.Equal(t, `<p>
+  Press
+  <kbd class="key">
+    Ctrl
+  </kbd>
+  +
+  <kbd class="key">
+    C
+  </kbd>
+</p>`, r.Render(doc))
+	assert.Equal(t, "Press <kbd>Ctrl</kbd>+<kbd>C</kbd>\n", Markdown(doc))
+}
@@ -0,0 +1,38 @@
+package blocks
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// RenderNodes renders the blocks and parses the result into a html node tree,
+// so the output can be post-processed programmatically. The nodes are parsed
+// from the unformatted output of Render, so custom renderers, WithReactCompat,
+// the limits and WithRecover apply the same way. Nil is returned when the
+// render fails. The returned nodes have no parent.
+func (r *Renderer) RenderNodes(blocks []Block) []*html.Node {
+	out, err := r.renderMarkup(context.Background(), blocks, func(out string) string { return out })
+	if err != nil {
+		return nil
+	}
+	return parseNodes(out)
+}
+
+// parseNodes parses the rendered markup as children of a body
+func parseNodes(out string) []*html.Node {
+	body := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	}
+	// parsing only fails on read errors, which a strings.Reader never returns
+	nodes, _ := html.ParseFragment(strings.NewReader(out), body)
+	return nodes
+}
+
+func RenderNodes(blocks []Block) []*html.Node {
+	return New().RenderNodes(blocks)
+}
@@ -0,0 +1,48 @@
+package blocks
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func TestRenderer_RenderNodes(t *testing.T) {
+
+	var blocks []Block
+	json.Unmarshal(testInput, &blocks)
+
+	nodes := RenderNodes(blocks)
+	assert.Len(t, nodes, len(blocks))
+
+	assert.Equal(t, "p", nodes[0].Data)
+	assert.Equal(t, "h1", nodes[5].Data)
+	assert.Equal(t, "img", nodes[8].Data)
+	assert.Equal(t, []html.Attribute{
+		{Key: "src", Val: "http://localhost:1337/uploads/cdreier_gopher_small_a32e6e2b51.jpg"},
+		{Key: "alt", Val: "cdreier_gopher_small.jpg"},
+		{Key: "width", Val: "256"},
+		{Key: "height", Val: "256"},
+	}, nodes[8].Attr)
+
+	out := strings.Builder{}
+	html.Render(&out, nodes[4])
+	assert.Equal(t, `<p>and <a href="http://asdf.de">links</a></p>`, out.String())
+}
+
+func TestRenderer_RenderNodes_renderPath(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("u"), Underline: ptr(true)},
+	}}}
+	nodes := New(WithReactCompat()).RenderNodes(doc)
+	out := strings.Builder{}
+	html.Render(&out, nodes[0])
+	assert.Equal(t, `<p><span style="text-decoration:underline">u</span></p>`, out.String())
+
+	r := New(WithLimits(Limits{MaxBlocks: 1}))
+	assert.Nil(t, r.RenderNodes(doc))
+	assert.Equal(t, WarningLimitExceeded, r.Warnings()[0].Code)
+}
@@ -0,0 +1,75 @@
+package blocks
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// WithNonce adds the nonce attribute of a strict Content-Security-Policy to
+// the markup the renderer emits itself: elements with a style attribute and
+// the script and style elements of custom math and mermaid renderers. Content
+// of the payload, like text or raw html blocks, never gets the nonce. Browsers
+// only honor the nonce on script and style elements, so style attributes
+// still need 'unsafe-hashes' or a stylesheet.
+func WithNonce(nonce string) Option {
+	return func(r *Renderer) {
+		r.nonce = nonce
+	}
+}
+
+// nonceAttr returns the nonce attribute including the leading space
+func (r *Renderer) nonceAttr() string {
+	if r.nonce == "" {
+		return ""
+	}
+	return fmt.Sprintf(` nonce="%s"`, html.EscapeString(r.nonce))
+}
+
+// withNonce adds the nonce to the markup of trusted custom renderers
+func (r *Renderer) withNonce(fragment string) string {
+	if r.nonce == "" {
+		return fragment
+	}
+	return r.addNonce(fragment)
+}
+
+// addNonce sets the nonce on all script and style bearing elements of the
+// html fragment.
+func (r *Renderer) addNonce(fragment string) string {
+	out := strings.Builder{}
+	z := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// the fragment is read from a string, so the error is io.EOF
+			return out.String()
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			out.Write(z.Raw())
+			continue
+		}
+		raw := string(z.Raw())
+		t := z.Token()
+		if !needsNonce(t) {
+			out.WriteString(raw)
+			continue
+		}
+		t.Attr = append(t.Attr, html.Attribute{Key: "nonce", Val: r.nonce})
+		out.WriteString(t.String())
+	}
+}
+
+func needsNonce(t html.Token) bool {
+	bearing := t.Data == "script" || t.Data == "style"
+	for _, a := range t.Attr {
+		if a.Key == "nonce" {
+			return false
+		}
+		if a.Key == "style" {
+			bearing = true
+		}
+	}
+	return bearing
+}
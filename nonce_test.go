@@ -0,0 +1,37 @@
+package blocks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNonce(t *testing.T) {
+
+	doc := []Block{
+		codeBlock("mermaid", "graph TD; A-->B"),
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", Width: 4, Height: 3}},
+		paragraph("a < b"),
+	}
+
+	diagram := func(src string) (string, error) {
+		return `<svg><style>.node { fill: red; }</style><script>1 < 2</script></svg>`, nil
+	}
+	out := New(WithMermaid(diagram), WithAspectRatio(), WithNonce("r4nd0m")).Render(doc)
+	assert.Contains(t, out, `<style nonce="r4nd0m">`)
+	assert.Contains(t, out, `<script nonce="r4nd0m">`)
+	assert.Contains(t, out, `1 < 2`)
+	assert.Contains(t, out, `<img src="/a.png" alt="" width="4" height="3" style="aspect-ratio: 4 / 3;" nonce="r4nd0m" />`)
+	assert.Contains(t, out, "a < b")
+	assert.NotContains(t, out, "<p nonce")
+
+	failing := func(src string) (string, error) {
+		return "", errors.New("no diagram")
+	}
+	out = New(WithMermaid(failing), WithNonce("r4nd0m")).Render(doc[:1])
+	assert.NotContains(t, out, "nonce")
+
+	out = New(WithNonce("r4nd0m")).Render([]Block{paragraph("<script>alert(document.cookie)</script>")})
+	assert.NotContains(t, out, "nonce", "content of the payload never gets the nonce")
+}
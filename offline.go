@@ -0,0 +1,208 @@
+package blocks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yosssi/gohtml"
+	nethtml "golang.org/x/net/html"
+)
+
+// OfflineOptions configures how images are downloaded for an offline export.
+type OfflineOptions struct {
+	// Client defaults to http.DefaultClient
+	Client *http.Client
+	// Timeout per image, defaults to 30 seconds
+	Timeout time.Duration
+	// MaxSize per image in bytes, defaults to 10 MB
+	MaxSize int64
+	// BaseURL resolves relative image urls
+	BaseURL string
+	// Title of the html document
+	Title string
+}
+
+func (o OfflineOptions) withDefaults() OfflineOptions {
+	if o.Client == nil {
+		o.Client = http.DefaultClient
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 30 * time.Second
+	}
+	if o.MaxSize <= 0 {
+		o.MaxSize = 10 << 20
+	}
+	return o
+}
+
+// RenderOffline renders a self contained html document for archival. All
+// images are downloaded and inlined as base64 data uris, including the
+// candidates of srcset attributes. Picture sources with a media condition,
+// like the dark variants of WithDarkImages, are inlined too, the other
+// sources are dropped in favour of the inlined fallback image. The
+// backgrounds of WithLowQualityPlaceholders are inlined as well. The errors
+// of the limits and WithRecover are returned like RenderTo does.
+func (r *Renderer) RenderOffline(ctx context.Context, blocks []Block, opts OfflineOptions) (string, error) {
+	opts = opts.withDefaults()
+	inliner := imageInliner{ctx: ctx, opts: opts, cache: map[string]string{}}
+
+	markup, err := r.renderMarkup(ctx, blocks, func(out string) string { return out })
+	if err != nil {
+		return "", err
+	}
+	out := strings.Builder{}
+	for _, n := range parseNodes(markup) {
+		if err := inliner.inline(n); err != nil {
+			return "", err
+		}
+		nethtml.Render(&out, n)
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+</head>
+<body>
+%s
+</body>
+</html>
+`, html.EscapeString(opts.Title), gohtml.Format(out.String())), nil
+}
+
+type imageInliner struct {
+	ctx   context.Context
+	opts  OfflineOptions
+	cache map[string]string
+}
+
+func (in *imageInliner) inline(n *nethtml.Node) error {
+	if n.Type == nethtml.ElementNode {
+		image := n.Data == "img" || n.Data == "source"
+		for i, a := range n.Attr {
+			var err error
+			switch {
+			case a.Key == "src" && image:
+				n.Attr[i].Val, err = in.dataURI(a.Val)
+			case a.Key == "srcset" && image:
+				n.Attr[i].Val, err = in.srcset(a.Val)
+			case a.Key == "style":
+				n.Attr[i].Val, err = in.style(a.Val)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == nethtml.ElementNode && c.Data == "source" && !hasAttr(c, "media") {
+			n.RemoveChild(c)
+		} else if err := in.inline(c); err != nil {
+			return err
+		}
+		c = next
+	}
+	return nil
+}
+
+func hasAttr(n *nethtml.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// srcset inlines the urls of the candidates, the descriptors are kept
+func (in *imageInliner) srcset(srcset string) (string, error) {
+	candidates := []string{}
+	for _, c := range parseSrcset(srcset) {
+		uri, err := in.dataURI(c.url)
+		if err != nil {
+			return "", err
+		}
+		candidates = append(candidates, strings.TrimSpace(uri+" "+c.descriptor))
+	}
+	return strings.Join(candidates, ", "), nil
+}
+
+// cssURL matches the urls of styles, like the background of the low quality
+// placeholders
+var cssURL = regexp.MustCompile(`url\('([^']*)'\)`)
+
+// style inlines the urls of the style attribute
+func (in *imageInliner) style(style string) (string, error) {
+	var err error
+	out := cssURL.ReplaceAllStringFunc(style, func(match string) string {
+		if err != nil {
+			return match
+		}
+		var uri string
+		uri, err = in.dataURI(cssURL.FindStringSubmatch(match)[1])
+		return "url('" + uri + "')"
+	})
+	return out, err
+}
+
+type srcsetCandidate struct {
+	url        string
+	descriptor string
+}
+
+// parseSrcset splits a srcset attribute into its candidates. Urls end at
+// whitespace, so the commas of data uris are kept.
+func parseSrcset(srcset string) []srcsetCandidate {
+	candidates := []srcsetCandidate{}
+	rest := srcset
+	for {
+		rest = strings.TrimLeft(rest, " \t\n\r\f,")
+		if rest == "" {
+			return candidates
+		}
+		end := strings.IndexAny(rest, " \t\n\r\f")
+		if end < 0 {
+			end = len(rest)
+		}
+		c := srcsetCandidate{url: rest[:end]}
+		rest = rest[end:]
+		if trimmed := strings.TrimRight(c.url, ","); trimmed != c.url {
+			// a trailing comma ends the candidate without descriptor
+			c.url = trimmed
+		} else {
+			descriptor, next, _ := strings.Cut(rest, ",")
+			c.descriptor, rest = strings.TrimSpace(descriptor), next
+		}
+		candidates = append(candidates, c)
+	}
+}
+
+func (in *imageInliner) dataURI(src string) (string, error) {
+	if strings.HasPrefix(src, "data:") {
+		return src, nil
+	}
+	if uri, ok := in.cache[src]; ok {
+		return uri, nil
+	}
+	resolved, err := resolveURL(in.opts.BaseURL, src)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(in.ctx, in.opts.Timeout)
+	defer cancel()
+	data, contentType, err := fetch(ctx, in.opts.Client, resolved, in.opts.MaxSize)
+	if err != nil {
+		return "", err
+	}
+	uri := "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+	in.cache[src] = uri
+	return uri, nil
+}
@@ -0,0 +1,135 @@
+package blocks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_RenderOffline(t *testing.T) {
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/uploads/a.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png"))
+		case "/uploads/large.png":
+			w.Write(make([]byte, 100))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	doc := []Block{
+		{Type: BlockTypeImage, Image: &Image{URL: "/uploads/a.png", AlternativeText: "a"}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/uploads/a.png", AlternativeText: "again"}},
+	}
+	r := New(WithPicture(PictureOptions{SourceURL: func(img Image, mime string) string {
+		return img.URL + ".webp"
+	}}))
+
+	out, err := r.RenderOffline(context.Background(), doc, OfflineOptions{BaseURL: srv.URL, Title: "a & b"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>a &amp; b</title>
+</head>
+<body>
+<picture>
+  <img src="data:image/png;base64,cG5n" alt="a"/>
+</picture>
+<picture>
+  <img src="data:image/png;base64,cG5n" alt="again"/>
+</picture>
+</body>
+</html>
+`, out)
+
+	_, err = r.RenderOffline(context.Background(), []Block{{Type: BlockTypeImage, Image: &Image{URL: "/uploads/large.png"}}}, OfflineOptions{BaseURL: srv.URL, MaxSize: 10})
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, err = r.RenderOffline(context.Background(), []Block{{Type: BlockTypeImage, Image: &Image{URL: "/uploads/missing.png"}}}, OfflineOptions{BaseURL: srv.URL})
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestRenderOfflineSrcset(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	doc := []Block{{Type: BlockTypeGallery, Images: []Image{
+		{URL: "/uploads/a.jpg", Mime: "image/jpeg", Width: 1200, Formats: map[string]ImageFormat{
+			"small": {URL: "/uploads/small_a.jpg", Mime: "image/jpeg", Width: 500},
+		}},
+	}}}
+	out, err := New().RenderOffline(context.Background(), doc, OfflineOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+	assert.NotContains(t, out, "/uploads/")
+	assert.Contains(t, out, `srcset="data:image/jpeg;base64,L3VwbG9hZHMvc21hbGxfYS5qcGc= 500w, data:image/jpeg;base64,L3VwbG9hZHMvYS5qcGc= 1200w"`)
+}
+
+func TestParseSrcset(t *testing.T) {
+	assert.Equal(t, []srcsetCandidate{
+		{url: "a.jpg", descriptor: "1x"},
+		{url: "data:image/png;base64,AA==", descriptor: "2x"},
+		{url: "c.jpg"},
+		{url: "d.jpg", descriptor: "640w"},
+	}, parseSrcset(" a.jpg 1x,data:image/png;base64,AA== 2x, c.jpg, d.jpg 640w"))
+}
+
+func TestRenderOfflineDarkImage(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	doc := []Block{{Type: BlockTypeImage, Image: &Image{URL: "/uploads/diagram.svg", Dark: &Image{URL: "/uploads/diagram-night.svg"}}}}
+	out, err := New(WithDarkImages(nil)).RenderOffline(context.Background(), doc, OfflineOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+	assert.NotContains(t, out, "/uploads/")
+	assert.Contains(t, out, `<source media="(prefers-color-scheme: dark)" srcset="data:image/svg+xml;base64,L3VwbG9hZHMvZGlhZ3JhbS1uaWdodC5zdmc="/>`)
+}
+
+func TestRenderOfflinePlaceholders(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer srv.Close()
+
+	doc := []Block{{Type: BlockTypeImage, Image: &Image{URL: "/uploads/a.jpg", PreviewURL: "/uploads/preview_a.jpg"}}}
+	out, err := New(WithLowQualityPlaceholders()).RenderOffline(context.Background(), doc, OfflineOptions{BaseURL: srv.URL})
+	assert.NoError(t, err)
+	assert.NotContains(t, out, "/uploads/")
+	assert.Contains(t, out, `style="background-image: url(&#39;data:image/jpeg;base64,L3VwbG9hZHMvcHJldmlld19hLmpwZw==&#39;); background-size: cover;"`)
+}
+
+func TestRenderOfflineErrors(t *testing.T) {
+
+	doc := []Block{paragraph("a"), paragraph("b")}
+	out, err := New(WithLimits(Limits{MaxBlocks: 2})).RenderOffline(context.Background(), doc, OfflineOptions{})
+	assert.Empty(t, out)
+	var limit *LimitError
+	assert.ErrorAs(t, err, &limit)
+
+	doc = []Block{{Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeText, Text: ptr("a")}, {Type: BlockTypeText}}}}
+	out, err = New(WithNilSafe(false), WithRecover(true)).RenderOffline(context.Background(), doc, OfflineOptions{})
+	assert.Empty(t, out)
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+}
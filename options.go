@@ -0,0 +1,99 @@
+package blocks
+
+type Option func(*Renderer)
+
+// WithNilSafe controls how blocks with missing required fields are rendered.
+// Nil safe mode is enabled by default, the missing values are replaced by
+// placeholders and a warning is recorded. Disabling it panics on malformed
+// blocks instead, which is useful to catch bad data early in development.
+func WithNilSafe(enabled bool) Option {
+	return func(r *Renderer) {
+		r.nilSafe = enabled
+	}
+}
+
+// Placeholders are rendered in place of blocks with missing data. They are
+// emitted as is, so they can also be html comments.
+type Placeholders struct {
+	// MissingText replaces text nodes without text
+	MissingText string
+	// MissingImage replaces image blocks without media
+	MissingImage string
+	// UnsupportedBlock replaces blocks of unknown type
+	UnsupportedBlock string
+	// UnsupportedList replaces lists with an unknown format
+	UnsupportedList string
+	// BrokenLink is used as href for links without url
+	BrokenLink string
+	// MissingVariable replaces variables without value, see WithVariables
+	MissingVariable string
+	// FailedBlock replaces blocks whose renderer panicked, see
+	// WithErrorBoundaries
+	FailedBlock string
+}
+
+var DefaultPlaceholders = Placeholders{
+	MissingText:      "",
+	MissingImage:     "missing image",
+	UnsupportedBlock: "unsupported block type",
+	UnsupportedList:  "unsupported list",
+	BrokenLink:       "#",
+	MissingVariable:  "",
+	FailedBlock:      "",
+}
+
+// WithPlaceholders replaces all placeholders, start from DefaultPlaceholders
+// to change only some of them.
+func WithPlaceholders(p Placeholders) Option {
+	return func(r *Renderer) {
+		r.placeholders = p
+	}
+}
+
+// WithLowQualityPlaceholders wraps images carrying a blurhash or previewUrl in
+// an element showing the low quality version as background until the real
+// image is loaded.
+func WithLowQualityPlaceholders() Option {
+	return func(r *Renderer) {
+		r.lqip = true
+	}
+}
+
+// WithAspectRatio adds an aspect-ratio style to images with known dimensions,
+// so responsive images scaled by css keep their space while loading.
+func WithAspectRatio() Option {
+	return func(r *Renderer) {
+		r.aspectRatio = true
+	}
+}
+
+// PictureOptions configures the <source> elements of <picture> output.
+type PictureOptions struct {
+	// Types are the mime types emitted as sources in order of preference,
+	// defaults to avif and webp.
+	Types []string
+	// SourceURL returns the url of the image converted to the given type, for
+	// image services converting on the fly. Without it the matching entries
+	// of the strapi formats map are used.
+	SourceURL func(img Image, mime string) string
+}
+
+// WithPicture wraps images in a <picture> element with modern format sources,
+// falling back to the original <img>.
+func WithPicture(opts PictureOptions) Option {
+	if len(opts.Types) == 0 {
+		opts.Types = []string{"image/avif", "image/webp"}
+	}
+	return func(r *Renderer) {
+		r.picture = &opts
+	}
+}
+
+// WithBlockRenderers adds renderers which are asked in order before the per
+// type renderers. The first renderer returning true renders the block, if all
+// of them return false the block is rendered as usual.
+func WithBlockRenderers(renderers ...BlockRenderer) Option {
+	return func(r *Renderer) {
+		r.blockRenderers = append(r.blockRenderers, renderers...)
+	}
+}
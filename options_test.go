@@ -0,0 +1,85 @@
+package blocks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNilSafe(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("before ")},
+		{Type: BlockTypeText, Bold: ptr(true)},
+	}}}
+
+	r := New(WithPlaceholders(Placeholders{MissingText: "[missing]"}))
+	assert.Equal(t, "<p>\n  before [missing]\n</p>", r.Render(doc))
+	assert.Equal(t, []Warning{{Code: WarningMissingText, Message: "text block without text", Path: []int{0, 1}}}, r.Warnings())
+
+	r.Render(nil)
+	assert.Empty(t, r.Warnings())
+
+	r = New(WithNilSafe(false))
+	assert.PanicsWithValue(t, "blocks: text block without text", func() { r.Render(doc) })
+}
+
+func TestWithPlaceholders(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeImage},
+		{Type: "video"},
+		{Type: BlockTypeList, Format: ptr("dotted")},
+		{Type: BlockTypeLink, Children: []Block{{Type: BlockTypeText, Text: ptr("link")}}},
+	}
+
+	r := New()
+	assert.Equal(t, "missing imageunsupported block typeunsupported list\n<a href=\"#\">\n  link\n</a>", r.Render(doc))
+	assert.Equal(t, []Warning{
+		{Code: WarningMissingImage, Message: "image block without media", Path: []int{0}},
+		{Code: WarningUnsupportedBlock, Message: `unsupported block type "video"`, Path: []int{1}},
+		{Code: WarningUnsupportedList, Message: `unsupported list format "dotted"`, Path: []int{2}},
+		{Code: WarningBrokenLink, Message: "link without url", Path: []int{3}},
+	}, r.Warnings())
+
+	r = New(WithPlaceholders(Placeholders{
+		MissingImage:     "<!-- fehlendes bild -->",
+		UnsupportedBlock: "",
+		UnsupportedList:  "",
+		BrokenLink:       "/404",
+	}))
+	assert.Equal(t, "<!-- fehlendes bild -->\n<a href=\"/404\">\n  link\n</a>", r.Render(doc))
+}
+
+func TestWithBlockRenderers(t *testing.T) {
+
+	doc := []Block{
+		heading(2, "title"),
+		{Type: "video", URL: ptr("/clip.mp4")},
+		paragraph("text"),
+	}
+
+	video := BlockRendererFunc(func(b Block) (string, bool) {
+		if b.Type != "video" {
+			return "", false
+		}
+		return fmt.Sprintf(`<video src=%q></video>`, deref(b.URL)), true
+	})
+	headings := BlockRendererFunc(func(b Block) (string, bool) {
+		if b.Type != BlockTypeHeading {
+			return "", false
+		}
+		return fmt.Sprintf(`<h%d class="title">%s</h%d>`, *b.Level, b.PlainText(), *b.Level), true
+	})
+
+	r := New(WithBlockRenderers(video, headings))
+	assert.Equal(t, `<h2 class="title">
+  title
+</h2>
+<video src="/clip.mp4"></video>
+<p>
+  text
+</p>`, r.Render(doc))
+	assert.Empty(t, r.Warnings())
+}
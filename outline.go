@@ -0,0 +1,60 @@
+package blocks
+
+import (
+	"strings"
+)
+
+// OutlineNode is a heading of the document with the headings of its
+// subsections as children.
+type OutlineNode struct {
+	Title string
+	// Slug is unique within the outline, for anchors
+	Slug  string
+	Level int
+	// Index is the index of the heading in the top level blocks
+	Index int
+	// Start and End are the range of top level blocks of the section after
+	// the heading, up to the next heading of the same or a higher level, so
+	// the subsections are included
+	Start    int
+	End      int
+	Children []OutlineNode
+}
+
+// Outline returns the top level headings of the document as a tree, for
+// sidebar navigations. Headings skipping a level are nested below the
+// closest higher heading, content before the first heading is not part of
+// the outline.
+func Outline(blocks []Block) []OutlineNode {
+	headings := []OutlineNode{}
+	slugs := map[string]int{}
+	for i, b := range blocks {
+		if b.Type != BlockTypeHeading || b.Level == nil {
+			continue
+		}
+		title := strings.TrimSpace(b.PlainText())
+		headings = append(headings, OutlineNode{Title: title, Slug: uniqueSlug(slugs, Slug(title)), Level: *b.Level, Index: i, Start: i + 1})
+	}
+	return nestOutline(headings, len(blocks))
+}
+
+// nestOutline nests the headings of the section ending at end below the
+// headings of the lowest level
+func nestOutline(headings []OutlineNode, end int) []OutlineNode {
+	nodes := []OutlineNode{}
+	for i := 0; i < len(headings); {
+		node := headings[i]
+		next := i + 1
+		for next < len(headings) && headings[next].Level > node.Level {
+			next++
+		}
+		node.End = end
+		if next < len(headings) {
+			node.End = headings[next].Index
+		}
+		node.Children = nestOutline(headings[i+1:next], node.End)
+		nodes = append(nodes, node)
+		i = next
+	}
+	return nodes
+}
@@ -0,0 +1,35 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutline(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		heading(1, "Guide"),
+		paragraph("a"),
+		heading(2, "Install"),
+		heading(4, "Linux"),
+		paragraph("b"),
+		heading(2, "Usage"),
+		heading(3, "Install"),
+		heading(1, "FAQ"),
+		{Type: BlockTypeHeading, Children: []Block{{Type: BlockTypeText, Text: ptr("no level")}}},
+	}
+	assert.Equal(t, []OutlineNode{
+		{Title: "Guide", Slug: "guide", Level: 1, Index: 1, Start: 2, End: 8, Children: []OutlineNode{
+			{Title: "Install", Slug: "install", Level: 2, Index: 3, Start: 4, End: 6, Children: []OutlineNode{
+				{Title: "Linux", Slug: "linux", Level: 4, Index: 4, Start: 5, End: 6, Children: []OutlineNode{}},
+			}},
+			{Title: "Usage", Slug: "usage", Level: 2, Index: 6, Start: 7, End: 8, Children: []OutlineNode{
+				{Title: "Install", Slug: "install-2", Level: 3, Index: 7, Start: 8, End: 8, Children: []OutlineNode{}},
+			}},
+		}},
+		{Title: "FAQ", Slug: "faq", Level: 1, Index: 8, Start: 9, End: 10, Children: []OutlineNode{}},
+	}, Outline(doc))
+	assert.Empty(t, Outline([]Block{paragraph("a")}))
+}
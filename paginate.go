@@ -0,0 +1,97 @@
+package blocks
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// PaginateOptions configures where Paginate starts a new page. With both
+// options set a page ends at whichever comes first.
+type PaginateOptions struct {
+	// HeadingLevel starts a new page at every heading of this level or above,
+	// 2 breaks on h1 and h2. Zero disables breaking on headings.
+	HeadingLevel int
+	// MaxBlocks is the maximum number of top level blocks per page, zero
+	// means no limit.
+	MaxBlocks int
+}
+
+// Page is a part of a paginated document.
+type Page struct {
+	// Number counts from 1
+	Number int
+	// Title is the text of the first top level heading on the page
+	Title string
+	Slug  string
+	// Offset is the index of the first block of the page in the document
+	Offset int
+	Blocks []Block
+	// Prev and Next are the numbers of the neighbour pages, 0 if there is none
+	Prev int
+	Next int
+}
+
+// Paginate splits a document into pages. The blocks of the pages are shared
+// with the document.
+func Paginate(blocks []Block, opts PaginateOptions) []Page {
+	pages := []Page{}
+	for i, b := range blocks {
+		if len(pages) == 0 || startsPage(pages[len(pages)-1], b, opts) {
+			pages = append(pages, Page{Number: len(pages) + 1, Offset: i})
+		}
+		page := &pages[len(pages)-1]
+		page.Blocks = blocks[page.Offset : i+1]
+		if page.Title == "" && b.Type == BlockTypeHeading {
+			page.Title = strings.TrimSpace(b.PlainText())
+			page.Slug = Slug(page.Title)
+		}
+	}
+	for i := range pages {
+		if i > 0 {
+			pages[i].Prev = i
+		}
+		if i < len(pages)-1 {
+			pages[i].Next = i + 2
+		}
+	}
+	return pages
+}
+
+func startsPage(current Page, b Block, opts PaginateOptions) bool {
+	if opts.MaxBlocks > 0 && len(current.Blocks) >= opts.MaxBlocks {
+		return true
+	}
+	if opts.HeadingLevel > 0 && b.Type == BlockTypeHeading && b.Level != nil && *b.Level <= opts.HeadingLevel {
+		return true
+	}
+	return false
+}
+
+var slugFolder = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// Slug turns a text into an url friendly identifier, like "über uns" into
+// "uber-uns".
+func Slug(text string) string {
+	folded, _, err := transform.String(slugFolder, text)
+	if err != nil {
+		folded = text
+	}
+	out := strings.Builder{}
+	dash := false
+	for _, c := range strings.ToLower(folded) {
+		if unicode.IsLetter(c) || unicode.IsDigit(c) {
+			if dash && out.Len() > 0 {
+				out.WriteRune('-')
+			}
+			out.WriteRune(c)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return out.String()
+}
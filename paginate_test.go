@@ -0,0 +1,44 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func heading(level int, text string) Block {
+	return Block{Type: BlockTypeHeading, Level: ptr(level), Children: []Block{{Type: BlockTypeText, Text: ptr(text)}}}
+}
+
+func TestPaginate(t *testing.T) {
+
+	doc := []Block{
+		heading(1, "Über uns"),
+		paragraph("intro"),
+		heading(2, "Team"),
+		paragraph("people"),
+		heading(3, "Gophers"),
+		paragraph("more people"),
+		heading(2, "Jobs & Karriere"),
+	}
+
+	pages := Paginate(doc, PaginateOptions{HeadingLevel: 2})
+	assert.Len(t, pages, 3)
+	assert.Equal(t, Page{Number: 1, Title: "Über uns", Slug: "uber-uns", Offset: 0, Blocks: doc[0:2], Next: 2}, pages[0])
+	assert.Equal(t, Page{Number: 2, Title: "Team", Slug: "team", Offset: 2, Blocks: doc[2:6], Prev: 1, Next: 3}, pages[1])
+	assert.Equal(t, Page{Number: 3, Title: "Jobs & Karriere", Slug: "jobs-karriere", Offset: 6, Blocks: doc[6:], Prev: 2}, pages[2])
+
+	pages = Paginate(doc, PaginateOptions{HeadingLevel: 2, MaxBlocks: 3})
+	assert.Len(t, pages, 4)
+	assert.Equal(t, []int{0, 2, 5, 6}, []int{pages[0].Offset, pages[1].Offset, pages[2].Offset, pages[3].Offset})
+	assert.Equal(t, "", pages[2].Title)
+
+	assert.Len(t, Paginate(doc, PaginateOptions{}), 1)
+	assert.Empty(t, Paginate(nil, PaginateOptions{MaxBlocks: 2}))
+}
+
+func TestSlug(t *testing.T) {
+	assert.Equal(t, "creme-brulee-rezept", Slug("Crème Brûlée — Rezept!"))
+	assert.Equal(t, "go-1-23", Slug("  Go 1.23 "))
+	assert.Equal(t, "", Slug("!?"))
+}
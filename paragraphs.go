@@ -0,0 +1,49 @@
+package blocks
+
+import (
+	"fmt"
+)
+
+// EmptyParagraph decides how paragraphs holding a single empty text are
+// rendered.
+type EmptyParagraph int
+
+const (
+	// EmptyParagraphBreak renders a <br />
+	EmptyParagraphBreak EmptyParagraph = iota
+	// EmptyParagraphNbsp renders a paragraph holding a non-breaking space
+	EmptyParagraphNbsp
+	// EmptyParagraphSkip renders nothing
+	EmptyParagraphSkip
+	// EmptyParagraphCollapse renders a run of empty paragraphs as a single
+	// <br />
+	EmptyParagraphCollapse
+)
+
+// WithEmptyParagraphs sets how empty paragraphs are rendered, the default is
+// EmptyParagraphBreak.
+func WithEmptyParagraphs(mode EmptyParagraph) Option {
+	return func(r *Renderer) {
+		r.emptyParagraphs = mode
+	}
+}
+
+func (b Block) emptyParagraph() bool {
+	return b.Type == BlockTypeParagraph && len(b.Children) == 1 && b.Children[0].EmptyText()
+}
+
+// collapsed reports whether the block at index i is an empty paragraph
+// following another one, which is dropped with EmptyParagraphCollapse.
+func (r *Renderer) collapsed(blocks []Block, i int) bool {
+	return r.emptyParagraphs == EmptyParagraphCollapse && i > 0 && blocks[i].emptyParagraph() && blocks[i-1].emptyParagraph()
+}
+
+func (r *Renderer) renderEmptyParagraph() string {
+	switch r.emptyParagraphs {
+	case EmptyParagraphNbsp:
+		return fmt.Sprintf("<p%s>&nbsp;</p>", r.attrs(ElementParagraph))
+	case EmptyParagraphSkip:
+		return ""
+	}
+	return "<br />"
+}
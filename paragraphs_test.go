@@ -0,0 +1,54 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEmptyParagraphs(t *testing.T) {
+
+	doc := []Block{paragraph("a"), paragraph(""), paragraph(""), paragraph("b"), paragraph("")}
+
+	assert.Equal(t, `<p>
+  a
+</p>
+<br />
+<br />
+<p>
+  b
+</p>
+<br />`, New().Render(doc))
+
+	assert.Equal(t, `<p>
+  a
+</p>
+<p>
+  &nbsp;
+</p>
+<p>
+  &nbsp;
+</p>
+<p>
+  b
+</p>
+<p>
+  &nbsp;
+</p>`, New(WithEmptyParagraphs(EmptyParagraphNbsp)).Render(doc))
+
+	assert.Equal(t, `<p>
+  a
+</p>
+<p>
+  b
+</p>`, New(WithEmptyParagraphs(EmptyParagraphSkip)).Render(doc))
+
+	assert.Equal(t, `<p>
+  a
+</p>
+<br />
+<p>
+  b
+</p>
+<br />`, New(WithEmptyParagraphs(EmptyParagraphCollapse)).Render(doc))
+}
@@ -0,0 +1,14 @@
+package blocks
+
+import (
+	"encoding/json"
+)
+
+// Parse decodes a strapi blocks payload.
+func Parse(raw []byte) ([]Block, error) {
+	var blocks []Block
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
@@ -0,0 +1,71 @@
+package blocks
+
+import (
+	"strings"
+)
+
+// RenderRange renders the top level blocks from index from up to, but not
+// including, index to. The range is clamped to the document, warnings keep
+// the paths of the whole document.
+func (r *Renderer) RenderRange(blocks []Block, from, to int) string {
+	r.begin()
+	if r.checkLimits(blocks) != nil {
+		return ""
+	}
+	blocks = r.transform(blocks)
+	defer r.recoverRender(blocks, nil)
+	from = max(from, 0)
+	to = min(to, len(blocks))
+	out := strings.Builder{}
+	for i := from; i < to; i++ {
+		out.WriteString(r.renderAt([]int{i}, blocks[i]))
+	}
+	return r.format(r.document(out.String()))
+}
+
+func RenderRange(blocks []Block, from, to int) string {
+	return New().RenderRange(blocks, from, to)
+}
+
+// RenderAt renders the single block the path points to, see Walk for the
+// path format. Nothing is rendered for paths outside the document.
+func (r *Renderer) RenderAt(blocks []Block, path []int) string {
+	r.begin()
+	if r.checkLimits(blocks) != nil {
+		return ""
+	}
+	blocks = r.transform(blocks)
+	defer r.recoverRender(blocks, nil)
+	b, ok := blockAt(blocks, path)
+	if !ok {
+		return ""
+	}
+	return r.format(r.document(r.renderAt(path, b)))
+}
+
+func RenderAt(blocks []Block, path []int) string {
+	return New().RenderAt(blocks, path)
+}
+
+func (r *Renderer) renderAt(path []int, b Block) string {
+	r.path = append(r.path[:0], path...)
+	out := r.renderBlock(b)
+	r.path = r.path[:0]
+	return out
+}
+
+// blockAt returns the block the path points to
+func blockAt(blocks []Block, path []int) (Block, bool) {
+	if len(path) == 0 {
+		return Block{}, false
+	}
+	var b Block
+	for _, i := range path {
+		if i < 0 || i >= len(blocks) {
+			return Block{}, false
+		}
+		b = blocks[i]
+		blocks = b.Children
+	}
+	return b, true
+}
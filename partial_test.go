@@ -0,0 +1,44 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRange(t *testing.T) {
+
+	doc := []Block{
+		paragraph("one"),
+		paragraph("two"),
+		{Type: "video"},
+		paragraph("four"),
+	}
+
+	assert.Equal(t, "<p>\n  two\n</p>\n<p>\n  four\n</p>", RenderRange(doc, 1, 2)+"\n"+RenderRange(doc, 3, 10))
+	assert.Equal(t, Render(doc), RenderRange(doc, -1, 10))
+	assert.Equal(t, "", RenderRange(doc, 3, 1))
+
+	r := New()
+	r.RenderRange(doc, 2, 3)
+	assert.Equal(t, []int{2}, r.Warnings()[0].Path)
+}
+
+func TestRenderAt(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		{Type: BlockTypeList, Format: ptr(string(ListFormatOrdered)), Children: []Block{
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeText, Text: ptr("first")}}},
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeImage}}},
+		}},
+	}
+
+	assert.Equal(t, "<li>\n  first\n</li>", RenderAt(doc, []int{1, 0}))
+	assert.Equal(t, "", RenderAt(doc, []int{1, 5}))
+	assert.Equal(t, "", RenderAt(doc, nil))
+
+	r := New()
+	assert.Equal(t, "<li>\n  missing image\n</li>", r.RenderAt(doc, []int{1, 1}))
+	assert.Equal(t, []int{1, 1, 0}, r.Warnings()[0].Path)
+}
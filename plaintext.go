@@ -0,0 +1,66 @@
+package blocks
+
+import (
+	"strings"
+)
+
+// PlainText returns the text content of the given blocks without any markup.
+// Top level blocks are separated by a blank line, annotations are left out.
+func PlainText(blocks []Block) string {
+	parts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		text := strings.TrimSpace(b.PlainText())
+		if text != "" && b.Type != BlockTypeAnnotation {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// PlainText returns the text content of the block and all of its children.
+func (b Block) PlainText() string {
+	if b.Type == BlockTypeText {
+		if b.Text == nil {
+			return ""
+		}
+		return *b.Text
+	}
+	if b.Type == BlockTypeMention && b.Mention != nil {
+		return "@" + b.Mention.Label()
+	}
+	out := strings.Builder{}
+	if b.Type == BlockTypeDetails && deref(b.Summary) != "" {
+		out.WriteString(*b.Summary)
+		if len(b.Children) > 0 {
+			out.WriteString("\n")
+		}
+	}
+	for i, c := range b.Children {
+		if c.Type == BlockTypeAnnotation {
+			continue
+		}
+		if i > 0 && !isInline(c.Type) {
+			out.WriteString("\n")
+		}
+		out.WriteString(c.PlainText())
+	}
+	return out.String()
+}
+
+// Words counts the whitespace separated words in the given blocks.
+func Words(blocks []Block) int {
+	count := 0
+	for _, b := range blocks {
+		count += len(strings.Fields(b.PlainText()))
+	}
+	return count
+}
+
+// isInline reports whether blocks of the type are part of the running text
+func isInline(t BlockType) bool {
+	switch t {
+	case BlockTypeText, BlockTypeLink, BlockTypeMention, BlockTypeAbbreviation, BlockTypeCrossReference, BlockTypeCitation, blockTypeMark:
+		return true
+	}
+	return false
+}
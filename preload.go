@@ -0,0 +1,123 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"strings"
+)
+
+// Preload is a resource hint, rendered as <link rel="preload"> in the head of
+// the page.
+type Preload struct {
+	URL string
+	// As is "image" or "font"
+	As   string
+	Type string
+	// ImageSrcset and ImageSizes match the srcset and sizes of the image
+	ImageSrcset string
+	ImageSizes  string
+	// FetchPriority is "high" for the likely largest contentful paint image
+	FetchPriority string
+}
+
+func (p Preload) String() string {
+	out := fmt.Sprintf(`<link rel="preload" href="%s" as="%s"`, html.EscapeString(p.URL), p.As)
+	if p.Type != "" {
+		out += fmt.Sprintf(` type="%s"`, html.EscapeString(p.Type))
+	}
+	if p.ImageSrcset != "" {
+		out += fmt.Sprintf(` imagesrcset="%s" imagesizes="%s"`, html.EscapeString(p.ImageSrcset), html.EscapeString(p.ImageSizes))
+	}
+	if p.FetchPriority != "" {
+		out += fmt.Sprintf(` fetchpriority="%s"`, p.FetchPriority)
+	}
+	if p.As == "font" {
+		// fonts are always fetched in cors mode
+		out += " crossorigin"
+	}
+	return out + ">"
+}
+
+// PreloadOptions configures Preloads.
+type PreloadOptions struct {
+	// Images is the number of images to preload, defaults to 1
+	Images int
+	// MinWidth skips images narrower than it, like icons and badges. Images
+	// without width are not skipped. Defaults to 200 pixels.
+	MinWidth int
+	// CodeFonts are preloaded if the document contains code, like the url of
+	// the monospace font of the site.
+	CodeFonts []string
+}
+
+// Preloads returns resource hints for the rendered document: the first
+// images of the document, the first with high fetch priority, and the code
+// fonts if code is shown. Images in running text count as well, the urls
+// match the rendered img tags.
+func (r *Renderer) Preloads(blocks []Block, opts PreloadOptions) []Preload {
+	if opts.Images <= 0 {
+		opts.Images = 1
+	}
+	if opts.MinWidth <= 0 {
+		opts.MinWidth = 200
+	}
+	images := []Preload{}
+	addImage := func(img Image, srcset, sizes string) {
+		if len(images) >= opts.Images || img.URL == "" || (img.Width > 0 && img.Width < opts.MinWidth) {
+			return
+		}
+		p := Preload{URL: r.imageSrc(img), As: "image", ImageSrcset: srcset, ImageSizes: sizes}
+		if len(images) == 0 {
+			p.FetchPriority = "high"
+		}
+		images = append(images, p)
+	}
+	code := false
+	Walk(r.transform(blocks), func(path []int, b Block) bool {
+		switch {
+		case b.Type == BlockTypeImage && b.Image != nil:
+			addImage(*b.Image, "", "")
+		case b.Type == BlockTypeGallery:
+			_, sizes := r.galleryGrid()
+			for _, img := range b.Images {
+				if srcset := Srcset(img); srcset != "" {
+					addImage(img, srcset, sizes)
+				} else {
+					addImage(img, "", "")
+				}
+			}
+		case b.Type == BlockTypeCode || b.HasModifier(ModifierCode):
+			code = true
+		}
+		return true
+	})
+
+	preloads := images
+	if code {
+		for _, font := range opts.CodeFonts {
+			preloads = append(preloads, Preload{URL: font, As: "font", Type: fontType(font)})
+		}
+	}
+	return preloads
+}
+
+func Preloads(blocks []Block, opts PreloadOptions) []Preload {
+	return New().Preloads(blocks, opts)
+}
+
+// fontType returns the mime type of the font by its extension
+func fontType(font string) string {
+	base, _, _ := strings.Cut(font, "?")
+	switch strings.ToLower(path.Ext(base)) {
+	case ".woff2":
+		return "font/woff2"
+	case ".woff":
+		return "font/woff"
+	case ".ttf":
+		return "font/ttf"
+	case ".otf":
+		return "font/otf"
+	}
+	return ""
+}
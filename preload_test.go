@@ -0,0 +1,34 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreloads(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeImage, Image: &Image{URL: "/badge.svg", Width: 80, Height: 20}},
+			{Type: BlockTypeText, Text: ptr("npm i"), Code: ptr(true)},
+		}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/hero.jpg", Width: 1600, Height: 900}},
+		{Type: BlockTypeGallery, Images: []Image{
+			{URL: "/a.jpg", Width: 1200, Formats: map[string]ImageFormat{"small": {URL: "/small_a.jpg", Width: 500}}},
+			{URL: "/b.jpg"},
+		}},
+	}
+
+	preloads := Preloads(doc, PreloadOptions{Images: 2, CodeFonts: []string{"/fonts/mono.woff2"}})
+	assert.Equal(t, []Preload{
+		{URL: "/hero.jpg", As: "image", FetchPriority: "high"},
+		{URL: "/a.jpg", As: "image", ImageSrcset: "/small_a.jpg 500w, /a.jpg 1200w", ImageSizes: "(max-width: 640px) 100vw, 33vw"},
+		{URL: "/fonts/mono.woff2", As: "font", Type: "font/woff2"},
+	}, preloads)
+	assert.Equal(t, `<link rel="preload" href="/hero.jpg" as="image" fetchpriority="high">`, preloads[0].String())
+	assert.Equal(t, `<link rel="preload" href="/a.jpg" as="image" imagesrcset="/small_a.jpg 500w, /a.jpg 1200w" imagesizes="(max-width: 640px) 100vw, 33vw">`, preloads[1].String())
+	assert.Equal(t, `<link rel="preload" href="/fonts/mono.woff2" as="font" type="font/woff2" crossorigin>`, preloads[2].String())
+
+	assert.Len(t, Preloads(doc[1:2], PreloadOptions{CodeFonts: []string{"/fonts/mono.woff2"}}), 1, "fonts only with code")
+}
@@ -0,0 +1,62 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const printHeadingStyle = "break-after: avoid; page-break-after: avoid;"
+const printFigureStyle = "break-inside: avoid; page-break-inside: avoid;"
+
+// WithPrint renders for printing, for "print article" pages. The urls of
+// links are written after the link text, images load eagerly, headings stay
+// with the following content and figures are not split across pages.
+// Interactive content is removed or expanded: iframes of html blocks are
+// dropped, details are open and code blocks have no copy button.
+func WithPrint() Option {
+	return func(r *Renderer) {
+		r.print = true
+	}
+}
+
+// elementAttributes returns the extra attributes of the element, in print
+// mode without the loading attributes of images.
+func (r *Renderer) elementAttributes(el Element) AttributeList {
+	if !r.print || el != ElementImage {
+		return r.attributes[el]
+	}
+	return slices.DeleteFunc(slices.Clone(r.attributes[el]), func(a Attribute) bool {
+		return a.Name == "loading"
+	})
+}
+
+// printedURL returns the url written after the link text, empty for relative
+// links and links showing their url as text.
+func printedURL(link, text string) string {
+	printed := ""
+	switch linkScheme(link) {
+	case "http", "https":
+		printed = link
+	case "mailto", "tel":
+		address, _, _ := strings.Cut(link[strings.Index(link, ":")+1:], "?")
+		if unescaped, err := url.PathUnescape(address); err == nil {
+			address = unescaped
+		}
+		printed = address
+	}
+	text = strings.TrimSpace(text)
+	if printed == "" || printed == text || strings.TrimSuffix(printed, "/") == text {
+		return ""
+	}
+	return printed
+}
+
+// printURL renders the printed url of the link, the class is set with
+// ElementPrintURL.
+func (r *Renderer) printURL(link, printed string) string {
+	_, printed = r.obfuscateEmail(link, html.EscapeString(printed))
+	return fmt.Sprintf(" <span%s>(%s)</span>", r.attrs(ElementPrintURL), printed)
+}
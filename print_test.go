@@ -0,0 +1,72 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrint(t *testing.T) {
+
+	embed := `<p>Video</p><iframe src="https://www.youtube-nocookie.com/embed/abc"></iframe>`
+	doc := []Block{
+		heading(2, "Sources"),
+		{Type: BlockTypeParagraph, Children: []Block{
+			link("https://example.com/guide", "the guide"),
+			{Type: BlockTypeText, Text: ptr(", ")},
+			link("mailto:team@example.com?subject=Hi", "mail us"),
+			{Type: BlockTypeText, Text: ptr(", ")},
+			link("https://example.com/", "https://example.com"),
+			{Type: BlockTypeText, Text: ptr(", ")},
+			link("/about", "about"),
+		}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", AlternativeText: "a", Caption: "A"}},
+		{Type: BlockTypeDetails, Summary: ptr("More"), Children: []Block{paragraph("hidden")}},
+		{Type: BlockTypeHTML, HTML: &embed},
+	}
+
+	r := New(WithPrint(), WithRawHTML(nil), WithAttributes(Attributes{ElementImage: {{Name: "loading", Value: "lazy"}}}))
+	assert.Equal(t, `<h2 style="break-after: avoid; page-break-after: avoid;">
+  Sources
+</h2>
+<p>
+  <a href="https://example.com/guide">
+    the guide
+  </a>
+  <span class="print-url">
+    (https://example.com/guide)
+  </span>
+  ,
+  <a href="mailto:team@example.com?subject=Hi">
+    mail us
+  </a>
+  <span class="print-url">
+    (team@example.com)
+  </span>
+  ,
+  <a href="https://example.com/">
+    https://example.com
+  </a>
+  ,
+  <a href="/about">
+    about
+  </a>
+</p>
+<figure style="break-inside: avoid; page-break-inside: avoid;">
+  <img src="/a.png" alt="a" loading="eager" />
+  <figcaption>
+    A
+  </figcaption>
+</figure>
+<details open>
+  <summary>
+    More
+  </summary>
+  <p>
+    hidden
+  </p>
+</details>
+<p>
+  Video
+</p>`, r.Render(doc))
+}
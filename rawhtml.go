@@ -0,0 +1,35 @@
+package blocks
+
+// WithRawHTML renders html blocks, carrying html snippets of trusted editors
+// like embeds. With a nil sanitizer the html is emitted verbatim, otherwise
+// it is cleaned up by the sanitizer first, for example with DefaultAllowlist.
+// Without the option html blocks are rendered as unsupported blocks.
+func WithRawHTML(sanitizer Sanitizer) Option {
+	return func(r *Renderer) {
+		r.rawHTML = true
+		r.sanitizer = sanitizer
+	}
+}
+
+func (r *Renderer) RenderHTML(b Block) string {
+	if !r.rawHTMLEnabled() {
+		return r.placeholders.UnsupportedBlock
+	}
+	clean := func(fragment string) string { return fragment }
+	if r.sanitizer != nil {
+		clean = r.sanitizer.Sanitize
+	}
+	if r.embeds != nil || r.print {
+		return r.embedIframes(deref(b.HTML), clean)
+	}
+	return clean(deref(b.HTML))
+}
+
+// rawHTMLEnabled reports whether html blocks are rendered, recording a
+// warning otherwise
+func (r *Renderer) rawHTMLEnabled() bool {
+	if !r.rawHTML {
+		r.warn(WarningUnsupportedBlock, "html blocks are not enabled, see WithRawHTML")
+	}
+	return r.rawHTML
+}
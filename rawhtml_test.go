@@ -0,0 +1,27 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHTML(t *testing.T) {
+
+	raw := []byte(`[{"type": "html", "html": "<div class=\"embed\" onclick=\"track()\">hi</div>", "children": []}]`)
+	assert.Empty(t, Validate(raw))
+	doc, err := Parse(raw)
+	assert.NoError(t, err)
+
+	out, warnings := New().RenderWithWarnings(doc)
+	assert.Equal(t, DefaultPlaceholders.UnsupportedBlock, out)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, WarningUnsupportedBlock, warnings[0].Code)
+
+	assert.Equal(t, `<div class="embed" onclick="track()">
+  hi
+</div>`, New(WithRawHTML(nil)).Render(doc))
+	assert.Equal(t, `<div class="embed">
+  hi
+</div>`, New(WithRawHTML(DefaultAllowlist)).Render(doc))
+}
@@ -0,0 +1,131 @@
+package blocks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithReactCompat renders the same markup as the default components of
+// @strapi/blocks-react-renderer with renderToStaticMarkup, so server side
+// rendered html matches the html the react renderer hydrates. The output is
+// not formatted, text is escaped the way react does it, empty paragraphs
+// become <br/> and unknown block types render nothing. Classes, themes and
+// custom renderers are not applied, limits and transformers still are.
+//
+// The react renderer applies modifiers in the key order of the json, which
+// is not kept by Block, the DefaultModifierOrder is used instead, which is
+// the order the strapi editor writes them in.
+func WithReactCompat() Option {
+	return func(r *Renderer) {
+		r.reactCompat = true
+	}
+}
+
+// reactEscaper escapes like escapeTextForBrowser of react-dom
+var reactEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&#x27;",
+)
+
+func reactRender(blocks []Block) string {
+	out := strings.Builder{}
+	for _, b := range blocks {
+		reactBlock(&out, b)
+	}
+	return out.String()
+}
+
+func reactBlock(out *strings.Builder, b Block) {
+	switch b.Type {
+	case BlockTypeText:
+		text := reactText(deref(b.Text))
+		for _, m := range DefaultModifierOrder {
+			if b.HasModifier(m) {
+				text = reactModifier(m, text)
+			}
+		}
+		out.WriteString(text)
+		return
+	case BlockTypeImage:
+		if b.Image == nil {
+			return
+		}
+		fmt.Fprintf(out, `<img src="%s"`, reactEscaper.Replace(b.Image.URL))
+		if b.Image.AlternativeText != "" {
+			fmt.Fprintf(out, ` alt="%s"`, reactEscaper.Replace(b.Image.AlternativeText))
+		}
+		out.WriteString("/>")
+		return
+	case BlockTypeParagraph:
+		if len(b.Children) == 1 && b.Children[0].Type == BlockTypeText && deref(b.Children[0].Text) == "" {
+			out.WriteString("<br/>")
+			return
+		}
+	case BlockTypeCode:
+		fmt.Fprintf(out, "<pre><code>%s</code></pre>", reactEscaper.Replace(b.PlainText()))
+		return
+	}
+
+	open, close, ok := reactTags(b)
+	if !ok {
+		return
+	}
+	out.WriteString(open)
+	for _, c := range b.Children {
+		reactBlock(out, c)
+	}
+	out.WriteString(close)
+}
+
+// reactTags returns the tags of the default react block components
+func reactTags(b Block) (string, string, bool) {
+	switch b.Type {
+	case BlockTypeParagraph:
+		return "<p>", "</p>", true
+	case BlockTypeQuote:
+		return "<blockquote>", "</blockquote>", true
+	case BlockTypeHeading:
+		if b.Level == nil || *b.Level < 1 || *b.Level > 6 {
+			return "", "", false
+		}
+		return fmt.Sprintf("<h%d>", *b.Level), fmt.Sprintf("</h%d>", *b.Level), true
+	case BlockTypeLink:
+		if b.URL == nil {
+			return "<a>", "</a>", true
+		}
+		return fmt.Sprintf(`<a href="%s">`, reactEscaper.Replace(*b.URL)), "</a>", true
+	case BlockTypeList:
+		if deref(b.Format) == string(ListFormatOrdered) {
+			return "<ol>", "</ol>", true
+		}
+		return "<ul>", "</ul>", true
+	case BlockTypeListItem:
+		return "<li>", "</li>", true
+	}
+	return "", "", false
+}
+
+// reactText escapes the text and replaces line breaks with <br/>
+func reactText(text string) string {
+	text = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(text)
+	return strings.ReplaceAll(reactEscaper.Replace(text), "\n", "<br/>")
+}
+
+func reactModifier(m Modifier, content string) string {
+	switch m {
+	case ModifierBold:
+		return "<strong>" + content + "</strong>"
+	case ModifierItalic:
+		return "<em>" + content + "</em>"
+	case ModifierUnderline:
+		return `<span style="text-decoration:underline">` + content + "</span>"
+	case ModifierStrikeThrough:
+		return "<del>" + content + "</del>"
+	case ModifierCode:
+		return "<code>" + content + "</code>"
+	}
+	return content
+}
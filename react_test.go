@@ -0,0 +1,37 @@
+package blocks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// the fixtures in testdata/react are the renderToStaticMarkup output of the
+// official react renderer with its default components, generated with
+// testdata/react/generator in the version of its package.json
+func TestWithReactCompat(t *testing.T) {
+	files, err := filepath.Glob("testdata/react/*.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+
+	r := New(WithReactCompat())
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			raw, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			doc, err := Parse(raw)
+			assert.NoError(t, err)
+			want, err := os.ReadFile(strings.TrimSuffix(file, ".json") + ".html")
+			assert.NoError(t, err)
+			assert.Equal(t, string(want), r.Render(doc))
+		})
+	}
+}
+
+func TestWithReactCompat_ignoresClasses(t *testing.T) {
+	r := New(WithReactCompat(), WithTheme(ThemeTailwindProse), WithClasses(Classes{ElementParagraph: "lead"}))
+	assert.Equal(t, "<p>text</p>", r.Render([]Block{paragraph("text")}))
+}
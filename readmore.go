@@ -0,0 +1,86 @@
+package blocks
+
+import (
+	"strings"
+)
+
+// ReadMoreMarker reports whether a top level block separates the teaser from
+// the body of a document.
+type ReadMoreMarker func(b Block) bool
+
+// ReadMoreText marks paragraphs consisting of the given text only, like
+// "<!--more-->".
+func ReadMoreText(text string) ReadMoreMarker {
+	return func(b Block) bool {
+		return b.Type == BlockTypeParagraph && strings.TrimSpace(b.PlainText()) == text
+	}
+}
+
+// ReadMoreBlock marks blocks of a dedicated type.
+func ReadMoreBlock(t BlockType) ReadMoreMarker {
+	return func(b Block) bool {
+		return b.Type == t
+	}
+}
+
+var DefaultReadMoreMarker = ReadMoreText("<!--more-->")
+
+// WithReadMore sets the marker of RenderTeaser and RenderBody, a nil marker
+// uses DefaultReadMoreMarker. The marker itself is never rendered.
+func WithReadMore(marker ReadMoreMarker) Option {
+	if marker == nil {
+		marker = DefaultReadMoreMarker
+	}
+	return func(r *Renderer) {
+		r.readMore = marker
+	}
+}
+
+// SplitReadMore splits the document at the first marker. Without a marker
+// the whole document is the teaser and the body.
+func SplitReadMore(blocks []Block, marker ReadMoreMarker) (teaser, body []Block, found bool) {
+	i := readMoreIndex(blocks, marker)
+	if i < 0 {
+		return blocks, blocks, false
+	}
+	return blocks[:i], blocks[i+1:], true
+}
+
+func readMoreIndex(blocks []Block, marker ReadMoreMarker) int {
+	for i, b := range blocks {
+		if marker(b) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RenderTeaser renders the blocks before the read more marker, or the whole
+// document if there is none. The marker is looked up before transformers are
+// applied.
+func (r *Renderer) RenderTeaser(blocks []Block) string {
+	i := readMoreIndex(blocks, r.readMoreMarker())
+	if i < 0 {
+		return r.Render(blocks)
+	}
+	return r.RenderRange(blocks, 0, i)
+}
+
+// RenderBody renders the blocks after the read more marker, or the whole
+// document if there is none. Warnings keep the paths of the whole document.
+func (r *Renderer) RenderBody(blocks []Block) string {
+	i := readMoreIndex(blocks, r.readMoreMarker())
+	return r.RenderRange(blocks, i+1, len(blocks))
+}
+
+func (r *Renderer) readMoreMarker() ReadMoreMarker {
+	if r.readMore == nil {
+		return DefaultReadMoreMarker
+	}
+	return r.readMore
+}
+
+// isReadMore reports whether the block is a marker which is not rendered
+func (r *Renderer) isReadMore(b Block) bool {
+	return r.readMore != nil && len(r.path) == 1 && r.readMore(b)
+}
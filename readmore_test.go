@@ -0,0 +1,51 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTeaser(t *testing.T) {
+
+	doc := []Block{
+		paragraph("teaser"),
+		paragraph("<!--more-->"),
+		paragraph("body"),
+		{Type: BlockTypeImage},
+	}
+
+	r := New()
+	assert.Equal(t, "<p>\n  teaser\n</p>", r.RenderTeaser(doc))
+	assert.Equal(t, "<p>\n  body\n</p>\nmissing image", r.RenderBody(doc))
+	assert.Equal(t, []int{3}, r.Warnings()[0].Path)
+
+	assert.Equal(t, Render(doc[2:]), r.RenderTeaser(doc[2:]))
+	assert.Equal(t, Render(doc[2:]), r.RenderBody(doc[2:]))
+
+	r = New(WithReadMore(nil))
+	assert.Equal(t, "<p>\n  teaser\n</p>\n<p>\n  body\n</p>", r.Render(doc[:3]))
+}
+
+func TestSplitReadMore(t *testing.T) {
+
+	doc := []Block{
+		paragraph("teaser"),
+		{Type: "read-more"},
+		paragraph("body"),
+	}
+
+	teaser, body, found := SplitReadMore(doc, ReadMoreBlock("read-more"))
+	assert.True(t, found)
+	assert.Equal(t, doc[:1], teaser)
+	assert.Equal(t, doc[2:], body)
+
+	teaser, body, found = SplitReadMore(doc, DefaultReadMoreMarker)
+	assert.False(t, found)
+	assert.Equal(t, doc, teaser)
+	assert.Equal(t, doc, body)
+
+	r := New(WithReadMore(ReadMoreBlock("read-more")))
+	assert.Equal(t, "<p>\n  body\n</p>", r.RenderBody(doc))
+	assert.Empty(t, r.Warnings())
+}
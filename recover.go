@@ -0,0 +1,96 @@
+package blocks
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// WithRecover recovers panics of block renderers, for example of custom
+// renderers or WithNilSafe(false). The render fails with a RenderError instead
+// of taking down the program: Render returns an empty string and records a
+// warning, RenderTo returns the error. All render methods recover, the ones
+// without error result return their zero value.
+func WithRecover(enabled bool) Option {
+	return func(r *Renderer) {
+		r.recoverPanics = enabled
+	}
+}
+
+// RenderError is a recovered panic of the block renderer of the block at
+// Path.
+type RenderError struct {
+	Path  []int
+	Type  BlockType
+	Panic any
+	Stack []byte
+}
+
+func (e *RenderError) Error() string {
+	return fmt.Sprintf("blocks: rendering %s block %v panicked: %v", e.Type, e.Path, e.Panic)
+}
+
+// recoverRender turns a panic into a RenderError with WithRecover, it is
+// deferred by every render method. The path of the panicking block is still
+// on the path stack, as it is not unwound on panics, and the type is looked
+// up in the blocks if given. err may be nil for methods without error result.
+func (r *Renderer) recoverRender(blocks []Block, err *error) {
+	if !r.recoverPanics {
+		return
+	}
+	p := recover()
+	if p == nil {
+		return
+	}
+	e := &RenderError{Path: append([]int{}, r.path...), Panic: p, Stack: debug.Stack()}
+	if b, ok := blockAt(blocks, e.Path); ok {
+		e.Type = b.Type
+	}
+	r.warn(WarningRenderPanic, "%s", e)
+	if err != nil {
+		*err = e
+	}
+}
+
+// WithErrorBoundaries recovers panics of single blocks and renders the
+// fallback in place of the failed block, the rest of the document is rendered
+// as usual. A nil fallback renders Placeholders.FailedBlock. The errors are
+// returned by Errors and recorded as warnings.
+func WithErrorBoundaries(fallback func(err *RenderError) string) Option {
+	return func(r *Renderer) {
+		r.errorBoundaries = true
+		r.failedBlock = fallback
+	}
+}
+
+// Errors returns the blocks which failed during the last render, see
+// WithErrorBoundaries.
+func (r *Renderer) Errors() []*RenderError {
+	return r.errors
+}
+
+// errorBoundary recovers a panic of the block and restores the render state
+// the block started with.
+func (r *Renderer) errorBoundary(ctx context.Context, b Block, depth int, out *string) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	e := r.blockFailed(ctx, b, depth, p)
+	if r.failedBlock != nil {
+		*out = r.failedBlock(e)
+	} else {
+		*out = r.placeholders.FailedBlock
+	}
+}
+
+// blockFailed restores the render state after the panic p of the block and
+// records the error.
+func (r *Renderer) blockFailed(ctx context.Context, b Block, depth int, p any) *RenderError {
+	r.path = r.path[:depth]
+	r.ctx = ctx
+	e := &RenderError{Path: append([]int{}, r.path...), Type: b.Type, Panic: p, Stack: debug.Stack()}
+	r.errors = append(r.errors, e)
+	r.warn(WarningRenderPanic, "%s", e)
+	return e
+}
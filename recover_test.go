@@ -0,0 +1,152 @@
+package blocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type panickingLink struct{}
+
+func (panickingLink) RenderLink(b Block) string {
+	panic("link renderer broke")
+}
+
+func TestWithRecover(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		{Type: BlockTypeParagraph, Children: []Block{link("/a", "a")}},
+	}
+
+	r := New(WithRecover(true))
+	r.LinkRenderer = panickingLink{}
+
+	assert.Equal(t, "", r.Render(doc))
+	assert.Equal(t, WarningRenderPanic, r.Warnings()[0].Code)
+	assert.Equal(t, []int{1, 0}, r.Warnings()[0].Path)
+
+	err := r.RenderTo(&bytes.Buffer{}, doc)
+	assert.EqualError(t, err, "blocks: rendering link block [1 0] panicked: link renderer broke")
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	assert.Equal(t, BlockTypeLink, renderErr.Type)
+	assert.NotEmpty(t, renderErr.Stack)
+
+	assert.Equal(t, "<p>\n  intro\n</p>", r.Render(doc[:1]))
+	assert.Empty(t, r.Warnings())
+
+	r = r.With(WithRecover(false))
+	assert.Panics(t, func() { r.Render(doc) })
+}
+
+func TestWithErrorBoundaries(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("see ")},
+			link("/a", "a"),
+		}},
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("nil ")},
+			{Type: BlockTypeText},
+		}},
+		paragraph("outro"),
+	}
+
+	r := New(WithNilSafe(false), WithErrorBoundaries(func(err *RenderError) string {
+		return fmt.Sprintf("<!-- %s failed -->", err.Type)
+	}))
+	r.LinkRenderer = panickingLink{}
+
+	assert.Equal(t, `<p>
+  intro
+</p>
+<p>
+  see
+  <!-- link failed -->
+</p>
+<p>
+  nil
+  <!-- text failed -->
+</p>
+<p>
+  outro
+</p>`, r.Render(doc))
+
+	assert.Len(t, r.Errors(), 2)
+	assert.Equal(t, []int{1, 1}, r.Errors()[0].Path)
+	assert.Equal(t, "link renderer broke", r.Errors()[0].Panic)
+	assert.Equal(t, []int{2, 1}, r.Errors()[1].Path)
+	assert.Equal(t, []int{2, 1}, r.Warnings()[1].Path)
+
+	r = New(WithErrorBoundaries(nil))
+	r.LinkRenderer = panickingLink{}
+	assert.Equal(t, "<p>\n  see\n</p>", r.Render(doc[1:2]))
+
+	r.Render(doc[:1])
+	assert.Empty(t, r.Errors())
+}
+
+func TestWithRecover_EntryPoints(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		{Type: BlockTypeParagraph, Children: []Block{link("/a", "a")}},
+	}
+	r := New(WithRecover(true))
+	r.LinkRenderer = panickingLink{}
+	recovered := func(name string) {
+		assert.Len(t, r.Warnings(), 1, name)
+		assert.Equal(t, WarningRenderPanic, r.Warnings()[0].Code, name)
+		assert.Equal(t, []int{1, 0}, r.Warnings()[0].Path, name)
+	}
+
+	assert.Equal(t, "", r.RenderRange(doc, 0, 2))
+	recovered("RenderRange")
+	assert.Equal(t, "", r.RenderAt(doc, []int{1}))
+	recovered("RenderAt")
+	assert.Nil(t, r.RenderNodes(doc))
+	recovered("RenderNodes")
+	assert.Nil(t, r.RenderSections(doc, 2))
+	recovered("RenderSections")
+	assert.Nil(t, r.RenderFields(map[string][]Block{"body": doc}))
+	recovered("RenderFields")
+
+	raw, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	err = r.RenderStream(&bytes.Buffer{}, bytes.NewReader(raw))
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	recovered("RenderStream")
+
+	rec := httptest.NewRecorder()
+	err = r.ServeSSE(rec, httptest.NewRequest(http.MethodGet, "/", nil), doc)
+	assert.ErrorAs(t, err, &renderErr)
+	assert.Equal(t, BlockTypeLink, renderErr.Type)
+	recovered("ServeSSE")
+}
+
+func TestWithRecover_EndsSpan(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	r := New(WithRecover(true), WithTracerProvider(tp))
+	r.LinkRenderer = panickingLink{}
+
+	r.Render([]Block{{Type: BlockTypeParagraph, Children: []Block{link("/a", "a")}}})
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("blocks.warnings", 1))
+}
@@ -0,0 +1,46 @@
+package blocks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ReplaceOptions configures Replace and ReplaceRegexp.
+type ReplaceOptions struct {
+	// URLs replaces in link urls as well
+	URLs bool
+}
+
+// Replace replaces all occurrences of old in the texts of the document,
+// the modifiers of the texts are kept.
+func Replace(old, new string, opts ReplaceOptions) Transformer {
+	return replaceFunc(func(s string) string {
+		return strings.ReplaceAll(s, old, new)
+	}, opts)
+}
+
+// ReplaceRegexp replaces all matches of re in the texts of the document,
+// $1 in repl refers to submatches like in regexp.Regexp.ReplaceAllString.
+func ReplaceRegexp(re *regexp.Regexp, repl string, opts ReplaceOptions) Transformer {
+	return replaceFunc(func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	}, opts)
+}
+
+func replaceFunc(replace func(string) string, opts ReplaceOptions) Transformer {
+	return TransformerFunc(func(blocks []Block) []Block {
+		// the mapping never fails
+		out, _ := mapBlocks(blocks, func(_ []int, b Block) (Block, error) {
+			if b.Type == BlockTypeText && b.Text != nil {
+				text := replace(*b.Text)
+				b.Text = &text
+			}
+			if b.Type == BlockTypeLink && b.URL != nil && opts.URLs {
+				url := replace(*b.URL)
+				b.URL = &url
+			}
+			return b, nil
+		})
+		return out
+	})
+}
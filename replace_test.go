@@ -0,0 +1,36 @@
+package blocks
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplace(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("Acme rocks, "), Bold: ptr(true)},
+			link("https://acme.com/acme", "visit Acme"),
+		}},
+	}
+
+	out := Replace("Acme", "Globex", ReplaceOptions{}).Transform(doc)
+	assert.Equal(t, "Globex rocks, ", *out[0].Children[0].Text)
+	assert.True(t, *out[0].Children[0].Bold)
+	assert.Equal(t, "visit Globex", *out[0].Children[1].Children[0].Text)
+	assert.Equal(t, "https://acme.com/acme", *out[0].Children[1].URL)
+	assert.Equal(t, "Acme rocks, ", *doc[0].Children[0].Text)
+
+	out = Replace("acme", "globex", ReplaceOptions{URLs: true}).Transform(doc)
+	assert.Equal(t, "https://globex.com/globex", *out[0].Children[1].URL)
+	assert.Equal(t, "Acme rocks, ", *out[0].Children[0].Text)
+}
+
+func TestReplaceRegexp(t *testing.T) {
+
+	doc := []Block{paragraph("© {{year}} by {{ author }}")}
+	r := New(WithTransformers(ReplaceRegexp(regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`), "[$1]", ReplaceOptions{})))
+	assert.Equal(t, "<p>\n  © [year] by [author]\n</p>", r.Render(doc))
+}
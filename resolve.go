@@ -0,0 +1,144 @@
+package blocks
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// EntityRef references a strapi entry instead of a site url.
+type EntityRef struct {
+	// Collection is the singular api name, for example article
+	Collection string
+	// ID is the id or document id of the entry
+	ID string
+}
+
+// ParseEntityRef parses entity links in the strapi://article/42 style and
+// content manager urls like /admin/content-manager/collection-types/api::article.article/42.
+func ParseEntityRef(link string) (EntityRef, bool) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return EntityRef{}, false
+	}
+	if u.Scheme == "strapi" {
+		id := strings.Trim(u.Path, "/")
+		if u.Host == "" || id == "" || strings.Contains(id, "/") {
+			return EntityRef{}, false
+		}
+		return EntityRef{Collection: u.Host, ID: id}, true
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 5 || parts[0] != "admin" || parts[1] != "content-manager" || parts[2] != "collection-types" {
+		return EntityRef{}, false
+	}
+	// api::article.article
+	uid := strings.TrimPrefix(parts[3], "api::")
+	collection, _, _ := strings.Cut(uid, ".")
+	if collection == "" || parts[4] == "" {
+		return EntityRef{}, false
+	}
+	return EntityRef{Collection: collection, ID: parts[4]}, true
+}
+
+// LinkResolver maps entity references to site routes.
+type LinkResolver interface {
+	ResolveLink(ref EntityRef) (string, bool)
+}
+
+type LinkResolverFunc func(ref EntityRef) (string, bool)
+
+func (f LinkResolverFunc) ResolveLink(ref EntityRef) (string, bool) {
+	return f(ref)
+}
+
+// WithLinkResolver resolves entity links at render time. Links that cannot be
+// resolved point to the fallback url and a warning is recorded.
+func WithLinkResolver(res LinkResolver, fallback string) Option {
+	return func(r *Renderer) {
+		r.linkResolver = res
+		r.linkFallback = fallback
+	}
+}
+
+// LinkTitleResolver is implemented by link resolvers that also know the title
+// of the referenced entry, like the title of an article. It titles entity
+// links without a title of their own.
+type LinkTitleResolver interface {
+	ResolveLinkTitle(ref EntityRef) (string, bool)
+}
+
+func (r *Renderer) linkTitle(link string) string {
+	res, ok := r.linkResolver.(LinkTitleResolver)
+	if !ok {
+		return ""
+	}
+	ref, ok := ParseEntityRef(link)
+	if !ok {
+		return ""
+	}
+	title, _ := res.ResolveLinkTitle(ref)
+	return title
+}
+
+// CachedLinkResolver remembers the results of res, including the misses. It
+// is safe for concurrent use, so it can be shared between renderers.
+func CachedLinkResolver(res LinkResolver) LinkResolver {
+	return &cachedLinkResolver{res: res, cache: map[EntityRef]cachedLink{}, titles: map[EntityRef]cachedLink{}}
+}
+
+type cachedLink struct {
+	value string
+	ok    bool
+}
+
+type cachedLinkResolver struct {
+	res    LinkResolver
+	mu     sync.Mutex
+	cache  map[EntityRef]cachedLink
+	titles map[EntityRef]cachedLink
+}
+
+func (c *cachedLinkResolver) ResolveLink(ref EntityRef) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if l, ok := c.cache[ref]; ok {
+		return l.value, l.ok
+	}
+	url, ok := c.res.ResolveLink(ref)
+	c.cache[ref] = cachedLink{value: url, ok: ok}
+	return url, ok
+}
+
+func (r *Renderer) resolveLink(link string) string {
+	if r.linkResolver == nil {
+		return link
+	}
+	ref, ok := ParseEntityRef(link)
+	if !ok {
+		return link
+	}
+	if resolved, ok := r.linkResolver.ResolveLink(ref); ok {
+		return resolved
+	}
+	r.warn(WarningUnresolvedLink, "cannot resolve link to %s %s", ref.Collection, ref.ID)
+	return r.linkFallback
+}
+
+// ResolveLinkTitle caches the titles of resolvers implementing
+// LinkTitleResolver.
+func (c *cachedLinkResolver) ResolveLinkTitle(ref EntityRef) (string, bool) {
+	res, ok := c.res.(LinkTitleResolver)
+	if !ok {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if l, ok := c.titles[ref]; ok {
+		return l.value, l.ok
+	}
+	title, ok := res.ResolveLinkTitle(ref)
+	c.titles[ref] = cachedLink{value: title, ok: ok}
+	return title, ok
+}
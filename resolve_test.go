@@ -0,0 +1,107 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEntityRef(t *testing.T) {
+
+	ref, ok := ParseEntityRef("strapi://article/42")
+	assert.True(t, ok)
+	assert.Equal(t, EntityRef{Collection: "article", ID: "42"}, ref)
+
+	ref, ok = ParseEntityRef("/admin/content-manager/collection-types/api::article.article/abc123")
+	assert.True(t, ok)
+	assert.Equal(t, EntityRef{Collection: "article", ID: "abc123"}, ref)
+
+	_, ok = ParseEntityRef("https://example.com/article/42")
+	assert.False(t, ok)
+	_, ok = ParseEntityRef("strapi://article")
+	assert.False(t, ok)
+}
+
+func TestWithLinkResolver(t *testing.T) {
+
+	calls := 0
+	res := CachedLinkResolver(LinkResolverFunc(func(ref EntityRef) (string, bool) {
+		calls++
+		if ref.ID == "42" {
+			return "/articles/the-answer", true
+		}
+		return "", false
+	}))
+
+	r := New(WithLinkResolver(res, "/404"))
+	out := r.Render([]Block{
+		link("strapi://article/42", "answer"),
+		link("strapi://article/42", "again"),
+		link("strapi://article/7", "gone"),
+		link("https://example.com", "external"),
+	})
+
+	assert.Equal(t, `<a href="/articles/the-answer">
+  answer
+</a>
+<a href="/articles/the-answer">
+  again
+</a>
+<a href="/404">
+  gone
+</a>
+<a href="https://example.com">
+  external
+</a>`, out)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []Warning{{Code: WarningUnresolvedLink, Message: "cannot resolve link to article 7", Path: []int{2}}}, r.Warnings())
+}
+
+type titledResolver struct {
+	LinkResolverFunc
+	calls int
+}
+
+func (t *titledResolver) ResolveLinkTitle(ref EntityRef) (string, bool) {
+	t.calls++
+	if ref.ID == "42" {
+		return `The "answer"`, true
+	}
+	return "", false
+}
+
+func TestLinkTitles(t *testing.T) {
+
+	res := &titledResolver{LinkResolverFunc: func(ref EntityRef) (string, bool) {
+		return "/" + ref.Collection + "/" + ref.ID, true
+	}}
+	titled := link("strapi://article/1", "own")
+	titled.Title = ptr("Own title")
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		link("strapi://article/42", "resolved"),
+		link("strapi://article/42", "again"),
+		link("strapi://article/7", "untitled"),
+		titled,
+		link("https://example.com", "external"),
+	}}}
+
+	r := New(WithLinkResolver(CachedLinkResolver(res), "/404"))
+	assert.Equal(t, `<p>
+  <a href="/article/42" title="The &#34;answer&#34;">
+    resolved
+  </a>
+  <a href="/article/42" title="The &#34;answer&#34;">
+    again
+  </a>
+  <a href="/article/7">
+    untitled
+  </a>
+  <a href="/article/1" title="Own title">
+    own
+  </a>
+  <a href="https://example.com">
+    external
+  </a>
+</p>`, r.Render(doc))
+	assert.Equal(t, 2, res.calls, "titles are cached")
+}
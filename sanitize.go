@@ -0,0 +1,170 @@
+package blocks
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Sanitizer cleans up untrusted html.
+type Sanitizer interface {
+	Sanitize(fragment string) string
+}
+
+type SanitizerFunc func(fragment string) string
+
+func (f SanitizerFunc) Sanitize(fragment string) string {
+	return f(fragment)
+}
+
+// Allowlist is a Sanitizer keeping only the allowed elements and attributes.
+// Other elements are removed, their text is kept, except for elements like
+// script and style that are removed with their content. Comments are
+// removed. Links keeping a target get rel="noopener noreferrer".
+type Allowlist struct {
+	// Elements maps the allowed elements to their allowed attributes, the
+	// attributes of "*" are allowed on all elements.
+	Elements map[string][]string
+	// URLSchemes are the schemes allowed in href and src attributes, relative
+	// urls are always allowed.
+	URLSchemes []string
+}
+
+// DefaultAllowlist allows the formatting elements of rich text. Ids are not
+// allowed, they could clobber globals of scripts and collide with the ids of
+// headings and figures.
+var DefaultAllowlist = Allowlist{
+	Elements: map[string][]string{
+		"*":          {"class", "title", "lang", "dir"},
+		"a":          {"href", "rel", "target"},
+		"img":        {"src", "alt", "width", "height", "loading"},
+		"p":          nil,
+		"br":         nil,
+		"hr":         nil,
+		"h1":         nil,
+		"h2":         nil,
+		"h3":         nil,
+		"h4":         nil,
+		"h5":         nil,
+		"h6":         nil,
+		"ul":         nil,
+		"ol":         {"start", "reversed"},
+		"li":         nil,
+		"dl":         nil,
+		"dt":         nil,
+		"dd":         nil,
+		"blockquote": {"cite"},
+		"pre":        nil,
+		"code":       nil,
+		"strong":     nil,
+		"b":          nil,
+		"em":         nil,
+		"i":          nil,
+		"u":          nil,
+		"s":          nil,
+		"del":        nil,
+		"ins":        nil,
+		"mark":       nil,
+		"sub":        nil,
+		"sup":        nil,
+		"small":      nil,
+		"kbd":        nil,
+		"abbr":       nil,
+		"span":       nil,
+		"div":        nil,
+		"figure":     nil,
+		"figcaption": nil,
+		"table":      nil,
+		"thead":      nil,
+		"tbody":      nil,
+		"tr":         nil,
+		"th":         {"colspan", "rowspan", "scope"},
+		"td":         {"colspan", "rowspan"},
+	},
+	URLSchemes: []string{"http", "https", "mailto", "tel"},
+}
+
+// dropped elements are removed with their content
+var dropped = []string{"script", "style", "template", "iframe", "object", "embed", "noscript", "noembed", "textarea"}
+
+func (a Allowlist) Sanitize(fragment string) string {
+	out := strings.Builder{}
+	z := html.NewTokenizer(strings.NewReader(fragment))
+	skip := ""
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// the fragment is read from a string, so the error is io.EOF
+			return out.String()
+		}
+		t := z.Token()
+		if skip != "" {
+			if tt == html.EndTagToken && t.Data == skip {
+				skip = ""
+			}
+			continue
+		}
+		switch tt {
+		case html.TextToken:
+			out.WriteString(t.String())
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if slices.Contains(dropped, t.Data) {
+				if tt == html.StartTagToken {
+					skip = t.Data
+				}
+				continue
+			}
+			if attrs, ok := a.Elements[t.Data]; ok {
+				t.Attr = a.attrs(t.Attr, attrs)
+				if t.Data == "a" {
+					t.Attr = noopener(t.Attr)
+				}
+				out.WriteString(t.String())
+			}
+		case html.EndTagToken:
+			if _, ok := a.Elements[t.Data]; ok {
+				out.WriteString(t.String())
+			}
+		}
+	}
+}
+
+func (a Allowlist) attrs(attrs []html.Attribute, allowed []string) []html.Attribute {
+	out := []html.Attribute{}
+	for _, attr := range attrs {
+		if !slices.Contains(allowed, attr.Key) && !slices.Contains(a.Elements["*"], attr.Key) {
+			continue
+		}
+		if (attr.Key == "href" || attr.Key == "src") && !a.allowedURL(attr.Val) {
+			continue
+		}
+		out = append(out, html.Attribute{Key: attr.Key, Val: attr.Val})
+	}
+	return out
+}
+
+// noopener adds noopener and noreferrer to the rel of links with a target,
+// so the opened page cannot navigate the opener
+func noopener(attrs []html.Attribute) []html.Attribute {
+	if !slices.ContainsFunc(attrs, func(attr html.Attribute) bool { return attr.Key == "target" }) {
+		return attrs
+	}
+	i := slices.IndexFunc(attrs, func(attr html.Attribute) bool { return attr.Key == "rel" })
+	if i < 0 {
+		return append(attrs, html.Attribute{Key: "rel", Val: "noopener noreferrer"})
+	}
+	rel := strings.Fields(attrs[i].Val)
+	for _, value := range []string{"noopener", "noreferrer"} {
+		if !slices.Contains(rel, value) {
+			rel = append(rel, value)
+		}
+	}
+	attrs[i].Val = strings.Join(rel, " ")
+	return attrs
+}
+
+func (a Allowlist) allowedURL(link string) bool {
+	scheme := linkScheme(strings.TrimSpace(link))
+	return scheme == "" || slices.Contains(a.URLSchemes, scheme)
+}
@@ -0,0 +1,34 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowlist(t *testing.T) {
+
+	tests := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{"allowed", `<p class="note">a <strong>b</strong></p>`, `<p class="note">a <strong>b</strong></p>`},
+		{"unknown element keeps text", `<blink>hi</blink>`, `hi`},
+		{"script with content", `a<script>alert(1)</script>b`, `ab`},
+		{"event handler", `<a href="/x" onclick="alert(1)">x</a>`, `<a href="/x">x</a>`},
+		{"javascript url", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"allowed scheme", `<img src="https://example.com/a.png" alt="a"/>`, `<img src="https://example.com/a.png" alt="a"/>`},
+		{"comment", `a<!-- secret -->b`, `ab`},
+		{"escaped text", `1 &lt; 2 & 3`, `1 &lt; 2 &amp; 3`},
+		{"id", `<p id="location">a</p><img id="cookie" src="/a.png"/>`, `<p>a</p><img src="/a.png"/>`},
+		{"target", `<a href="/x" target="_blank">x</a>`, `<a href="/x" target="_blank" rel="noopener noreferrer">x</a>`},
+		{"target with rel", `<a href="/x" rel="nofollow noopener" target="_blank">x</a>`, `<a href="/x" rel="nofollow noopener noreferrer" target="_blank">x</a>`},
+		{"rel without target", `<a href="/x" rel="nofollow">x</a>`, `<a href="/x" rel="nofollow">x</a>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.out, DefaultAllowlist.Sanitize(tt.in))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package blocks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section is a part of a document started by a heading.
+type Section struct {
+	// Title is the text of the heading, it is empty for the content before
+	// the first heading
+	Title string
+	// Slug is unique within the document, for anchors
+	Slug string
+	// HTML is the rendered content of the section without its heading
+	HTML string
+}
+
+// RenderSections splits the document at the headings of the given level or
+// above and renders the sections separately, for tabs, accordions or anchor
+// linked pages. Content before the first heading becomes a section without
+// title.
+func (r *Renderer) RenderSections(blocks []Block, level int) []Section {
+	r.begin()
+	if r.checkLimits(blocks) != nil {
+		return nil
+	}
+	blocks = r.transform(blocks)
+	defer r.recoverRender(blocks, nil)
+	sections := []Section{}
+	slugs := map[string]int{}
+	for _, page := range Paginate(blocks, PaginateOptions{HeadingLevel: level}) {
+		section := Section{}
+		offset, content := page.Offset, page.Blocks
+		if startsSection(content[0], level) {
+			section.Title = strings.TrimSpace(content[0].PlainText())
+			section.Slug = uniqueSlug(slugs, Slug(section.Title))
+			offset, content = offset+1, content[1:]
+		}
+		out := strings.Builder{}
+		for i, b := range content {
+			out.WriteString(r.renderAt([]int{offset + i}, b))
+		}
+		section.HTML = r.format(r.document(out.String()))
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+func RenderSections(blocks []Block, level int) []Section {
+	return New().RenderSections(blocks, level)
+}
+
+func startsSection(b Block, level int) bool {
+	return b.Type == BlockTypeHeading && b.Level != nil && *b.Level <= level
+}
+
+// uniqueSlug appends a counter to slugs which are already taken, counting up
+// until the slug is free. taken counts the uses of every slug.
+func uniqueSlug(taken map[string]int, slug string) string {
+	candidate := slug
+	for n := taken[slug] + 1; taken[candidate] > 0; n++ {
+		candidate = fmt.Sprintf("%s-%d", slug, n)
+	}
+	if candidate != slug {
+		taken[candidate]++
+	}
+	taken[slug]++
+	return candidate
+}
@@ -0,0 +1,47 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSections(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		heading(2, "Setup"),
+		paragraph("install"),
+		heading(3, "Docker"),
+		{Type: BlockTypeImage},
+		heading(2, "Setup"),
+		heading(1, "FAQ"),
+		paragraph("questions"),
+	}
+
+	r := New()
+	assert.Equal(t, []Section{
+		{HTML: "<p>\n  intro\n</p>"},
+		{Title: "Setup", Slug: "setup", HTML: "<p>\n  install\n</p>\n<h3>\n  Docker\n</h3>\nmissing image"},
+		{Title: "Setup", Slug: "setup-2", HTML: ""},
+		{Title: "FAQ", Slug: "faq", HTML: "<p>\n  questions\n</p>"},
+	}, r.RenderSections(doc, 2))
+	assert.Equal(t, []int{4}, r.Warnings()[0].Path)
+
+	sections := RenderSections(doc[1:], 1)
+	assert.Len(t, sections, 2)
+	assert.Equal(t, "", sections[0].Title)
+	assert.Equal(t, "FAQ", sections[1].Title)
+
+	assert.Empty(t, RenderSections(nil, 2))
+}
+
+func TestUniqueSlug(t *testing.T) {
+
+	taken := map[string]int{}
+	slugs := []string{}
+	for _, slug := range []string{"a", "a", "a-2", "a", "b", "a-2"} {
+		slugs = append(slugs, uniqueSlug(taken, slug))
+	}
+	assert.Equal(t, []string{"a", "a-2", "a-2-2", "a-3", "b", "a-2-3"}, slugs)
+}
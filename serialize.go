@@ -0,0 +1,170 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/yosssi/gohtml"
+	nethtml "golang.org/x/net/html"
+)
+
+type AttributeQuotes int
+
+const (
+	// QuoteDouble quotes all attribute values with double quotes
+	QuoteDouble AttributeQuotes = iota
+	// QuoteMinimal leaves out the quotes where html allows it and writes
+	// empty values as boolean attributes
+	QuoteMinimal
+)
+
+type EntityEscaping int
+
+const (
+	// EntitiesNamed escapes the html special characters
+	EntitiesNamed EntityEscaping = iota
+	// EntitiesNumeric escapes the special characters and all non ascii
+	// characters with numeric references, for consumers without named
+	// entities or with a limited charset
+	EntitiesNumeric
+	// EntitiesMinimal only escapes what is required, & and < in text and &
+	// and the quote in attribute values
+	EntitiesMinimal
+)
+
+// Serialization controls the syntax of the output.
+type Serialization struct {
+	// SelfClosing writes void elements as <br />, otherwise as <br>
+	SelfClosing bool
+	Quotes      AttributeQuotes
+	Entities    EntityEscaping
+}
+
+// SerializationXHTML is well-formed xml, for EPUB and strict feed
+// validators.
+var SerializationXHTML = Serialization{SelfClosing: true, Quotes: QuoteDouble, Entities: EntitiesNumeric}
+
+// SerializationHTML5 is the minimal html5 syntax.
+var SerializationHTML5 = Serialization{Quotes: QuoteMinimal, Entities: EntitiesMinimal}
+
+// WithSerialization rewrites the output with the given syntax. Tag and
+// attribute names are written in lower case, except the camel case names of
+// svg.
+func WithSerialization(s Serialization) Option {
+	return func(r *Renderer) {
+		r.serialization = &s
+	}
+}
+
+// format indents the output and applies the serialization
+func (r *Renderer) format(out string) string {
+	out = gohtml.Format(out)
+	if r.serialization == nil {
+		return out
+	}
+	return r.serialization.serialize(out)
+}
+
+// svgNames restores the case of svg names, the tokenizer lowercases them
+var svgNames = map[string]string{}
+
+func init() {
+	for _, name := range []string{
+		"viewBox", "preserveAspectRatio", "gradientUnits", "gradientTransform", "patternUnits",
+		"patternContentUnits", "patternTransform", "clipPathUnits", "markerWidth", "markerHeight",
+		"markerUnits", "refX", "refY", "textLength", "lengthAdjust", "stdDeviation", "maskUnits",
+		"maskContentUnits", "pathLength", "startOffset", "foreignObject", "linearGradient",
+		"radialGradient", "clipPath", "textPath", "feGaussianBlur", "feOffset", "feBlend",
+		"feColorMatrix", "feMerge", "feMergeNode", "feFlood", "feComposite",
+	} {
+		svgNames[strings.ToLower(name)] = name
+	}
+}
+
+func (s Serialization) serialize(fragment string) string {
+	out := strings.Builder{}
+	z := nethtml.NewTokenizer(strings.NewReader(fragment))
+	raw := false
+	for {
+		tt := z.Next()
+		if tt == nethtml.ErrorToken {
+			return out.String()
+		}
+		if tt == nethtml.TextToken && raw {
+			// script and style content is not escaped
+			out.Write(z.Raw())
+			continue
+		}
+		t := z.Token()
+		switch tt {
+		case nethtml.TextToken:
+			out.WriteString(s.escape(t.Data, 0))
+		case nethtml.StartTagToken, nethtml.SelfClosingTagToken:
+			void := slices.Contains(voidElements, t.Data)
+			fmt.Fprintf(&out, "<%s", svgName(t.Data))
+			for _, a := range t.Attr {
+				out.WriteString(s.attr(a))
+			}
+			if (void && s.SelfClosing) || (!void && tt == nethtml.SelfClosingTagToken) {
+				out.WriteString(" />")
+			} else {
+				out.WriteString(">")
+			}
+			raw = tt == nethtml.StartTagToken && (t.Data == "script" || t.Data == "style")
+		case nethtml.EndTagToken:
+			raw = false
+			if !slices.Contains(voidElements, t.Data) {
+				fmt.Fprintf(&out, "</%s>", svgName(t.Data))
+			}
+		case nethtml.CommentToken:
+			fmt.Fprintf(&out, "<!--%s-->", t.Data)
+		default:
+			out.Write(z.Raw())
+		}
+	}
+}
+
+func svgName(name string) string {
+	if svg, ok := svgNames[name]; ok {
+		return svg
+	}
+	return name
+}
+
+func (s Serialization) attr(a nethtml.Attribute) string {
+	key := svgName(a.Key)
+	if s.Quotes == QuoteMinimal {
+		if a.Val == "" {
+			return " " + key
+		}
+		if !strings.ContainsAny(a.Val, " \t\n\f\r\"'=<>`") {
+			return fmt.Sprintf(" %s=%s", key, s.escape(a.Val, 0))
+		}
+	}
+	return fmt.Sprintf(` %s="%s"`, key, s.escape(a.Val, '"'))
+}
+
+// escape escapes text, or attribute values quoted with quote
+func (s Serialization) escape(text string, quote rune) string {
+	switch s.Entities {
+	case EntitiesNumeric:
+		out := strings.Builder{}
+		for _, c := range text {
+			if c > 127 || c == '&' || c == '<' || c == '>' || c == '"' || c == '\'' {
+				fmt.Fprintf(&out, "&#%d;", c)
+			} else {
+				out.WriteRune(c)
+			}
+		}
+		return out.String()
+	case EntitiesMinimal:
+		text = strings.ReplaceAll(text, "&", "&amp;")
+		if quote != 0 {
+			return strings.ReplaceAll(text, string(quote), "&#34;")
+		}
+		return strings.ReplaceAll(text, "<", "&lt;")
+	}
+	return html.EscapeString(text)
+}
@@ -0,0 +1,55 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialization(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("Café & co")},
+			link("/a?b=1&c=2", "link"),
+		}},
+		paragraph(""),
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", AlternativeText: "the α"}},
+		{Type: BlockTypeDetails, Summary: ptr("More"), Open: ptr(true), Children: []Block{}},
+	}
+
+	assert.Equal(t, `<p>
+  Caf&#233; &#38; co
+  <a href="/a?b=1&#38;c=2">
+    link
+  </a>
+</p>
+<br />
+<img src="/a.png" alt="the &#945;" />
+<details open="">
+  <summary>
+    More
+  </summary>
+</details>`, New(WithSerialization(SerializationXHTML)).Render(doc))
+
+	assert.Equal(t, `<p>
+  Café &amp; co
+  <a href="/a?b=1&amp;c=2">
+    link
+  </a>
+</p>
+<br>
+<img src=/a.png alt="the α">
+<details open>
+  <summary>
+    More
+  </summary>
+</details>`, New(WithSerialization(SerializationHTML5)).Render(doc))
+}
+
+func TestSerializationSVG(t *testing.T) {
+
+	s := Serialization{SelfClosing: true}
+	assert.Equal(t, `<svg viewBox="0 0 10 10"><path d="M0 0" /><clipPath id="c"></clipPath></svg><script>if (a < b) {}</script>`,
+		s.serialize(`<svg viewBox="0 0 10 10"><path d="M0 0"/><clipPath id="c"></clipPath></svg><script>if (a < b) {}</script>`))
+}
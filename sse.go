@@ -0,0 +1,71 @@
+package blocks
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const SSEEventBlock = "block"
+const SSEEventDone = "done"
+
+// ServeSSE renders the blocks one by one and sends each top level block as a
+// server-sent "block" event, flushing the response after every event so the
+// first blocks show up while later ones are still rendering. The event id is
+// the index of the block. A final "done" event carrying nothing ends the
+// stream. Rendering stops when the request is canceled.
+func (r *Renderer) ServeSSE(w http.ResponseWriter, req *http.Request, blocks []Block) (err error) {
+	r.begin()
+	if err := r.checkLimits(blocks); err != nil {
+		return err
+	}
+	blocks = r.transform(blocks)
+	defer r.recoverRender(blocks, &err)
+	r.ctx = req.Context()
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for i, b := range blocks {
+		if err := req.Context().Err(); err != nil {
+			return err
+		}
+		out := r.format(r.renderAt([]int{i}, b))
+		if err := writeEvent(w, SSEEventBlock, strconv.Itoa(i), out); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	if err := writeEvent(w, SSEEventDone, "", ""); err != nil {
+		return err
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// writeEvent writes a server-sent event, every line of data gets its own data
+// field.
+func writeEvent(w http.ResponseWriter, event, id, data string) error {
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "event: %s\n", event)
+	if id != "" {
+		fmt.Fprintf(&out, "id: %s\n", id)
+	}
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" {
+			out.WriteString("data:\n")
+			continue
+		}
+		fmt.Fprintf(&out, "data: %s\n", line)
+	}
+	out.WriteString("\n")
+	_, err := w.Write([]byte(out.String()))
+	return err
+}
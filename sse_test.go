@@ -0,0 +1,53 @@
+package blocks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeSSE(t *testing.T) {
+
+	doc := []Block{
+		heading(1, "Title"),
+		{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quote")}}},
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	err := New().ServeSSE(rec, req, doc)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, `event: block
+id: 0
+data: <h1>
+data:   Title
+data: </h1>
+
+event: block
+id: 1
+data: <blockquote>
+data:   quote
+data: </blockquote>
+
+event: done
+data:
+
+`, rec.Body.String())
+}
+
+func TestServeSSE_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	err := New().ServeSSE(rec, req, []Block{heading(1, "Title")})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotContains(t, rec.Body.String(), "Title")
+}
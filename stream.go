@@ -0,0 +1,60 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RenderStream decodes the blocks payload from rd and writes the rendered
+// html to w one top level block at a time, so neither the whole document nor
+// the whole output is kept in memory. The output is formatted per top level
+// block and transformers are applied per top level block as well. Limits
+// apply to the whole payload, what was rendered before a limit is hit has
+// already been written.
+func (r *Renderer) RenderStream(w io.Writer, rd io.Reader) (err error) {
+	r.begin()
+	defer r.recoverRender(nil, &err)
+	dec := json.NewDecoder(rd)
+	if t, err := dec.Token(); err != nil {
+		return err
+	} else if t != json.Delim('[') {
+		return fmt.Errorf("blocks: expected an array of blocks, got %v", t)
+	}
+
+	if _, err := io.WriteString(w, r.wrapperStart()); err != nil {
+		return err
+	}
+	count := 0
+	for i := 0; dec.More(); i++ {
+		var b Block
+		if err := dec.Decode(&b); err != nil {
+			return err
+		}
+		if err := r.limits.checkBlock(b, []int{i}, &count); err != nil {
+			r.warn(WarningLimitExceeded, "%s", err)
+			return err
+		}
+		html := strings.Builder{}
+		for _, t := range r.transform([]Block{b}) {
+			html.WriteString(r.renderAt([]int{i}, t))
+		}
+		out := r.format(html.String())
+		if i > 0 {
+			out = "\n" + out
+		}
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, r.wrapperEnd())
+	return err
+}
+
+func RenderStream(w io.Writer, rd io.Reader) error {
+	return New().RenderStream(w, rd)
+}
@@ -0,0 +1,40 @@
+package blocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderStream(t *testing.T) {
+
+	var doc []Block
+	assert.NoError(t, json.Unmarshal(testInput, &doc))
+
+	out := bytes.Buffer{}
+	assert.NoError(t, RenderStream(&out, bytes.NewReader(testInput)))
+	assert.Equal(t, strings.Count(Render(doc), "<"), strings.Count(out.String(), "<"))
+	assert.Contains(t, out.String(), "<h1>\n  now titles: header 1\n</h1>\n")
+
+	r := New(WithTheme(ThemeTailwindProse))
+	out.Reset()
+	assert.NoError(t, r.RenderStream(&out, strings.NewReader(`[{"type":"video"},{"type":"paragraph","children":[{"type":"text","text":"a"}]}]`)))
+	assert.Equal(t, "<article class=\"prose\">unsupported block type\n<p>\n  a\n</p></article>", out.String())
+	assert.Equal(t, []int{0}, r.Warnings()[0].Path)
+}
+
+func TestRenderStream_Errors(t *testing.T) {
+
+	out := bytes.Buffer{}
+	assert.Error(t, RenderStream(&out, strings.NewReader(`{"type":"text"}`)))
+	assert.Error(t, RenderStream(&out, strings.NewReader(`[{"type":"text"`)))
+
+	r := New(WithLimits(Limits{MaxBlocks: 2}))
+	out.Reset()
+	err := r.RenderStream(&out, strings.NewReader(`[{"type":"quote","children":[{"type":"text","text":"a"}]},{"type":"text","text":"b"}]`))
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, "<blockquote>\n  a\n</blockquote>", out.String())
+}
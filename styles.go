@@ -0,0 +1,45 @@
+package blocks
+
+// Styles maps elements to the inline style they are rendered with.
+type Styles map[Element]string
+
+// DefaultStyles are the inline styles of WithInlineStyles, they are also used
+// for the rules of Stylesheet.
+var DefaultStyles = Styles{
+	ElementDocument:       "max-width: 65ch; line-height: 1.6;",
+	ElementParagraph:      "margin: 0 0 1em;",
+	ElementHeading:        "margin: 1.5em 0 0.5em; line-height: 1.25;",
+	ElementList:           "margin: 0 0 1em; padding-left: 1.5em;",
+	ElementLink:           "color: #0969da;",
+	ElementImage:          "max-width: 100%; height: auto;",
+	ElementFigure:         "margin: 1.5em 0;",
+	ElementFigcaption:     "margin-top: 0.5em; font-size: 0.875em; color: #555;",
+	ElementQuote:          "margin: 1.5em 0; padding-left: 1em; border-left: 4px solid #ddd; color: #555;",
+	ElementQuoteFigure:    "margin: 1.5em 0;",
+	ElementPre:            "overflow-x: auto; padding: 1em; background: #f6f8fa; border-radius: 4px;",
+	ElementCodeBlock:      "position: relative;",
+	ElementCodeHighlight:  "display: inline-block; width: 100%; background: #fff8c5;",
+	ElementCodeLineNumber: "display: inline-block; width: 2em; margin-right: 1em; text-align: right; color: #999; user-select: none;",
+	ElementMention:        "color: #0969da; font-weight: 500;",
+	ElementColumns:        "display: flex; flex-wrap: wrap; gap: 1.5em; margin: 0 0 1em;",
+	ElementColumn:         "flex: 1 1 0; min-width: 12em;",
+	ElementEmbed:          "width: 100%; margin: 0 0 1em;",
+	ElementEmbedFrame:     "width: 100%; height: 100%; border: 0;",
+	ElementGallery:        "display: grid; gap: 1em; margin: 0 0 1em;",
+	ElementGalleryItem:    "margin: 0;",
+}
+
+// WithInlineStyles renders style attributes instead of classes, for html
+// email or pages the stylesheet can not be loaded into. The given styles
+// override the DefaultStyles, set an empty style to drop a default.
+func WithInlineStyles(styles Styles) Option {
+	return func(r *Renderer) {
+		r.styles = Styles{}
+		for el, style := range DefaultStyles {
+			r.styles[el] = style
+		}
+		for el, style := range styles {
+			r.styles[el] = style
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInlineStyles(t *testing.T) {
+
+	doc := []Block{
+		paragraph("text"),
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", Width: 4, Height: 3}},
+		{Type: BlockTypeMention, Mention: &Mention{Username: "gopher"}},
+	}
+
+	r := New(WithAspectRatio(), WithInlineStyles(Styles{ElementParagraph: "color: red;", ElementMention: ""}))
+	assert.Equal(t, `<p style="color: red;">
+  text
+</p>
+<img src="/a.png" alt="" width="4" height="3" style="aspect-ratio: 4 / 3; max-width: 100%; height: auto;" />
+<span>
+  @gopher
+</span>`, r.Render(doc))
+	assert.NotContains(t, r.Render(doc), "class=")
+}
@@ -0,0 +1,188 @@
+package blocks
+
+import (
+	"errors"
+	"html/template"
+	"io/fs"
+	"strings"
+)
+
+// TemplateData is passed to block templates.
+type TemplateData struct {
+	Block Block
+	// Children holds the rendered children of the block
+	Children template.HTML
+	// Text is the plain text of the block
+	Text string
+}
+
+// WithTemplates loads html templates named after the block types, like
+// paragraph.html, heading.html or list-item.html, from fsys and renders
+// those block types with them instead of the built-in markup. Missing files
+// keep the built-in markup. Like template.Must it panics if a template
+// cannot be parsed, use ParseTemplates to handle the error.
+func WithTemplates(fsys fs.FS) Option {
+	templates, err := ParseTemplates(fsys)
+	if err != nil {
+		panic(err)
+	}
+	return templates.Option()
+}
+
+// BlockTemplates are the parsed templates of WithTemplates.
+type BlockTemplates map[BlockType]*template.Template
+
+// ParseTemplates parses the block templates found in fsys.
+func ParseTemplates(fsys fs.FS) (BlockTemplates, error) {
+	templates := BlockTemplates{}
+	for _, tt := range templateTypes {
+		name := string(tt.blockType) + ".html"
+		src, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := template.New(name).Parse(string(src))
+		if err != nil {
+			return nil, err
+		}
+		templates[tt.blockType] = tmpl
+	}
+	return templates, nil
+}
+
+// Option installs the templates, see WithTemplates.
+func (t BlockTemplates) Option() Option {
+	return func(r *Renderer) {
+		tr := templateRenderer{r: r, templates: t}
+		for _, tt := range templateTypes {
+			if _, ok := t[tt.blockType]; ok {
+				tt.install(r, tr)
+			}
+		}
+	}
+}
+
+// templateTypes are the block types with templates and how their template
+// renderer is installed
+var templateTypes = []struct {
+	blockType BlockType
+	install   func(r *Renderer, tr templateRenderer)
+}{
+	{BlockTypeParagraph, func(r *Renderer, tr templateRenderer) { r.ParagraphRenderer = tr }},
+	{BlockTypeText, func(r *Renderer, tr templateRenderer) { r.TextRenderer = tr }},
+	{BlockTypeList, func(r *Renderer, tr templateRenderer) { r.ListRenderer = tr }},
+	{BlockTypeListItem, func(r *Renderer, tr templateRenderer) { r.ListItemRenderer = tr }},
+	{BlockTypeHeading, func(r *Renderer, tr templateRenderer) { r.HeadingRenderer = tr }},
+	{BlockTypeLink, func(r *Renderer, tr templateRenderer) { r.LinkRenderer = tr }},
+	{BlockTypeImage, func(r *Renderer, tr templateRenderer) { r.ImageRenderer = tr }},
+	{BlockTypeQuote, func(r *Renderer, tr templateRenderer) { r.QuoteRenderer = tr }},
+	{BlockTypeCode, func(r *Renderer, tr templateRenderer) { r.CodeRenderer = tr }},
+	{BlockTypeMention, func(r *Renderer, tr templateRenderer) { r.MentionRenderer = tr }},
+	{BlockTypeMath, func(r *Renderer, tr templateRenderer) { r.MathRenderer = tr }},
+	{BlockTypeAbbreviation, func(r *Renderer, tr templateRenderer) { r.AbbreviationRenderer = tr }},
+	{BlockTypeDefinitionList, func(r *Renderer, tr templateRenderer) { r.DefinitionListRenderer = tr }},
+	{BlockTypeDefinitionTerm, func(r *Renderer, tr templateRenderer) { r.DefinitionTermRenderer = tr }},
+	{BlockTypeDefinitionDescription, func(r *Renderer, tr templateRenderer) { r.DefinitionDescriptionRenderer = tr }},
+	{BlockTypeDetails, func(r *Renderer, tr templateRenderer) { r.DetailsRenderer = tr }},
+	{BlockTypeColumns, func(r *Renderer, tr templateRenderer) { r.ColumnsRenderer = tr }},
+	{BlockTypeColumn, func(r *Renderer, tr templateRenderer) { r.ColumnRenderer = tr }},
+	{BlockTypeHTML, func(r *Renderer, tr templateRenderer) { r.HTMLRenderer = tr }},
+	{BlockTypeGallery, func(r *Renderer, tr templateRenderer) { r.GalleryRenderer = tr }},
+	{BlockTypeAnnotation, func(r *Renderer, tr templateRenderer) { r.AnnotationRenderer = tr }},
+}
+
+// templateRenderer implements all block renderer interfaces by executing the
+// template of the block type.
+type templateRenderer struct {
+	r         *Renderer
+	templates BlockTemplates
+}
+
+func (tr templateRenderer) render(b Block) string {
+	data := TemplateData{
+		Block:    b,
+		Children: template.HTML(tr.r.internalRender(b.Children)),
+		Text:     b.PlainText(),
+	}
+	out := strings.Builder{}
+	if err := tr.templates[b.Type].Execute(&out, data); err != nil {
+		tr.r.warn(WarningTemplateError, "%s template: %s", b.Type, err)
+		return tr.r.renderBuiltin(b)
+	}
+	return out.String()
+}
+
+func (tr templateRenderer) RenderParagraph(b Block) string             { return tr.render(b) }
+func (tr templateRenderer) RenderText(b Block) string                  { return tr.render(b) }
+func (tr templateRenderer) RenderList(b Block) string                  { return tr.render(b) }
+func (tr templateRenderer) RenderListItem(b Block) string              { return tr.render(b) }
+func (tr templateRenderer) RenderHeading(b Block) string               { return tr.render(b) }
+func (tr templateRenderer) RenderLink(b Block) string                  { return tr.render(b) }
+func (tr templateRenderer) RenderImage(b Block) string                 { return tr.render(b) }
+func (tr templateRenderer) RenderQuote(b Block) string                 { return tr.render(b) }
+func (tr templateRenderer) RenderCode(b Block) string                  { return tr.render(b) }
+func (tr templateRenderer) RenderMention(b Block) string               { return tr.render(b) }
+func (tr templateRenderer) RenderMath(b Block) string                  { return tr.render(b) }
+func (tr templateRenderer) RenderAbbreviation(b Block) string          { return tr.render(b) }
+func (tr templateRenderer) RenderDefinitionList(b Block) string        { return tr.render(b) }
+func (tr templateRenderer) RenderDefinitionTerm(b Block) string        { return tr.render(b) }
+func (tr templateRenderer) RenderDefinitionDescription(b Block) string { return tr.render(b) }
+func (tr templateRenderer) RenderDetails(b Block) string               { return tr.render(b) }
+func (tr templateRenderer) RenderColumns(b Block) string               { return tr.render(b) }
+func (tr templateRenderer) RenderColumn(b Block) string                { return tr.render(b) }
+func (tr templateRenderer) RenderAnnotation(b Block) string            { return tr.render(b) }
+func (tr templateRenderer) RenderGallery(b Block) string               { return tr.render(b) }
+func (tr templateRenderer) RenderHTML(b Block) string                  { return tr.render(b) }
+
+// renderBuiltin renders the block with the built-in markup, ignoring custom
+// renderers.
+func (r *Renderer) renderBuiltin(b Block) string {
+	switch b.Type {
+	case BlockTypeParagraph:
+		return r.RenderParagraph(b)
+	case BlockTypeText:
+		return r.RenderText(b)
+	case BlockTypeList:
+		return r.RenderList(b)
+	case BlockTypeListItem:
+		return r.RenderListItem(b)
+	case BlockTypeHeading:
+		return r.RenderHeading(b)
+	case BlockTypeLink:
+		return r.RenderLink(b)
+	case BlockTypeImage:
+		return r.RenderImage(b)
+	case BlockTypeQuote:
+		return r.RenderQuote(b)
+	case BlockTypeCode:
+		return r.RenderCode(b)
+	case BlockTypeMention:
+		return r.RenderMention(b)
+	case BlockTypeMath:
+		return r.RenderMath(b)
+	case BlockTypeAbbreviation:
+		return r.RenderAbbreviation(b)
+	case BlockTypeDefinitionList:
+		return r.RenderDefinitionList(b)
+	case BlockTypeDefinitionTerm:
+		return r.RenderDefinitionTerm(b)
+	case BlockTypeDefinitionDescription:
+		return r.RenderDefinitionDescription(b)
+	case BlockTypeDetails:
+		return r.RenderDetails(b)
+	case BlockTypeColumns:
+		return r.RenderColumns(b)
+	case BlockTypeColumn:
+		return r.RenderColumn(b)
+	case BlockTypeHTML:
+		return r.RenderHTML(b)
+	case BlockTypeGallery:
+		return r.RenderGallery(b)
+	case BlockTypeAnnotation:
+		return r.RenderAnnotation(b)
+	}
+	return r.placeholders.UnsupportedBlock
+}
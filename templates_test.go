@@ -0,0 +1,65 @@
+package blocks
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTemplates(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeHeading, Level: ptr(2), Children: []Block{{Type: BlockTypeText, Text: ptr("intro")}}},
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("a <b> ")},
+			{Type: BlockTypeText, Text: ptr("bold"), Bold: ptr(true)},
+		}},
+		{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quote")}}},
+	}
+
+	r := New(WithTemplates(os.DirFS("testdata/templates")))
+	assert.Equal(t, `<h2 id="intro">
+  intro
+</h2>
+<p class="lead">
+  a &lt;b&gt;
+  <b>
+    bold
+  </b>
+</p>
+<blockquote>
+  quote
+</blockquote>`, r.Render(doc))
+}
+
+func TestParseTemplates(t *testing.T) {
+
+	_, err := ParseTemplates(fstest.MapFS{"paragraph.html": {Data: []byte("{{.Children")}})
+	assert.Error(t, err)
+
+	templates, err := ParseTemplates(fstest.MapFS{"quote.html": {Data: []byte("{{.Missing}}")}})
+	assert.NoError(t, err)
+
+	r := New(templates.Option())
+	out := r.Render([]Block{{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quote")}}}})
+	assert.Equal(t, "<blockquote>\n  quote\n</blockquote>", out)
+	assert.Equal(t, WarningTemplateError, r.Warnings()[0].Code)
+}
+
+func TestParseTemplatesBlockTypes(t *testing.T) {
+
+	fsys := fstest.MapFS{}
+	for _, tt := range templateTypes {
+		fsys[string(tt.blockType)+".html"] = &fstest.MapFile{Data: []byte("[{{.Block.Type}}]")}
+	}
+	templates, err := ParseTemplates(fsys)
+	assert.NoError(t, err)
+	assert.Len(t, templates, len(templateTypes))
+
+	r := New(templates.Option(), WithRawHTML(nil), WithAnnotations(AnnotationsVisible))
+	for _, tt := range templateTypes {
+		assert.Equal(t, "["+string(tt.blockType)+"]", r.Render([]Block{{Type: tt.blockType}}), tt.blockType)
+	}
+}
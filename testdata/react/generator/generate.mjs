@@ -0,0 +1,20 @@
+// Renders the fixtures in testdata/react with the official react renderer,
+// the html next to every json file is what TestWithReactCompat expects:
+//
+//	cd testdata/react/generator && npm install && npm run generate
+//
+// The versions are pinned in package.json, regenerate the fixtures when
+// updating them.
+import { readdir, readFile, writeFile } from "node:fs/promises";
+import { createElement } from "react";
+import { renderToStaticMarkup } from "react-dom/server";
+import { BlocksRenderer } from "@strapi/blocks-react-renderer";
+
+const dir = new URL("..", import.meta.url);
+
+for (const file of (await readdir(dir)).filter((f) => f.endsWith(".json"))) {
+  const content = JSON.parse(await readFile(new URL(file, dir), "utf8"));
+  const html = renderToStaticMarkup(createElement(BlocksRenderer, { content }));
+  await writeFile(new URL(file.replace(/\.json$/, ".html"), dir), html);
+  console.log(file);
+}
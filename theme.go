@@ -0,0 +1,88 @@
+package blocks
+
+import (
+	"fmt"
+)
+
+const ThemePlain = "plain"
+const ThemeTailwindProse = "tailwind-prose"
+const ThemeBootstrap5 = "bootstrap5"
+
+// Theme configures the classes and the surrounding markup of the rendered
+// html in one go.
+type Theme struct {
+	Classes Classes
+	// Wrapper is the element the whole document is wrapped in, like
+	// "article". The wrapper gets the class of ElementDocument.
+	Wrapper string
+	// QuoteFigure wraps quotes in a figure element
+	QuoteFigure bool
+}
+
+// Themes holds the built-in themes by name.
+var Themes = map[string]Theme{
+	ThemePlain: {},
+	ThemeTailwindProse: {
+		Wrapper: "article",
+		Classes: Classes{
+			ElementDocument: "prose",
+			ElementImage:    "rounded-lg",
+		},
+	},
+	ThemeBootstrap5: {
+		QuoteFigure: true,
+		Classes: Classes{
+			ElementImage:      "img-fluid",
+			ElementFigure:     "figure",
+			ElementFigcaption: "figure-caption",
+			ElementQuote:      "blockquote",
+			ElementPre:        "bg-light p-3 rounded",
+			ElementList:       "mb-3",
+		},
+	},
+}
+
+// WithTheme applies the theme of the given name from Themes. Classes set by
+// an earlier WithClasses are reset, so WithClasses should come after the
+// theme. It panics on unknown themes.
+func WithTheme(name string) Option {
+	theme, ok := Themes[name]
+	if !ok {
+		panic(fmt.Sprintf("blocks: unknown theme %q", name))
+	}
+	return theme.Option()
+}
+
+// Option applies the theme, see WithTheme.
+func (t Theme) Option() Option {
+	return func(r *Renderer) {
+		r.classes = defaultClasses()
+		for el, class := range t.Classes {
+			r.classes[el] = class
+		}
+		r.wrapper = t.Wrapper
+		r.quoteFigure = t.QuoteFigure
+	}
+}
+
+// document wraps the rendered document in the wrapper of the theme.
+func (r *Renderer) document(content string) string {
+	if r.wrapper != "" {
+		content = r.wrapperStart() + content + r.wrapperEnd()
+	}
+	return content
+}
+
+func (r *Renderer) wrapperStart() string {
+	if r.wrapper == "" {
+		return ""
+	}
+	return fmt.Sprintf("<%s%s>", r.wrapper, r.attrs(ElementDocument))
+}
+
+func (r *Renderer) wrapperEnd() string {
+	if r.wrapper == "" {
+		return ""
+	}
+	return fmt.Sprintf("</%s>", r.wrapper)
+}
@@ -0,0 +1,53 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTheme(t *testing.T) {
+
+	doc := []Block{
+		paragraph("text"),
+		{Type: BlockTypeQuote, Children: []Block{{Type: BlockTypeText, Text: ptr("quote")}}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", Caption: "caption"}},
+	}
+
+	assert.Equal(t, Render(doc), New(WithTheme(ThemePlain)).Render(doc))
+
+	assert.Equal(t, `<article class="prose">
+  <p>
+    text
+  </p>
+  <blockquote>
+    quote
+  </blockquote>
+  <figure>
+    <img src="/a.png" alt="" class="rounded-lg" />
+    <figcaption>
+      caption
+    </figcaption>
+  </figure>
+</article>`, New(WithTheme(ThemeTailwindProse)).Render(doc))
+
+	assert.Equal(t, `<p>
+  text
+</p>
+<figure>
+  <blockquote class="blockquote">
+    quote
+  </blockquote>
+</figure>
+<figure class="figure">
+  <img src="/a.png" alt="" class="img-fluid" />
+  <figcaption class="figure-caption">
+    caption
+  </figcaption>
+</figure>`, New(WithTheme(ThemeBootstrap5)).Render(doc))
+
+	r := New(WithTheme(ThemeBootstrap5), WithClasses(Classes{ElementQuote: "blockquote fs-4"}))
+	assert.Contains(t, r.Render(doc[1:2]), `<blockquote class="blockquote fs-4">`)
+
+	assert.Panics(t, func() { WithTheme("unknown") })
+}
@@ -0,0 +1,61 @@
+package blocks
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cdreier/strapi-blocks-go-renderer"
+
+// WithTracerProvider records a span for every render. Blocks of the given
+// types get a child span, for example to measure expensive custom renderers.
+func WithTracerProvider(tp trace.TracerProvider, spanTypes ...BlockType) Option {
+	return func(r *Renderer) {
+		r.tracer = tp.Tracer(tracerName)
+		r.spanTypes = map[BlockType]bool{}
+		for _, t := range spanTypes {
+			r.spanTypes[t] = true
+		}
+	}
+}
+
+func (r *Renderer) startRenderSpan(ctx context.Context, blocks []Block) (context.Context, trace.Span) {
+	if r.tracer == nil {
+		return ctx, nil
+	}
+	return r.tracer.Start(ctx, "blocks.Render", trace.WithAttributes(
+		attribute.Int("blocks.count", len(blocks)),
+	))
+}
+
+func (r *Renderer) endRenderSpan(span trace.Span, out string, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.SetAttributes(
+		attribute.Int("blocks.output_size", len(out)),
+		attribute.Int("blocks.warnings", len(r.warnings)),
+	)
+	span.End()
+}
+
+func (r *Renderer) tracedBlock(b Block) string {
+	parent := r.ctx
+	ctx, span := r.tracer.Start(parent, "blocks.render "+string(b.Type), trace.WithAttributes(
+		attribute.String("blocks.type", string(b.Type)),
+		attribute.Int("blocks.children", len(b.Children)),
+	))
+	r.ctx = ctx
+	out := r.dispatchBlock(b)
+	r.ctx = parent
+	span.SetAttributes(attribute.Int("blocks.output_size", len(out)))
+	span.End()
+	return out
+}
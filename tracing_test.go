@@ -0,0 +1,46 @@
+package blocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	doc := []Block{
+		paragraph("text"),
+		codeBlock("go", "a := 1"),
+		codeBlock("go", "b := 2"),
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+	out := New(WithTracerProvider(tp, BlockTypeCode)).RenderContext(ctx, doc)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 4)
+
+	render := spans[2]
+	assert.Equal(t, "blocks.Render", render.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), render.Parent().SpanID())
+	assert.Contains(t, render.Attributes(), attribute.Int("blocks.count", 3))
+	assert.Contains(t, render.Attributes(), attribute.Int("blocks.output_size", len(out)))
+
+	for _, code := range spans[:2] {
+		assert.Equal(t, "blocks.render code", code.Name())
+		assert.Equal(t, render.SpanContext().SpanID(), code.Parent().SpanID())
+	}
+}
+
+func TestRenderWithoutTracer(t *testing.T) {
+	doc := []Block{paragraph("text")}
+	assert.Equal(t, Render(doc), New().RenderContext(context.Background(), doc))
+}
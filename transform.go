@@ -0,0 +1,29 @@
+package blocks
+
+// Transformer rewrites a document before it is rendered. Transformers must
+// not modify the given blocks, they return a new tree instead.
+type Transformer interface {
+	Transform(blocks []Block) []Block
+}
+
+type TransformerFunc func(blocks []Block) []Block
+
+func (f TransformerFunc) Transform(blocks []Block) []Block {
+	return f(blocks)
+}
+
+// WithTransformers adds transformers which are applied in order to every
+// rendered document. Paths of warnings and of RenderAt refer to the
+// transformed document.
+func WithTransformers(transformers ...Transformer) Option {
+	return func(r *Renderer) {
+		r.transformers = append(r.transformers, transformers...)
+	}
+}
+
+func (r *Renderer) transform(blocks []Block) []Block {
+	for _, t := range r.transformers {
+		blocks = t.Transform(blocks)
+	}
+	return blocks
+}
@@ -0,0 +1,147 @@
+package blocks
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const DefaultTruncateLength = 160
+
+// SentenceBreaker returns the rune offsets at which the sentences of the
+// text end, for language specific sentence detection.
+type SentenceBreaker func(text string) []int
+
+// TruncateOptions configures Truncate.
+type TruncateOptions struct {
+	// Length is the target length in characters, defaults to
+	// DefaultTruncateLength
+	Length int
+	// Slack is how many characters a sentence may end after Length, when it
+	// ends closer to Length than the sentence before. Defaults to a tenth
+	// of Length.
+	Slack int
+	// Sentences defaults to Sentences
+	Sentences SentenceBreaker
+	// Ellipsis is appended when no sentence ends near Length and the text is
+	// cut at a word instead, defaults to "…"
+	Ellipsis string
+}
+
+// Truncate shortens the plain text to complete sentences, ending as close to
+// the target length as possible, for meta descriptions and cards.
+// Whitespace is collapsed. Texts without a sentence ending in the second
+// half of the target length are cut at a word and get the ellipsis.
+func Truncate(text string, opts TruncateOptions) string {
+	if opts.Length <= 0 {
+		opts.Length = DefaultTruncateLength
+	}
+	if opts.Slack <= 0 {
+		opts.Slack = opts.Length / 10
+	}
+	if opts.Sentences == nil {
+		opts.Sentences = Sentences
+	}
+	if opts.Ellipsis == "" {
+		opts.Ellipsis = "…"
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= opts.Length {
+		return text
+	}
+
+	best := 0
+	for _, end := range opts.Sentences(text) {
+		// custom breakers may return offsets outside the text
+		end = min(max(end, 0), len(runes))
+		if end <= opts.Length {
+			best = end
+		} else if end <= opts.Length+opts.Slack && end-opts.Length < opts.Length-best {
+			best = end
+		}
+	}
+	if best >= opts.Length/2 {
+		return strings.TrimSpace(string(runes[:best]))
+	}
+
+	// an ellipsis longer than the target length leaves no room for the text
+	limit := max(opts.Length-len([]rune(opts.Ellipsis)), 0)
+	cut := limit
+	for cut > 0 && !unicode.IsSpace(runes[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		cut = limit
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), func(c rune) bool {
+		return unicode.IsSpace(c) || unicode.IsPunct(c)
+	}) + opts.Ellipsis
+}
+
+// Excerpt truncates the text of the document without its headings, see
+// Truncate.
+func Excerpt(blocks []Block, opts TruncateOptions) string {
+	parts := []string{}
+	for _, b := range blocks {
+		if b.Type != BlockTypeHeading && b.Type != BlockTypeAnnotation {
+			parts = append(parts, b.PlainText())
+		}
+	}
+	return Truncate(strings.Join(parts, " "), opts)
+}
+
+// Sentences is the default SentenceBreaker, it knows a few english
+// abbreviations. Use SentenceBreakerFor for other languages.
+var Sentences = SentenceBreakerFor("mr", "mrs", "ms", "dr", "prof", "st", "vs", "e.g", "i.e")
+
+// SentenceBreakerFor returns a SentenceBreaker ending sentences at ., !, ?
+// and … followed by whitespace, and at the fullwidth 。！？ of chinese and
+// japanese. Closing quotes and brackets belong to the sentence. A period
+// after one of the abbreviations, compared case insensitive without the
+// period, or after a single letter does not end the sentence.
+func SentenceBreakerFor(abbreviations ...string) SentenceBreaker {
+	abbreviations = slices.Clone(abbreviations)
+	for i, a := range abbreviations {
+		abbreviations[i] = strings.ToLower(a)
+	}
+	return func(text string) []int {
+		runes := []rune(text)
+		ends := []int{}
+		for i := 0; i < len(runes); i++ {
+			c := runes[i]
+			fullwidth := c == '。' || c == '！' || c == '？'
+			if !fullwidth && c != '.' && c != '!' && c != '?' && c != '…' {
+				continue
+			}
+			end := i + 1
+			for end < len(runes) && (strings.ContainsRune(".!?…", runes[end]) || isClosing(runes[end])) {
+				end++
+			}
+			if !fullwidth && end < len(runes) && !unicode.IsSpace(runes[end]) {
+				continue
+			}
+			if c == '.' && end == i+1 && isAbbreviation(runes[:i], abbreviations) {
+				continue
+			}
+			ends = append(ends, end)
+			i = end - 1
+		}
+		return ends
+	}
+}
+
+func isClosing(c rune) bool {
+	return unicode.Is(unicode.Pe, c) || unicode.Is(unicode.Pf, c) || c == '"' || c == '\''
+}
+
+// isAbbreviation reports whether the word before the period is an
+// abbreviation
+func isAbbreviation(before []rune, abbreviations []string) bool {
+	start := len(before)
+	for start > 0 && !unicode.IsSpace(before[start-1]) && before[start-1] != '(' {
+		start--
+	}
+	word := strings.ToLower(string(before[start:]))
+	return len([]rune(word)) == 1 && unicode.IsLetter([]rune(word)[0]) || slices.Contains(abbreviations, word)
+}
@@ -0,0 +1,53 @@
+package blocks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+
+	text := "Go is fast. It compiles quickly! Does it scale? Yes, to large code bases."
+	assert.Equal(t, "Go is fast.", Truncate(text, TruncateOptions{Length: 20}))
+	assert.Equal(t, "Go is fast. It compiles quickly!", Truncate(text, TruncateOptions{Length: 32}))
+	assert.Equal(t, "Go is fast. It compiles quickly! Does it scale?", Truncate(text, TruncateOptions{Length: 45}), "the sentence ending within the slack is closer")
+	assert.Equal(t, text, Truncate(text, TruncateOptions{Length: 100}))
+
+	long := "A single sentence without an end that goes on and on, well beyond the target length"
+	assert.Equal(t, "A single sentence without an end…", Truncate(long, TruncateOptions{Length: 36}))
+	assert.Equal(t, "A single sentence ...", Truncate(long, TruncateOptions{Length: 24, Ellipsis: " ..."}))
+	assert.Equal(t, "Go is fast.", Truncate("Go  is\nfast. "+strings.Repeat("word ", 50), TruncateOptions{Length: 20}))
+}
+
+func TestTruncate_Bounds(t *testing.T) {
+
+	long := strings.Repeat("word ", 20)
+	assert.Equal(t, " [read more]", Truncate(long, TruncateOptions{Length: 5, Ellipsis: " [read more]"}))
+
+	breaker := func(text string) []int { return []int{-3, 1000} }
+	assert.Equal(t, "word…", Truncate(long, TruncateOptions{Length: 8, Slack: 2000, Sentences: breaker}))
+	assert.Equal(t, strings.TrimSpace(long), Truncate(long, TruncateOptions{Length: 60, Slack: 2000, Sentences: breaker}))
+}
+
+func TestSentences(t *testing.T) {
+
+	assert.Equal(t, []int{36, 46, 67}, Sentences(`Dr. Smith met J. Doe (e.g. at work). "Really?" he asked, 3.5 times.`))
+	assert.Equal(t, []int{6, 12}, Sentences("日本語です。次の文です。"))
+	assert.Equal(t, []int{7, 14}, Sentences("Wait... What?!"))
+
+	german := SentenceBreakerFor("z.B", "bzw", "Nr")
+	assert.Equal(t, []int{27}, german("Das gilt z.B. für Nr. drei. Und"))
+}
+
+func TestExcerpt(t *testing.T) {
+
+	doc := []Block{
+		heading(1, "Title"),
+		paragraph("First sentence of the article."),
+		paragraph("Second sentence, which is a bit longer."),
+	}
+	assert.Equal(t, "First sentence of the article.", Excerpt(doc, TruncateOptions{Length: 50}))
+	assert.Equal(t, "First sentence of the article. Second sentence, which is a bit longer.", Excerpt(doc, TruncateOptions{}))
+}
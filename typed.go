@@ -0,0 +1,422 @@
+package blocks
+
+import (
+	"encoding/json"
+)
+
+// Node is the typed counterpart of a Block. Each block type has its own node
+// struct holding only the fields valid for that type.
+type Node interface {
+	Block() Block
+}
+
+// Nodes is a list of typed nodes, it decodes strapi payloads by their type
+// discriminator.
+type Nodes []Node
+
+type ParagraphNode struct {
+	Children Nodes
+}
+
+type TextNode struct {
+	Text          string
+	Bold          bool
+	Italic        bool
+	Underline     bool
+	StrikeThrough bool
+	Code          bool
+	Superscript   bool
+	Subscript     bool
+	Keyboard      bool
+	Highlight     Mark
+	Color         string
+	Background    string
+	Math          bool
+}
+
+type LinkNode struct {
+	URL      string
+	Title    string
+	Children Nodes
+}
+
+type HeadingNode struct {
+	Level    int
+	Children Nodes
+}
+
+type ListNode struct {
+	Format   ListFormat
+	Children Nodes
+}
+
+type ListItemNode struct {
+	Children Nodes
+}
+
+type ImageNode struct {
+	Image Image
+}
+
+type QuoteNode struct {
+	Children Nodes
+}
+
+type CodeNode struct {
+	Language string
+	// HighlightLines are the line ranges to highlight, see ParseLineRanges
+	HighlightLines string
+	Children       Nodes
+}
+
+type MathNode struct {
+	TeX string
+}
+
+// HTMLNode is a raw html block, see WithRawHTML.
+type HTMLNode struct {
+	HTML string
+}
+
+type GalleryNode struct {
+	Images []Image
+}
+
+// CrossReferenceNode references a figure, see NumberFigures.
+type CrossReferenceNode struct {
+	Target string
+}
+
+// CitationNode cites a reference, see Citations.
+type CitationNode struct {
+	Target string
+}
+
+type BibliographyNode struct {
+	Children Nodes
+}
+
+type BibliographyEntryNode struct {
+	Target   string
+	Children Nodes
+}
+
+type AnnotationNode struct {
+	Children Nodes
+}
+
+type MentionNode struct {
+	Mention Mention
+}
+
+type DefinitionListNode struct {
+	Children Nodes
+}
+
+type DefinitionTermNode struct {
+	Children Nodes
+}
+
+type DefinitionDescriptionNode struct {
+	Children Nodes
+}
+
+type DetailsNode struct {
+	Summary  string
+	Open     bool
+	Children Nodes
+}
+
+type ColumnsNode struct {
+	Children Nodes
+}
+
+type ColumnNode struct {
+	Children Nodes
+}
+
+type AbbreviationNode struct {
+	Title    string
+	Children Nodes
+}
+
+// UnknownNode keeps blocks of unsupported types and invalid blocks, for
+// example an image without media, as they are.
+type UnknownNode struct {
+	Raw Block
+}
+
+// ParseNodes decodes a strapi payload into typed nodes.
+func ParseNodes(raw []byte) (Nodes, error) {
+	var nodes Nodes
+	if err := json.Unmarshal(raw, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (n *Nodes) UnmarshalJSON(data []byte) error {
+	var blocks []Block
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return err
+	}
+	*n = NodesOf(blocks)
+	return nil
+}
+
+func (n Nodes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Blocks())
+}
+
+// Blocks converts the nodes back to flat blocks, for example to render them.
+func (n Nodes) Blocks() []Block {
+	blocks := make([]Block, 0, len(n))
+	for _, node := range n {
+		blocks = append(blocks, node.Block())
+	}
+	return blocks
+}
+
+func NodesOf(blocks []Block) Nodes {
+	nodes := make(Nodes, 0, len(blocks))
+	for _, b := range blocks {
+		nodes = append(nodes, b.Node())
+	}
+	return nodes
+}
+
+// Node returns the typed node for the block.
+func (b Block) Node() Node {
+	children := NodesOf(b.Children)
+	switch b.Type {
+	case BlockTypeParagraph:
+		return ParagraphNode{Children: children}
+	case BlockTypeText:
+		return TextNode{
+			Text:          deref(b.Text),
+			Bold:          deref(b.Bold),
+			Italic:        deref(b.Italic),
+			Underline:     deref(b.Underline),
+			StrikeThrough: deref(b.StrikeThrough),
+			Code:          deref(b.Code),
+			Superscript:   deref(b.Superscript),
+			Subscript:     deref(b.Subscript),
+			Keyboard:      deref(b.Keyboard),
+			Highlight:     deref(b.Highlight),
+			Color:         deref(b.Color),
+			Background:    deref(b.Background),
+			Math:          deref(b.Math),
+		}
+	case BlockTypeLink:
+		return LinkNode{URL: deref(b.URL), Title: deref(b.Title), Children: children}
+	case BlockTypeHeading:
+		if b.Level != nil {
+			return HeadingNode{Level: *b.Level, Children: children}
+		}
+	case BlockTypeList:
+		if b.Format != nil {
+			return ListNode{Format: ListFormat(*b.Format), Children: children}
+		}
+	case BlockTypeListItem:
+		return ListItemNode{Children: children}
+	case BlockTypeImage:
+		if b.Image != nil {
+			return ImageNode{Image: *b.Image}
+		}
+	case BlockTypeQuote:
+		return QuoteNode{Children: children}
+	case BlockTypeCode:
+		return CodeNode{Language: deref(b.Language), HighlightLines: deref(b.HighlightLines), Children: children}
+	case BlockTypeMath:
+		return MathNode{TeX: b.PlainText()}
+	case BlockTypeMention:
+		if b.Mention != nil {
+			return MentionNode{Mention: *b.Mention}
+		}
+	case BlockTypeAbbreviation:
+		return AbbreviationNode{Title: deref(b.Title), Children: children}
+	case BlockTypeDefinitionList:
+		return DefinitionListNode{Children: children}
+	case BlockTypeDefinitionTerm:
+		return DefinitionTermNode{Children: children}
+	case BlockTypeDefinitionDescription:
+		return DefinitionDescriptionNode{Children: children}
+	case BlockTypeColumns:
+		return ColumnsNode{Children: children}
+	case BlockTypeColumn:
+		return ColumnNode{Children: children}
+	case BlockTypeCrossReference:
+		return CrossReferenceNode{Target: deref(b.Target)}
+	case BlockTypeCitation:
+		return CitationNode{Target: deref(b.Target)}
+	case BlockTypeBibliography:
+		return BibliographyNode{Children: children}
+	case BlockTypeBibliographyEntry:
+		return BibliographyEntryNode{Target: deref(b.Target), Children: children}
+	case BlockTypeAnnotation:
+		return AnnotationNode{Children: children}
+	case BlockTypeGallery:
+		return GalleryNode{Images: b.Images}
+	case BlockTypeHTML:
+		return HTMLNode{HTML: deref(b.HTML)}
+	case BlockTypeDetails:
+		return DetailsNode{Summary: deref(b.Summary), Open: deref(b.Open), Children: children}
+	}
+	return UnknownNode{Raw: b}
+}
+
+func (n ParagraphNode) Block() Block {
+	return Block{Type: BlockTypeParagraph, Children: n.Children.Blocks()}
+}
+
+func (n TextNode) Block() Block {
+	b := Block{
+		Type:          BlockTypeText,
+		Text:          &n.Text,
+		Bold:          optional(n.Bold),
+		Italic:        optional(n.Italic),
+		Underline:     optional(n.Underline),
+		StrikeThrough: optional(n.StrikeThrough),
+		Code:          optional(n.Code),
+		Superscript:   optional(n.Superscript),
+		Subscript:     optional(n.Subscript),
+		Keyboard:      optional(n.Keyboard),
+		Math:          optional(n.Math),
+	}
+	if n.Highlight.Active {
+		b.Highlight = &n.Highlight
+	}
+	if n.Color != "" {
+		b.Color = &n.Color
+	}
+	if n.Background != "" {
+		b.Background = &n.Background
+	}
+	return b
+}
+
+func (n LinkNode) Block() Block {
+	b := Block{Type: BlockTypeLink, URL: &n.URL, Children: n.Children.Blocks()}
+	if n.Title != "" {
+		b.Title = &n.Title
+	}
+	return b
+}
+
+func (n HeadingNode) Block() Block {
+	return Block{Type: BlockTypeHeading, Level: &n.Level, Children: n.Children.Blocks()}
+}
+
+func (n ListNode) Block() Block {
+	format := string(n.Format)
+	return Block{Type: BlockTypeList, Format: &format, Children: n.Children.Blocks()}
+}
+
+func (n ListItemNode) Block() Block {
+	return Block{Type: BlockTypeListItem, Children: n.Children.Blocks()}
+}
+
+func (n ImageNode) Block() Block {
+	empty := ""
+	return Block{Type: BlockTypeImage, Image: &n.Image, Children: []Block{{Type: BlockTypeText, Text: &empty}}}
+}
+
+func (n QuoteNode) Block() Block {
+	return Block{Type: BlockTypeQuote, Children: n.Children.Blocks()}
+}
+
+func (n CodeNode) Block() Block {
+	b := Block{Type: BlockTypeCode, Children: n.Children.Blocks()}
+	if n.Language != "" {
+		b.Language = &n.Language
+	}
+	if n.HighlightLines != "" {
+		b.HighlightLines = &n.HighlightLines
+	}
+	return b
+}
+
+func (n MathNode) Block() Block {
+	return Block{Type: BlockTypeMath, Children: []Block{{Type: BlockTypeText, Text: &n.TeX}}}
+}
+
+func (n HTMLNode) Block() Block {
+	return Block{Type: BlockTypeHTML, HTML: &n.HTML, Children: []Block{}}
+}
+
+func (n GalleryNode) Block() Block {
+	return Block{Type: BlockTypeGallery, Images: n.Images, Children: []Block{}}
+}
+
+func (n CrossReferenceNode) Block() Block {
+	return Block{Type: BlockTypeCrossReference, Target: &n.Target, Children: []Block{}}
+}
+
+func (n CitationNode) Block() Block {
+	return Block{Type: BlockTypeCitation, Target: &n.Target, Children: []Block{}}
+}
+
+func (n BibliographyNode) Block() Block {
+	return Block{Type: BlockTypeBibliography, Children: n.Children.Blocks()}
+}
+
+func (n BibliographyEntryNode) Block() Block {
+	return Block{Type: BlockTypeBibliographyEntry, Target: &n.Target, Children: n.Children.Blocks()}
+}
+
+func (n AnnotationNode) Block() Block {
+	return Block{Type: BlockTypeAnnotation, Children: n.Children.Blocks()}
+}
+
+func (n MentionNode) Block() Block {
+	empty := ""
+	return Block{Type: BlockTypeMention, Mention: &n.Mention, Children: []Block{{Type: BlockTypeText, Text: &empty}}}
+}
+
+func (n DefinitionListNode) Block() Block {
+	return Block{Type: BlockTypeDefinitionList, Children: n.Children.Blocks()}
+}
+
+func (n DefinitionTermNode) Block() Block {
+	return Block{Type: BlockTypeDefinitionTerm, Children: n.Children.Blocks()}
+}
+
+func (n DefinitionDescriptionNode) Block() Block {
+	return Block{Type: BlockTypeDefinitionDescription, Children: n.Children.Blocks()}
+}
+
+func (n DetailsNode) Block() Block {
+	return Block{Type: BlockTypeDetails, Summary: &n.Summary, Open: optional(n.Open), Children: n.Children.Blocks()}
+}
+
+func (n ColumnsNode) Block() Block {
+	return Block{Type: BlockTypeColumns, Children: n.Children.Blocks()}
+}
+
+func (n ColumnNode) Block() Block {
+	return Block{Type: BlockTypeColumn, Children: n.Children.Blocks()}
+}
+
+func (n AbbreviationNode) Block() Block {
+	return Block{Type: BlockTypeAbbreviation, Title: &n.Title, Children: n.Children.Blocks()}
+}
+
+func (n UnknownNode) Block() Block {
+	return n.Raw
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
+
+// optional returns nil for false, the way strapi omits unset modifiers
+func optional(v bool) *bool {
+	if !v {
+		return nil
+	}
+	return &v
+}
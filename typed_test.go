@@ -0,0 +1,51 @@
+package blocks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNodes(t *testing.T) {
+
+	nodes, err := ParseNodes(testInput)
+	assert.NoError(t, err)
+
+	assert.IsType(t, ParagraphNode{}, nodes[0])
+	assert.Equal(t, TextNode{Text: "bold", Bold: true}, nodes[1].(ParagraphNode).Children[1])
+	assert.Equal(t, LinkNode{URL: "http://asdf.de", Children: Nodes{TextNode{Text: "links"}}}, nodes[4].(ParagraphNode).Children[1])
+	assert.Equal(t, 2, nodes[6].(HeadingNode).Level)
+	assert.Equal(t, "cdreier_gopher_small.jpg", nodes[8].(ImageNode).Image.AlternativeText)
+	assert.Equal(t, "plaintext", nodes[10].(CodeNode).Language)
+	assert.Equal(t, ListFormatUnordered, nodes[11].(ListNode).Format)
+	assert.IsType(t, ListNode{}, nodes[11].(ListNode).Children[3])
+
+	var blocks []Block
+	json.Unmarshal(testInput, &blocks)
+	assert.Equal(t, Render(blocks), Render(nodes.Blocks()))
+}
+
+func TestParseNodes_Code(t *testing.T) {
+
+	raw := []byte(`[{"type": "code", "language": "go", "highlightLines": "2", "children": [{"type": "text", "text": "a\nb"}]}]`)
+	nodes, err := ParseNodes(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, CodeNode{Language: "go", HighlightLines: "2", Children: Nodes{TextNode{Text: "a\nb"}}}, nodes[0])
+
+	blocks, err := Parse(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, Render(blocks), Render(nodes.Blocks()))
+	assert.Equal(t, blocks[0].HighlightLines, nodes.Blocks()[0].HighlightLines)
+}
+
+func TestParseNodes_Unknown(t *testing.T) {
+
+	nodes, err := ParseNodes([]byte(`[{"type": "heading"}, {"type": "video"}]`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, Nodes{
+		UnknownNode{Raw: Block{Type: BlockTypeHeading}},
+		UnknownNode{Raw: Block{Type: "video"}},
+	}, nodes)
+}
@@ -0,0 +1,88 @@
+package blocks
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Schema is the JSON Schema describing the strapi blocks format.
+//
+//go:embed schema.json
+var Schema []byte
+
+const schemaURL = "https://github.com/cdreier/strapi-blocks-go-renderer/schema.json"
+
+var compiledSchema = func() *jsonschema.Schema {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(Schema))
+	if err != nil {
+		panic(err)
+	}
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(schemaURL, doc); err != nil {
+		panic(err)
+	}
+	return c.MustCompile(schemaURL)
+}()
+
+var validationPrinter = message.NewPrinter(language.English)
+
+// ValidationError is a single schema violation. Path is a JSON pointer to the
+// offending value, the empty string refers to the whole document.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate checks a raw payload against the embedded schema and returns all
+// violations. A valid document returns no errors.
+func Validate(raw []byte) []ValidationError {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return []ValidationError{{Message: err.Error()}}
+	}
+	err = compiledSchema.Validate(doc)
+	if err == nil {
+		return nil
+	}
+	ve, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []ValidationError{{Message: err.Error()}}
+	}
+	errs := []ValidationError{}
+	collectValidationErrors(ve, &errs)
+	return errs
+}
+
+func collectValidationErrors(ve *jsonschema.ValidationError, errs *[]ValidationError) {
+	if len(ve.Causes) > 0 {
+		for _, c := range ve.Causes {
+			collectValidationErrors(c, errs)
+		}
+		return
+	}
+	*errs = append(*errs, ValidationError{
+		Path:    jsonPointer(ve.InstanceLocation),
+		Message: ve.ErrorKind.LocalizedString(validationPrinter),
+	})
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func jsonPointer(tokens []string) string {
+	out := strings.Builder{}
+	for _, t := range tokens {
+		out.WriteString("/")
+		out.WriteString(pointerEscaper.Replace(t))
+	}
+	return out.String()
+}
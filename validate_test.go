@@ -0,0 +1,27 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	assert.Empty(t, Validate(testInput))
+
+	errs := Validate([]byte(`[
+		{"type": "heading", "level": 7, "children": [{"type": "text", "text": "title"}]},
+		{"type": "paragraph", "children": [{"type": "text", "text": 1}]},
+		{"type": "video", "children": []}
+	]`))
+	assert.Equal(t, []ValidationError{
+		{Path: "/0/level", Message: "maximum: got 7, want 6"},
+		{Path: "/1/children/0/text", Message: "got number, want string"},
+		{Path: "/2/type", Message: "value must be one of 'paragraph', 'heading', 'list', 'quote', 'code', 'image', 'math', 'definition-list', 'details', 'columns', 'html', 'gallery', 'bibliography', 'annotation'"},
+	}, errs)
+
+	errs = Validate([]byte(`[{`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "", errs[0].Path)
+}
@@ -0,0 +1,55 @@
+package blocks
+
+import (
+	"html"
+	"regexp"
+)
+
+// MissingVariable decides how placeholders without a value are rendered.
+type MissingVariable int
+
+const (
+	// MissingVariableKeep renders the placeholder as it is
+	MissingVariableKeep MissingVariable = iota
+	// MissingVariableEmpty removes the placeholder
+	MissingVariableEmpty
+	// MissingVariablePlaceholder renders Placeholders.MissingVariable
+	MissingVariablePlaceholder
+)
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+
+// WithVariables replaces {{name}} placeholders in texts with the given
+// values, values are escaped. Every placeholder without a value records a
+// warning. Use Renderer.With to supply the values per request.
+func WithVariables(values map[string]string, missing MissingVariable) Option {
+	return func(r *Renderer) {
+		r.variables = values
+		r.missingVariable = missing
+	}
+}
+
+func (r *Renderer) interpolate(text string) string {
+	return r.substitute(text, html.EscapeString)
+}
+
+// substitute replaces the placeholders with the values passed through escape
+func (r *Renderer) substitute(text string, escape func(string) string) string {
+	if r.variables == nil {
+		return text
+	}
+	return variablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := variablePattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := r.variables[name]; ok {
+			return escape(value)
+		}
+		r.warn(WarningMissingVariable, "no value for variable %q", name)
+		switch r.missingVariable {
+		case MissingVariableEmpty:
+			return ""
+		case MissingVariablePlaceholder:
+			return r.placeholders.MissingVariable
+		}
+		return placeholder
+	})
+}
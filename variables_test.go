@@ -0,0 +1,31 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithVariables(t *testing.T) {
+
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("Hi {{ name }}, ")},
+		{Type: BlockTypeText, Text: ptr("only {{price}} until {{deadline}}"), Bold: ptr(true)},
+	}}}
+
+	base := New()
+	assert.Equal(t, "<p>\n  Hi {{ name }},\n  <strong>\n    only {{price}} until {{deadline}}\n  </strong>\n</p>", base.Render(doc))
+
+	values := map[string]string{"name": "<Ann>", "price": "9 €"}
+	r := base.With(WithVariables(values, MissingVariableKeep))
+	assert.Equal(t, "<p>\n  Hi &lt;Ann&gt;,\n  <strong>\n    only 9 € until {{deadline}}\n  </strong>\n</p>", r.Render(doc))
+	assert.Equal(t, []Warning{{Code: WarningMissingVariable, Message: `no value for variable "deadline"`, Path: []int{0, 1}}}, r.Warnings())
+
+	r = base.With(WithVariables(values, MissingVariableEmpty))
+	assert.Contains(t, r.Render(doc), "only 9 € until\n")
+
+	p := DefaultPlaceholders
+	p.MissingVariable = "soon"
+	r = base.With(WithVariables(values, MissingVariablePlaceholder), WithPlaceholders(p))
+	assert.Contains(t, r.Render(doc), "only 9 € until soon\n")
+}
@@ -0,0 +1,43 @@
+package blocks
+
+// Walk visits every block in depth-first order. The path holds the child
+// indexes leading to the block. Returning false skips the children of the
+// visited block.
+func Walk(blocks []Block, fn func(path []int, b Block) bool) {
+	walk(blocks, nil, fn)
+}
+
+func walk(blocks []Block, parent []int, fn func(path []int, b Block) bool) {
+	for i, b := range blocks {
+		path := append(append([]int{}, parent...), i)
+		if fn(path, b) {
+			walk(b.Children, path, fn)
+		}
+	}
+}
+
+// mapBlocks returns a deep copy of the blocks with fn applied to every
+// block, parents first. The children of the returned block are mapped next.
+func mapBlocks(blocks []Block, fn func(path []int, b Block) (Block, error)) ([]Block, error) {
+	return mapLevel(blocks, nil, fn)
+}
+
+func mapLevel(blocks []Block, parent []int, fn func(path []int, b Block) (Block, error)) ([]Block, error) {
+	if blocks == nil {
+		return nil, nil
+	}
+	out := make([]Block, 0, len(blocks))
+	for i, b := range blocks {
+		path := append(append([]int{}, parent...), i)
+		mapped, err := fn(path, b)
+		if err != nil {
+			return nil, err
+		}
+		mapped.Children, err = mapLevel(mapped.Children, path, fn)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, mapped)
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package blocks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+const WarningMissingText = "missing-text"
+const WarningMissingImage = "missing-image"
+const WarningUnsupportedBlock = "unsupported-block"
+const WarningUnsupportedList = "unsupported-list"
+const WarningBrokenLink = "broken-link"
+const WarningUnresolvedLink = "unresolved-link"
+const WarningMissingMention = "missing-mention"
+const WarningMathError = "math-error"
+const WarningDiagramError = "diagram-error"
+const WarningTemplateError = "template-error"
+const WarningMissingVariable = "missing-variable"
+const WarningLimitExceeded = "limit-exceeded"
+const WarningRenderPanic = "render-panic"
+const WarningUnsupportedComponent = "unsupported-component"
+const WarningInvalidBlurhash = "invalid-blurhash"
+const WarningInvalidColor = "invalid-color"
+const WarningBlockedEmbed = "blocked-embed"
+const WarningUnresolvedReference = "unresolved-reference"
+const WarningInvalidHTML = "invalid-html"
+
+// Warning is a non fatal problem found in the content while rendering.
+type Warning struct {
+	Code    string
+	Message string
+	// Path points to the block in the rendered document, see Walk
+	Path []int
+	// Field is the field of RenderFields the block belongs to
+	Field string
+}
+
+// WithLogger logs every warning with the path of the block, see Walk for the
+// path format.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Renderer) {
+		r.logger = logger
+	}
+}
+
+// Warnings returns the warnings recorded during the last render.
+func (r *Renderer) Warnings() []Warning {
+	return r.warnings
+}
+
+// RenderWithWarnings renders the blocks and returns the warnings of the
+// render, so content problems can be shown to the editors.
+func (r *Renderer) RenderWithWarnings(blocks []Block) (string, []Warning) {
+	out := r.Render(blocks)
+	return out, r.warnings
+}
+
+func RenderWithWarnings(blocks []Block) (string, []Warning) {
+	return New().RenderWithWarnings(blocks)
+}
+
+// begin resets the state of the previous render
+func (r *Renderer) begin() {
+	r.ctx = context.Background()
+	r.path = nil
+	r.types = nil
+	r.field = ""
+	r.ids = 0
+	r.warnings = nil
+	r.errors = nil
+}
+
+func (r *Renderer) warn(code, format string, args ...any) {
+	w := Warning{Code: code, Message: fmt.Sprintf(format, args...), Path: append([]int{}, r.path...), Field: r.field}
+	r.warnings = append(r.warnings, w)
+	if r.logger != nil {
+		r.logger.WarnContext(r.ctx, w.Message, slog.String("code", code), slog.Any("path", w.Path))
+	}
+}
+
+func (r *Renderer) missingText(b Block) string {
+	if !r.nilSafe {
+		panic(fmt.Sprintf("blocks: %s block without text", b.Type))
+	}
+	r.warn(WarningMissingText, "%s block without text", b.Type)
+	return r.placeholders.MissingText
+}
@@ -0,0 +1,47 @@
+package blocks
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		{Type: BlockTypeList, Format: ptr(string(ListFormatUnordered)), Children: []Block{
+			{Type: BlockTypeListItem, Children: []Block{{Type: BlockTypeImage}}},
+		}},
+	}
+
+	logs := bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	New(WithLogger(logger)).Render(doc)
+	assert.Equal(t, "level=WARN msg=\"image block without media\" code=missing-image path=\"[1 0 0]\"\n", logs.String())
+}
+
+func TestRenderWithWarnings(t *testing.T) {
+
+	doc := []Block{
+		paragraph("intro"),
+		{Type: BlockTypeQuote, Children: []Block{{Type: "video"}}},
+	}
+
+	out, warnings := RenderWithWarnings(doc)
+	assert.Equal(t, "<p>\n  intro\n</p>\n<blockquote>\n  unsupported block type\n</blockquote>", out)
+	assert.Equal(t, []Warning{{Code: WarningUnsupportedBlock, Message: `unsupported block type "video"`, Path: []int{1, 0}}}, warnings)
+
+	_, warnings = RenderWithWarnings(doc[:1])
+	assert.Empty(t, warnings)
+}
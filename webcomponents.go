@@ -0,0 +1,129 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const DefaultComponentPrefix = "cms"
+
+// WithWebComponents renders every block as a custom element named after its
+// type, like <cms-paragraph> and <cms-image>, for frontends styling and
+// hydrating blocks as web components. The fields of the blocks become data
+// attributes instead of concrete markup: scalar fields like data-level, the
+// fields of objects like data-image-url, and lists and maps as json. Plain
+// texts are written as escaped text, texts with modifiers as <cms-text
+// data-bold>. An empty prefix uses DefaultComponentPrefix. Classes and
+// custom renderers are not applied, transformers still are.
+func WithWebComponents(prefix string) Option {
+	if prefix == "" {
+		prefix = DefaultComponentPrefix
+	}
+	return func(r *Renderer) {
+		r.componentPrefix = prefix
+	}
+}
+
+func (r *Renderer) componentRender(blocks []Block) string {
+	out := strings.Builder{}
+	for _, b := range blocks {
+		r.componentBlock(&out, b)
+	}
+	return out.String()
+}
+
+func (r *Renderer) componentBlock(out *strings.Builder, b Block) {
+	attrs := componentAttrs(b)
+	if b.Type == BlockTypeText && attrs == "" {
+		out.WriteString(html.EscapeString(deref(b.Text)))
+		return
+	}
+	name := r.componentPrefix + "-" + componentName(string(b.Type))
+	fmt.Fprintf(out, "<%s%s>", name, attrs)
+	if b.Type == BlockTypeText {
+		out.WriteString(html.EscapeString(deref(b.Text)))
+	}
+	for _, c := range b.Children {
+		r.componentBlock(out, c)
+	}
+	fmt.Fprintf(out, "</%s>", name)
+}
+
+// componentAttrs returns the data attributes of the set fields of the block
+func componentAttrs(b Block) string {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		return ""
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return ""
+	}
+	for _, key := range []string{"type", "children", "text"} {
+		delete(fields, key)
+	}
+
+	out := strings.Builder{}
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if obj, ok := fields[key].(map[string]any); ok {
+			for _, sub := range slices.Sorted(maps.Keys(obj)) {
+				// the fields of objects are not optional, zero is unset
+				if obj[sub] == 0.0 {
+					continue
+				}
+				out.WriteString(dataAttr(key+"-"+componentName(sub), obj[sub]))
+			}
+			continue
+		}
+		out.WriteString(dataAttr(key, fields[key]))
+	}
+	return out.String()
+}
+
+// dataAttr renders the value as data attribute, true as boolean attribute.
+// Unset, false and empty values are left out.
+func dataAttr(key string, value any) string {
+	name := "data-" + componentName(key)
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if v {
+			return " " + name
+		}
+		return ""
+	case string:
+		if v == "" {
+			return ""
+		}
+		return fmt.Sprintf(` %s="%s"`, name, html.EscapeString(v))
+	case float64:
+		return fmt.Sprintf(` %s="%v"`, name, v)
+	}
+	raw, err := json.Marshal(value)
+	if err != nil || string(raw) == "{}" || string(raw) == "[]" {
+		return ""
+	}
+	return fmt.Sprintf(` %s="%s"`, name, html.EscapeString(string(raw)))
+}
+
+// componentName turns camel case names into kebab case, like
+// alternativeText into alternative-text
+func componentName(name string) string {
+	out := strings.Builder{}
+	for i, c := range name {
+		if unicode.IsUpper(c) {
+			if i > 0 {
+				out.WriteRune('-')
+			}
+			c = unicode.ToLower(c)
+		}
+		out.WriteRune(c)
+	}
+	return out.String()
+}
@@ -0,0 +1,41 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebComponents(t *testing.T) {
+
+	doc := []Block{
+		heading(2, "Title"),
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("a <b> ")},
+			{Type: BlockTypeText, Text: ptr("bold"), Bold: ptr(true), Italic: ptr(false)},
+			link("/x", "x"),
+		}},
+		{Type: BlockTypeImage, Image: &Image{URL: "/a.png", AlternativeText: "a", Width: 800, Height: 600}},
+	}
+
+	assert.Equal(t, `<cms-heading data-level="2">
+  Title
+</cms-heading>
+<cms-paragraph>
+  a &lt;b&gt;
+  <cms-text data-bold>
+    bold
+  </cms-text>
+  <cms-link data-url="/x">
+    x
+  </cms-link>
+</cms-paragraph>
+<cms-image data-image-alternative-text="a" data-image-height="600" data-image-url="/a.png" data-image-width="800"></cms-image>`,
+		New(WithWebComponents("")).Render(doc))
+}
+
+func TestComponentName(t *testing.T) {
+
+	assert.Equal(t, "alternative-text", componentName("alternativeText"))
+	assert.Equal(t, "definition-list", componentName("definition-list"))
+}
@@ -0,0 +1,109 @@
+package blocks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WhitespaceOptions selects the normalizations of NormalizeWhitespace. Code
+// blocks and code texts are never changed.
+type WhitespaceOptions struct {
+	// Trim removes whitespace at the start and the end of the text of a block,
+	// spaces between adjacent texts are kept.
+	Trim bool
+	// Collapse replaces runs of spaces and tabs with a single space, also
+	// across adjacent texts. Line breaks and the no-break spaces U+00A0 and
+	// U+202F are kept.
+	Collapse bool
+	// DropEmpty removes texts consisting only of whitespace at the start and
+	// the end of a block, between inline siblings they separate words and
+	// are kept. A block left without children keeps an empty text.
+	DropEmpty bool
+}
+
+// spaceRun matches the space separators without the no-break spaces
+var spaceRun = regexp.MustCompile(`[ \t\f\v\x{1680}\x{2000}-\x{200A}\x{205F}\x{3000}]+`)
+
+// NormalizeWhitespace returns a transformer cleaning up the whitespace of
+// texts, for example sloppy editor input.
+func NormalizeWhitespace(opts WhitespaceOptions) Transformer {
+	return TransformerFunc(func(blocks []Block) []Block {
+		return opts.normalize(blocks)
+	})
+}
+
+func (opts WhitespaceOptions) normalize(blocks []Block) []Block {
+	if blocks == nil {
+		return nil
+	}
+	out := make([]Block, 0, len(blocks))
+	for _, b := range blocks {
+		if b.Type != BlockTypeCode {
+			b.Children = opts.normalize(b.Children)
+			b.Children = opts.texts(b.Children)
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// texts normalizes the texts among the children of a block
+func (opts WhitespaceOptions) texts(children []Block) []Block {
+	if len(children) == 0 {
+		return children
+	}
+	from, to := 0, len(children)
+	if opts.DropEmpty {
+		for from < to && isBlankText(children[from]) {
+			from++
+		}
+		for to > from && isBlankText(children[to-1]) {
+			to--
+		}
+	}
+	out := make([]Block, 0, to-from)
+	for _, c := range children[from:to] {
+		if c.Type != BlockTypeText || c.Text == nil || c.HasModifier(ModifierCode) {
+			out = append(out, c)
+			continue
+		}
+		text := *c.Text
+		if opts.Collapse {
+			text = spaceRun.ReplaceAllString(text, " ")
+			if prev := lastText(out); prev != nil && strings.HasSuffix(*prev.Text, " ") {
+				text = strings.TrimPrefix(text, " ")
+			}
+		}
+		c.Text = &text
+		out = append(out, c)
+	}
+
+	if opts.Trim && len(out) > 0 {
+		if first := &out[0]; first.Type == BlockTypeText && first.Text != nil && !first.HasModifier(ModifierCode) {
+			text := strings.TrimLeft(*first.Text, " \t\n\r\f\v")
+			first.Text = &text
+		}
+		if last := &out[len(out)-1]; last.Type == BlockTypeText && last.Text != nil && !last.HasModifier(ModifierCode) {
+			text := strings.TrimRight(*last.Text, " \t\n\r\f\v")
+			last.Text = &text
+		}
+	}
+	if len(out) == 0 {
+		empty := ""
+		out = append(out, Block{Type: BlockTypeText, Text: &empty})
+	}
+	return out
+}
+
+// isBlankText reports whether the block is a text of whitespace only
+func isBlankText(b Block) bool {
+	return b.Type == BlockTypeText && b.Text != nil && !b.HasModifier(ModifierCode) && strings.TrimSpace(*b.Text) == ""
+}
+
+// lastText returns the last block if it is a text
+func lastText(blocks []Block) *Block {
+	if len(blocks) == 0 || blocks[len(blocks)-1].Type != BlockTypeText || blocks[len(blocks)-1].Text == nil {
+		return nil
+	}
+	return &blocks[len(blocks)-1]
+}
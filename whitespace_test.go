@@ -0,0 +1,67 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeWhitespace(t *testing.T) {
+
+	doc := []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("  Hello   big ")},
+			{Type: BlockTypeText, Text: ptr(" world"), Bold: ptr(true)},
+			{Type: BlockTypeText, Text: ptr("   ")},
+			{Type: BlockTypeText, Text: ptr("a  b"), Code: ptr(true)},
+			{Type: BlockTypeText, Text: ptr(" end \n")},
+		}},
+		{Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeText, Text: ptr(" \t ")}}},
+		codeBlock("go", "  if  ok {}  "),
+	}
+
+	got := NormalizeWhitespace(WhitespaceOptions{Trim: true, Collapse: true, DropEmpty: true}).Transform(doc)
+	assert.Equal(t, []Block{
+		{Type: BlockTypeParagraph, Children: []Block{
+			{Type: BlockTypeText, Text: ptr("Hello big ")},
+			{Type: BlockTypeText, Text: ptr("world"), Bold: ptr(true)},
+			{Type: BlockTypeText, Text: ptr(" ")},
+			{Type: BlockTypeText, Text: ptr("a  b"), Code: ptr(true)},
+			{Type: BlockTypeText, Text: ptr(" end")},
+		}},
+		{Type: BlockTypeParagraph, Children: []Block{{Type: BlockTypeText, Text: ptr("")}}},
+		codeBlock("go", "  if  ok {}  "),
+	}, got)
+	assert.Equal(t, "  Hello   big ", *doc[0].Children[0].Text, "input is not modified")
+}
+
+func TestNormalizeWhitespace_collapseOnly(t *testing.T) {
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr(" a  ")},
+		{Type: BlockTypeText, Text: ptr("  ")},
+	}}}
+	got := NormalizeWhitespace(WhitespaceOptions{Collapse: true}).Transform(doc)
+	assert.Equal(t, []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr(" a ")},
+		{Type: BlockTypeText, Text: ptr("")},
+	}}}, got)
+}
+
+func TestNormalizeWhitespace_dropEmpty(t *testing.T) {
+	doc := []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr(" ")},
+		{Type: BlockTypeText, Text: ptr("foo"), Bold: ptr(true)},
+		{Type: BlockTypeText, Text: ptr(" ")},
+		{Type: BlockTypeText, Text: ptr("bar"), Bold: ptr(true)},
+		{Type: BlockTypeText, Text: ptr("10\u00a0km  und\u202f%")},
+		{Type: BlockTypeText, Text: ptr("\t")},
+	}}}
+	got := NormalizeWhitespace(WhitespaceOptions{Collapse: true, DropEmpty: true}).Transform(doc)
+	assert.Equal(t, []Block{{Type: BlockTypeParagraph, Children: []Block{
+		{Type: BlockTypeText, Text: ptr("foo"), Bold: ptr(true)},
+		{Type: BlockTypeText, Text: ptr(" ")},
+		{Type: BlockTypeText, Text: ptr("bar"), Bold: ptr(true)},
+		{Type: BlockTypeText, Text: ptr("10\u00a0km und\u202f%")},
+	}}}, got)
+	assert.Equal(t, "foo bar10\u00a0km und\u202f%", got[0].PlainText())
+}
@@ -0,0 +1,147 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ZoneComponent is a component of a strapi dynamic zone.
+type ZoneComponent struct {
+	// Name is the __component identifier, like "shared.rich-text"
+	Name   string
+	Fields map[string]json.RawMessage
+
+	r *Renderer
+}
+
+// Decode decodes the field into v.
+func (c ZoneComponent) Decode(field string, v any) error {
+	raw, ok := c.Fields[field]
+	if !ok {
+		return fmt.Errorf("blocks: component %s has no field %q", c.Name, field)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// RenderBlocks renders a blocks field of the component with the renderer of
+// the zone, warnings are collected for the whole zone. Every field is
+// checked against the limits of the renderer, like the fields of
+// RenderFields.
+func (c ZoneComponent) RenderBlocks(field string) (string, error) {
+	var blocks []Block
+	if err := c.Decode(field, &blocks); err != nil {
+		return "", err
+	}
+	if err := c.r.checkLimits(blocks); err != nil {
+		return "", err
+	}
+	return c.r.internalRender(c.r.transform(blocks)), nil
+}
+
+// blocksFields returns the names of the fields holding blocks, sorted
+func (c ZoneComponent) blocksFields() []string {
+	fields := []string{}
+	for name, raw := range c.Fields {
+		if isBlocks(raw) {
+			fields = append(fields, name)
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// isBlocks reports whether the json is a non empty list of typed objects
+func isBlocks(raw json.RawMessage) bool {
+	var nodes []struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(raw, &nodes) != nil || len(nodes) == 0 {
+		return false
+	}
+	for _, n := range nodes {
+		if n.Type == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ComponentRenderer renders a component of a dynamic zone.
+type ComponentRenderer interface {
+	RenderComponent(c ZoneComponent) (string, error)
+}
+
+type ComponentRendererFunc func(c ZoneComponent) (string, error)
+
+func (f ComponentRendererFunc) RenderComponent(c ZoneComponent) (string, error) {
+	return f(c)
+}
+
+// WithComponent registers the renderer of a dynamic zone component.
+func WithComponent(name string, renderer ComponentRenderer) Option {
+	return func(r *Renderer) {
+		if r.components == nil {
+			r.components = map[string]ComponentRenderer{}
+		}
+		r.components[name] = renderer
+	}
+}
+
+// RenderZone renders a dynamic zone payload. Components are rendered with
+// their registered renderer, unregistered components with blocks fields
+// render those fields and other components record a warning. Paths of
+// warnings start with the index of the component. The limits and
+// WithRecover apply as for Render, the nesting of the payload is checked
+// before it is decoded like ParseLimited does.
+func (r *Renderer) RenderZone(raw []byte) (out string, err error) {
+	r.begin()
+	defer r.recoverRender(nil, &err)
+	if r.limits.MaxDepth > 0 {
+		// the blocks are nested in the list of components and their objects
+		if err := checkNesting(raw, r.limits.MaxDepth, 2); err != nil {
+			r.warn(WarningLimitExceeded, "%s", err)
+			return "", err
+		}
+	}
+	var components []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &components); err != nil {
+		return "", err
+	}
+	html := strings.Builder{}
+	for i, fields := range components {
+		c := ZoneComponent{Fields: fields, r: r}
+		if err := c.Decode("__component", &c.Name); err != nil {
+			return "", fmt.Errorf("blocks: dynamic zone component %d: %w", i, err)
+		}
+		r.path = append(r.path[:0], i)
+		component, err := r.renderComponent(c)
+		if err != nil {
+			return "", fmt.Errorf("blocks: dynamic zone component %d (%s): %w", i, c.Name, err)
+		}
+		html.WriteString(component)
+	}
+	r.path = r.path[:0]
+	return r.format(r.document(html.String())), nil
+}
+
+func (r *Renderer) renderComponent(c ZoneComponent) (string, error) {
+	if renderer, ok := r.components[c.Name]; ok {
+		return renderer.RenderComponent(c)
+	}
+	fields := c.blocksFields()
+	if len(fields) == 0 {
+		r.warn(WarningUnsupportedComponent, "unsupported component %q", c.Name)
+		return r.placeholders.UnsupportedBlock, nil
+	}
+	out := strings.Builder{}
+	for _, field := range fields {
+		html, err := c.RenderBlocks(field)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(html)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,92 @@
+package blocks
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const zonePayload = `[
+  {"__component": "shared.rich-text", "id": 1, "body": [
+    {"type": "paragraph", "children": [{"type": "text", "text": "intro"}]}
+  ]},
+  {"__component": "shared.quote", "id": 2, "author": "Rob Pike", "text": "Clear is better than clever."},
+  {"__component": "shared.slider", "id": 3, "files": []},
+  {"__component": "shared.rich-text", "id": 4, "body": [{"type": "video"}]}
+]`
+
+func TestRenderZone(t *testing.T) {
+
+	quote := ComponentRendererFunc(func(c ZoneComponent) (string, error) {
+		var author, text string
+		if err := c.Decode("author", &author); err != nil {
+			return "", err
+		}
+		if err := c.Decode("text", &text); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("<blockquote>%s<cite>%s</cite></blockquote>", html.EscapeString(text), html.EscapeString(author)), nil
+	})
+
+	r := New(WithComponent("shared.quote", quote))
+	out, err := r.RenderZone([]byte(zonePayload))
+	assert.NoError(t, err)
+	assert.Equal(t, `<p>
+  intro
+</p>
+<blockquote>
+  Clear is better than clever.
+  <cite>
+    Rob Pike
+  </cite>
+</blockquote>
+unsupported block typeunsupported block type`, out)
+	assert.Equal(t, []Warning{
+		{Code: WarningUnsupportedComponent, Message: `unsupported component "shared.slider"`, Path: []int{2}},
+		{Code: WarningUnsupportedBlock, Message: `unsupported block type "video"`, Path: []int{3, 0}},
+	}, r.Warnings())
+}
+
+func TestRenderZone_Errors(t *testing.T) {
+
+	_, err := New().RenderZone([]byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = New().RenderZone([]byte(`[{"id": 1}]`))
+	assert.EqualError(t, err, `blocks: dynamic zone component 0: blocks: component  has no field "__component"`)
+
+	failing := ComponentRendererFunc(func(c ZoneComponent) (string, error) {
+		return c.RenderBlocks("missing")
+	})
+	_, err = New(WithComponent("shared.rich-text", failing)).RenderZone([]byte(zonePayload))
+	assert.EqualError(t, err, `blocks: dynamic zone component 0 (shared.rich-text): blocks: component shared.rich-text has no field "missing"`)
+}
+
+func TestRenderZone_Limits(t *testing.T) {
+
+	r := New(WithLimits(Limits{MaxTextLength: 3}))
+	out, err := r.RenderZone([]byte(zonePayload))
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Empty(t, out)
+	assert.Equal(t, WarningLimitExceeded, r.Warnings()[0].Code)
+	assert.Equal(t, []int{0}, r.Warnings()[0].Path)
+
+	_, err = New(WithLimits(Limits{MaxDepth: 2})).RenderZone([]byte(zonePayload))
+	assert.NoError(t, err)
+
+	deep := strings.Repeat(`[{"type": "list", "children": `, 40) + `[]` + strings.Repeat(`}]`, 40)
+	_, err = New(WithLimits(Limits{MaxDepth: 32})).RenderZone([]byte(`[{"__component": "shared.rich-text", "body": ` + deep + `}]`))
+	assert.EqualError(t, err, "blocks: document exceeds the max depth of 32")
+
+	panicking := ComponentRendererFunc(func(c ZoneComponent) (string, error) {
+		panic("boom")
+	})
+	r = New(WithRecover(true), WithComponent("shared.quote", panicking))
+	_, err = r.RenderZone([]byte(zonePayload))
+	var renderErr *RenderError
+	assert.ErrorAs(t, err, &renderErr)
+	assert.Equal(t, []int{1}, renderErr.Path)
+}